@@ -2,24 +2,14 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
-	"github.com/AlecAivazis/survey/v2"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
 )
 
-func Checkboxes(label string, opts []string) []string {
-	res := []string{}
-	prompt := &survey.MultiSelect{
-		Message: label,
-		Options: opts,
-	}
-	survey.AskOne(prompt, &res)
-
-	return res
-}
-
 func main() {
-	answers := Checkboxes(
+	answers, err := prompts.Checkboxes(
 		"Which are your favourite programming languages?",
 		[]string{
 			"C",
@@ -44,6 +34,10 @@ func main() {
 			"Perl",
 		},
 	)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(prompts.ExitCode(err))
+	}
 	s := strings.Join(answers, ", ")
 	fmt.Println("Oh, I see! You like", s)
 }
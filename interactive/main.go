@@ -2,15 +2,17 @@ package main
 
 import (
 	"fmt"
-	"syscall"
 
-	"golang.org/x/term"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
 )
 
 func main() {
-	if term.IsTerminal(int(syscall.Stdin)) {
+	switch i := prompts.Interactivity(); i.Mode {
+	case prompts.Interactive:
 		fmt.Println("Terminal is interactive! You're good to use prompts!")
-	} else {
-		fmt.Println("Terminal is not interactive! Consider using flags or environment variables!")
+	case prompts.ForcedInteractive:
+		fmt.Printf("Terminal is interactive because %s! Prompts will ask on it!\n", i.Reason)
+	default:
+		fmt.Printf("Terminal is not interactive because %s! Consider using flags or environment variables!\n", i.Reason)
 	}
 }
@@ -0,0 +1,114 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// WithAccessible makes a prompt work with screen readers: instead of
+// redrawing the screen as keys are pressed, it writes its question and
+// reads the answer as a line, as it does when the input is piped. Select
+// and Checkboxes list their options numbered, to be answered by number
+// or by name, and the answer is repeated once taken. Spinners and progress
+// bars print their messages instead of animating them.
+//
+// This is the default when the ACCESSIBLE environment variable is set to
+// anything but the empty string.
+func WithAccessible() Option {
+	return optionFunc(func(c *config) { c.accessible = true })
+}
+
+// accessibleByDefault reports whether the environment asks for
+// accessible prompts.
+func accessibleByDefault() bool {
+	return os.Getenv("ACCESSIBLE") != ""
+}
+
+// A secretModel is a lineModel whose answers are not echoed as typed.
+type secretModel interface {
+	lineModel
+	secret() bool
+}
+
+// runAccessible asks for the answer of m a line at a time on the terminal
+// whose input and output are in and out, as runLines does for piped input.
+// Rejected answers are asked again, and the answer taken is written out.
+func runAccessible(ctx context.Context, c *config, m lineModel, in *os.File, out io.Writer) error {
+	ctx, interrupted, stop := withInterrupt(ctx)
+	defer stop()
+	lines, max := linesOf(in), c.maxRetries
+	for attempts := 0; ; {
+		fmt.Fprint(out, m.prompt())
+		var s string
+		var err error
+		if sm, ok := m.(secretModel); ok && sm.secret() {
+			var b []byte
+			b, err = term.ReadPassword(int(in.Fd()))
+			// The line break is not echoed either.
+			fmt.Fprintln(out)
+			s = string(b)
+			Wipe(b)
+		} else {
+			s, err = lines.readLine(ctx)
+		}
+		var done bool
+		if em, ok := m.(eofModel); ok && err == io.EOF {
+			done, err = em.eof()
+		} else if err != nil {
+			if interrupted() {
+				return ErrInterrupted
+			}
+			return err
+		} else if done, err = m.answer(s); err != nil {
+			// Tell users what is wrong without the package name.
+			msg := strings.TrimPrefix(c.theme.errorText(err), "prompts: ")
+			fmt.Fprintln(out, c.theme.render(c.theme.Error, msg))
+			if attempts++; max > 0 && attempts >= max {
+				return ErrTooManyAttempts
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if done {
+			for _, l := range m.view().lines {
+				fmt.Fprintln(out, l)
+			}
+			return nil
+		}
+	}
+}
+
+// numberedOptions lists options numbered from one, as accessible prompts
+// show them, with why those in disabled cannot be picked.
+func numberedOptions(t *Theme, options []string, disabled map[string]string) string {
+	var b strings.Builder
+	for i, o := range options {
+		fmt.Fprintf(&b, "  %d) %s", i+1, o)
+		if reason, ok := disabled[o]; ok {
+			if reason == "" {
+				reason = "not available"
+			}
+			b.WriteString(" " + t.render(t.Hint, "("+t.text(reason)+")"))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// optionNumber returns the index of the option numbered s among n
+// in numberedOptions, or -1 if s is not one of their numbers.
+func optionNumber(s string, n int) int {
+	i, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || i < 1 || i > n {
+		return -1
+	}
+	return i - 1
+}
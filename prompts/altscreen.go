@@ -0,0 +1,18 @@
+package prompts
+
+// WithAltScreen makes a prompt take the whole terminal while it is asked,
+// as editors do, drawing on the alternate screen of the terminal so that
+// moving through big prompts such as TreeSelect, DirTree or the steps of
+// a Wizard leaves nothing in the scrollback. Once the prompt ends, the
+// screen it was asked from is restored, with its answer below it as usual.
+// Terminals without an alternate screen draw on their only one.
+func WithAltScreen() Option {
+	return optionFunc(func(c *config) { c.altScreen = true })
+}
+
+// Escape sequences switching to the alternate screen, with the cursor
+// at its top, and back to the screen the cursor was on.
+const (
+	altScreenOn  = "\x1b[?1049h\x1b[H"
+	altScreenOff = "\x1b[?1049l"
+)
@@ -0,0 +1,156 @@
+package prompts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WithKey sets the key identifying a prompt in answers files.
+// It defaults to the label of the prompt.
+func WithKey(key string) Option {
+	return optionFunc(func(c *config) { c.key = key })
+}
+
+// WithAnswersFile makes a prompt take its answer from a file of
+// pre-recorded answers instead of asking for it, so that a multi-prompt
+// flow can run unattended. The file holds a JSON object, if its name ends
+// with ".json", or a YAML mapping otherwise, from prompt keys to answers:
+//
+//	name: Gopher
+//	continue: yes
+//	languages: [Go, Rust]
+//
+// A prompt whose key is missing from the file returns an error wrapping
+// ErrNoAnswer, unless WithAskMissing is given too.
+func WithAnswersFile(path string) Option {
+	return optionFunc(func(c *config) { c.answersFile = path })
+}
+
+// WithAskMissing makes a prompt ask for its answer as usual when it is
+// missing from the answers file, instead of failing.
+func WithAskMissing() Option {
+	return optionFunc(func(c *config) { c.askMissing = true })
+}
+
+// resultModel is a model whose answer can be recorded.
+type resultModel interface {
+	model
+	// result returns the answer as it is stored in answers files.
+	result() interface{}
+}
+
+// listModel is a lineModel that can also take a list as its answer.
+type listModel interface {
+	lineModel
+	answerList(list []string) error
+}
+
+// textModel is a lineModel whose answer is read over several lines,
+// which a preset answer gives all at once.
+type textModel interface {
+	lineModel
+	// answerText takes the whole answer from s.
+	answerText(s string) error
+}
+
+// presetAnswer gives m the answer preset by c, if any,
+// and reports whether it did.
+func presetAnswer(c *config, m lineModel) (bool, error) {
+	if v, ok := envAnswer(c); ok {
+		return true, presetValue(m, v, "$"+c.envVar)
+	}
+	if c.answersFile == "" {
+		return false, nil
+	}
+	answers, err := loadAnswers(c.answersFile)
+	if err != nil {
+		return true, err
+	}
+	v, ok := answers[c.key]
+	if !ok {
+		if c.askMissing {
+			return false, nil
+		}
+		return true, fmt.Errorf("%w for %q in %s", ErrNoAnswer, c.key, c.answersFile)
+	}
+	return true, presetValue(m, v, c.answersFile)
+}
+
+// presetValue gives m the answer v read from source.
+func presetValue(m lineModel, v interface{}, source string) error {
+	var s string
+	switch v := v.(type) {
+	case []interface{}:
+		list := make([]string, len(v))
+		for i, e := range v {
+			list[i] = fmt.Sprint(e)
+		}
+		if lm, ok := m.(listModel); ok {
+			err := lm.answerList(list)
+			var invalid *invalidAnswer
+			if errors.As(err, &invalid) {
+				return fmt.Errorf("prompts: invalid answer in %s: %w", source, err)
+			}
+			return err
+		}
+		s = strings.Join(list, ",")
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		list := make([]interface{}, len(keys))
+		for i, k := range keys {
+			list[i] = k + "=" + fmt.Sprint(v[k])
+		}
+		return presetValue(m, list, source)
+	case time.Time:
+		// YAML reads unquoted dates and times as such.
+		s = v.Format(time.RFC3339)
+	case nil:
+	default:
+		s = fmt.Sprint(v)
+	}
+	var err error
+	done := true
+	if tm, ok := m.(textModel); ok {
+		err = tm.answerText(s)
+	} else {
+		done, err = m.answer(s)
+	}
+	var invalid *invalidAnswer
+	if errors.As(err, &invalid) {
+		return fmt.Errorf("prompts: invalid answer in %s: %w", source, err)
+	}
+	if err == nil && !done {
+		err = fmt.Errorf("prompts: invalid answer %q in %s", s, source)
+	}
+	return err
+}
+
+// loadAnswers reads an answers file.
+func loadAnswers(path string) (map[string]interface{}, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	answers := map[string]interface{}{}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(b, &answers)
+	} else {
+		err = yaml.Unmarshal(b, &answers)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("prompts: reading answers file: %w", err)
+	}
+	return answers, nil
+}
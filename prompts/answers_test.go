@@ -0,0 +1,80 @@
+package prompts_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+)
+
+// writeAnswers writes an answers file named name holding s.
+func writeAnswers(t *testing.T, name, s string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(s), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestAnswersFile(t *testing.T) {
+	tests := []struct {
+		name, file string
+	}{
+		{"answers.yaml", "name: Gopher\ncontinue: yes\nlanguages: [Go, Rust]\n"},
+		{"answers.json", `{"name": "Gopher", "continue": true, "languages": ["Go", "Rust"]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := prompts.WithAnswersFile(writeAnswers(t, tt.name, tt.file))
+			// Nothing is read: the input would end at once.
+			in := prompts.WithInput(strings.NewReader(""))
+			name, err := prompts.Input("Name?", prompts.WithKey("name"), file, in)
+			if err != nil || name != "Gopher" {
+				t.Errorf("Input() = %q, %v, want \"Gopher\", nil", name, err)
+			}
+			ok, err := prompts.Confirm("Continue?", false, prompts.WithKey("continue"), file, in)
+			if err != nil || !ok {
+				t.Errorf("Confirm() = %v, %v, want true, nil", ok, err)
+			}
+			langs, err := prompts.Checkboxes("Languages?", []string{"Go", "Python", "Rust"}, prompts.WithKey("languages"), file, in)
+			if err != nil || strings.Join(langs, ",") != "Go,Rust" {
+				t.Errorf("Checkboxes() = %q, %v, want [Go Rust], nil", langs, err)
+			}
+		})
+	}
+}
+
+func TestAnswersFileLabelKey(t *testing.T) {
+	path := writeAnswers(t, "answers.yaml", "Name?: Gopher\n")
+	got, err := prompts.Input("Name?", prompts.WithAnswersFile(path), prompts.WithInput(strings.NewReader("")))
+	if err != nil || got != "Gopher" {
+		t.Fatalf("Input() = %q, %v, want \"Gopher\", nil", got, err)
+	}
+}
+
+func TestAnswersFileMissing(t *testing.T) {
+	path := writeAnswers(t, "answers.yaml", "name: Gopher\n")
+	_, err := prompts.Input("Age?", prompts.WithKey("age"), prompts.WithAnswersFile(path),
+		prompts.WithInput(strings.NewReader("")))
+	if !errors.Is(err, prompts.ErrNoAnswer) {
+		t.Fatalf("Input() returned %v, want ErrNoAnswer", err)
+	}
+	got, err := prompts.Input("Age?", prompts.WithKey("age"), prompts.WithAnswersFile(path), prompts.WithAskMissing(),
+		prompts.WithInput(strings.NewReader("42\n")), prompts.WithOutput(&strings.Builder{}))
+	if err != nil || got != "42" {
+		t.Fatalf("Input() with WithAskMissing = %q, %v, want \"42\", nil", got, err)
+	}
+}
+
+func TestAnswersFileInvalid(t *testing.T) {
+	path := writeAnswers(t, "answers.yaml", "continue: maybe\n")
+	_, err := prompts.Confirm("Continue?", false, prompts.WithKey("continue"), prompts.WithAnswersFile(path),
+		prompts.WithInput(strings.NewReader("")))
+	if want := "prompts: invalid answer in " + path + ": please answer yes or no"; err == nil || err.Error() != want {
+		t.Fatalf("Confirm() returned %v, want %s", err, want)
+	}
+}
@@ -0,0 +1,311 @@
+package prompts
+
+import (
+	"context"
+	"time"
+)
+
+// defaultDebounce is how long AutocompleteAsync waits for the text to stop
+// changing when no Debounce is configured.
+const defaultDebounce = 150 * time.Millisecond
+
+// AutocompleteOptions configures Autocomplete and AutocompleteAsync.
+type AutocompleteOptions struct {
+	// PageSize is the number of suggestions shown at a time,
+	// 7 if it is not positive.
+	PageSize int
+	// Debounce is how long AutocompleteAsync waits for the text to stop
+	// changing before loading suggestions for it, 150ms if it is not
+	// positive.
+	Debounce time.Duration
+}
+
+func (o AutocompleteOptions) apply(c *config) { c.autocomplete = o }
+
+// Autocomplete asks for a line of text as Input does, listing below it the
+// suggestions returned by suggest for the text typed so far, with the
+// characters matching it in the Match style of the theme. Given
+// WithMatcher, only the suggestions it matches are listed. Up and Down
+// highlight a suggestion and Tab takes it, or the first one, as the text
+// typed so far. Enter answers with the highlighted suggestion, if any, and
+// with the typed text otherwise. The options of Input apply as well.
+//
+// If the standard input is not a terminal, the answer is the next line
+// read from it, without suggestions.
+//
+// It returns ErrInterrupted if the user presses Ctrl+C and io.EOF if the
+// user presses Ctrl+D on an empty line or the input ends.
+func Autocomplete(label string, suggest func(input string) []string, opts ...Option) (string, error) {
+	return AutocompleteContext(context.Background(), label, suggest, opts...)
+}
+
+// AutocompleteContext is like Autocomplete but gives up when ctx is done,
+// returning ctx.Err().
+func AutocompleteContext(ctx context.Context, label string, suggest func(input string) []string, opts ...Option) (string, error) {
+	c := newConfig(label, opts)
+	m := newAutocompleteModel(c, label)
+	m.suggest = suggest
+	m.refresh()
+	if err := run(ctx, c, m); err != nil {
+		return "", err
+	}
+	return m.value(), nil
+}
+
+// SuggestionsLoader loads the suggestions of AutocompleteAsync for the text
+// typed so far, e.g. by querying an API. It should give up when ctx is done.
+type SuggestionsLoader func(ctx context.Context, input string) ([]string, error)
+
+// AutocompleteAsync is like Autocomplete but loads the suggestions with
+// load in the background, once the text has not changed for the Debounce
+// of AutocompleteOptions, showing a spinner after the text while it does.
+// Loading suggestions for a text that has since changed is given up and
+// what it returns dropped; until the suggestions for the new text arrive,
+// those for the text before are listed. If loading fails, the error is
+// shown below the text instead of suggestions, and the text can still be
+// answered.
+func AutocompleteAsync(label string, load SuggestionsLoader, opts ...Option) (string, error) {
+	return AutocompleteAsyncContext(context.Background(), label, load, opts...)
+}
+
+// AutocompleteAsyncContext is like AutocompleteAsync but gives up when ctx
+// is done, returning ctx.Err(). The context given to load is done as well
+// when the prompt ends before the suggestions are loaded.
+func AutocompleteAsyncContext(ctx context.Context, label string, load SuggestionsLoader, opts ...Option) (string, error) {
+	c := newConfig(label, opts)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	m := newAutocompleteModel(c, label)
+	m.load, m.ctx = load, ctx
+	m.debounce = c.autocomplete.Debounce
+	if m.debounce <= 0 {
+		m.debounce = defaultDebounce
+	}
+	m.frames = SpinnerDots
+	if c.theme.ascii {
+		m.frames = SpinnerLine
+	}
+	// Load the suggestions for the text there is to begin with at once.
+	m.due = time.Now()
+	if err := run(ctx, c, m); err != nil {
+		return "", err
+	}
+	return m.value(), nil
+}
+
+func newAutocompleteModel(c *config, label string) *autocompleteModel {
+	m := &autocompleteModel{
+		inputModel: newInputModel(label, c),
+		matcher:    c.matcher,
+		pageSize:   c.autocomplete.PageSize,
+		cursor:     -1,
+	}
+	if m.pageSize <= 0 {
+		m.pageSize = defaultPageSize
+	}
+	m.maxPage = m.pageSize
+	return m
+}
+
+type autocompleteModel struct {
+	*inputModel
+	suggest     func(string) []string
+	suggestions []string
+	// matcher, if set, filters the suggestions.
+	matcher Matcher
+	// cursor is the index of the highlighted suggestion, or -1.
+	cursor   int
+	top      int
+	pageSize int
+	// maxPage is the page size configured, as for optionList.
+	maxPage int
+	// typed is the text the suggestions are for.
+	typed string
+
+	// load, if set, loads the suggestions in the background instead of
+	// suggest, with ctx, once the text has not changed for debounce.
+	// due is when to start loading, if it is to.
+	load     SuggestionsLoader
+	ctx      context.Context
+	debounce time.Duration
+	due      time.Time
+	// loading receives the outcome of the loading under way, if any,
+	// which cancel gives up.
+	loading chan suggested
+	cancel  context.CancelFunc
+	// frames are those of the spinner shown while loading since start.
+	frames []string
+	start  time.Time
+	frame  int
+	// loadErr is why the last suggestions could not be loaded.
+	loadErr error
+}
+
+// suggested is the outcome of a SuggestionsLoader for text.
+type suggested struct {
+	text        string
+	suggestions []string
+	err         error
+}
+
+// refresh asks for suggestions for the current text,
+// or schedules loading them.
+func (m *autocompleteModel) refresh() {
+	m.cursor, m.top = -1, 0
+	if m.load != nil {
+		m.giveUp()
+		m.due = time.Now().Add(m.debounce)
+		return
+	}
+	m.typed = m.line.String()
+	m.suggestions = m.matching(m.suggest(m.typed))
+}
+
+// matching returns the suggestions matching the typed text,
+// if there is a matcher to say so.
+func (m *autocompleteModel) matching(suggestions []string) []string {
+	if m.matcher == nil {
+		return suggestions
+	}
+	return filterMatching(m.matcher, []rune(m.typed), suggestions)
+}
+
+// giveUp gives up the loading under way, if any.
+func (m *autocompleteModel) giveUp() {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	m.loading, m.cancel = nil, nil
+}
+
+// startLoading loads the suggestions for the current text in the
+// background.
+func (m *autocompleteModel) startLoading() {
+	ctx, cancel := context.WithCancel(m.ctx)
+	loading := make(chan suggested, 1)
+	load, text := m.load, m.line.String()
+	go func() {
+		suggestions, err := load(ctx, text)
+		loading <- suggested{text: text, suggestions: suggestions, err: err}
+	}()
+	m.loading, m.cancel = loading, cancel
+	m.start, m.frame = time.Now(), 0
+}
+
+func (m *autocompleteModel) tick() (bool, error) {
+	if m.load == nil {
+		return false, nil
+	}
+	changed := false
+	if !m.due.IsZero() && !time.Now().Before(m.due) {
+		m.due = time.Time{}
+		m.startLoading()
+		changed = true
+	}
+	if m.loading == nil {
+		return changed, nil
+	}
+	select {
+	case s := <-m.loading:
+		m.giveUp()
+		m.typed, m.loadErr = s.text, s.err
+		m.suggestions = nil
+		if s.err == nil {
+			m.suggestions = m.matching(s.suggestions)
+		}
+		m.cursor, m.top = -1, 0
+		return true, nil
+	default:
+	}
+	frame := spinnerFrame(m.start, len(m.frames))
+	changed = changed || frame != m.frame
+	m.frame = frame
+	return changed, nil
+}
+
+// take makes the i-th suggestion the current text.
+func (m *autocompleteModel) take(i int) {
+	s := m.suggestions[i]
+	m.line = line{buf: []rune(s), pos: len([]rune(s))}
+}
+
+func (m *autocompleteModel) update(k key) (bool, error) {
+	if n := len(m.suggestions); n > 0 {
+		switch {
+		case bound(m.keys.Up, k):
+			m.cursor = (m.cursor + n) % (n + 1)
+			if m.cursor == n {
+				m.cursor = -1
+			}
+		case bound(m.keys.Down, k):
+			m.cursor = (m.cursor+2)%(n+1) - 1
+		case bound(m.keys.Complete, k):
+			if m.cursor < 0 {
+				m.cursor = 0
+			}
+			m.take(m.cursor)
+			m.refresh()
+			return false, nil
+		case bound(m.keys.Submit, k) && m.cursor >= 0:
+			m.take(m.cursor)
+			return m.inputModel.update(k)
+		default:
+			return m.edit(k)
+		}
+		if m.cursor >= 0 {
+			m.top = scrollTop(m.top, m.cursor, m.pageSize)
+		}
+		return false, nil
+	}
+	return m.edit(k)
+}
+
+func (m *autocompleteModel) keyHelp() ([]keyAction, bool) {
+	actions, _ := m.inputModel.keyHelp()
+	return append([]keyAction{
+		act("move through the suggestions", m.keys.Up, m.keys.Down),
+		act("take the suggestion", m.keys.Complete),
+	}, actions...), true
+}
+
+// edit passes k on to the line, refreshing the suggestions
+// if it changes the text.
+func (m *autocompleteModel) edit(k key) (bool, error) {
+	done, err := m.inputModel.update(k)
+	if !done && err == nil && m.line.String() != m.typed {
+		m.refresh()
+	}
+	return done, err
+}
+
+func (m *autocompleteModel) resize(width, height int) {
+	m.pageSize = fitPage(m.maxPage, height, 1)
+	if m.cursor >= 0 {
+		m.top = scrollTop(m.top, m.cursor, m.pageSize)
+	}
+}
+
+func (m *autocompleteModel) view() frame {
+	f := m.inputModel.view()
+	if m.done {
+		return f
+	}
+	lines := append([]string{}, f.lines[0])
+	if m.loading != nil {
+		lines[0] += " " + m.theme.render(m.theme.Highlight, m.frames[m.frame])
+	}
+	if m.loadErr != nil {
+		lines = append(lines, m.theme.errorLine(m.loadErr))
+	}
+	end := m.top + m.pageSize
+	if end > len(m.suggestions) {
+		end = len(m.suggestions)
+	}
+	typed := []rune(m.typed)
+	for i := m.top; i < end; i++ {
+		positions, _, _ := match(m.matcher, typed, m.suggestions[i])
+		lines = append(lines, m.theme.option(m.suggestions[i], "", positions, i == m.cursor))
+	}
+	f.lines = append(lines, f.lines[1:]...)
+	return f
+}
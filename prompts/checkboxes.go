@@ -0,0 +1,346 @@
+package prompts
+
+import (
+	"context"
+	"strings"
+)
+
+// CheckboxesOptions configures Checkboxes.
+type CheckboxesOptions struct {
+	// PageSize is the number of options shown at once.
+	// Zero means the default page size.
+	PageSize int
+	// MinSelected and MaxSelected are the fewest and the most options
+	// that can be picked. Zero means no limit.
+	MinSelected, MaxSelected int
+	// Default lists the options checked to begin with,
+	// such as those picked the last time.
+	Default []string
+	// Descriptions maps options to short descriptions shown next to them
+	// and Help to longer help shown for the option under the cursor
+	// when the Help key is pressed.
+	Descriptions, Help map[string]string
+	// Disabled maps the options that are shown but cannot be checked or
+	// unchecked to why not, which is shown next to them if it is not empty.
+	Disabled map[string]string
+	// Groups maps options to the name of the group they are listed under,
+	// after the options in no group. The header of a group is collapsed
+	// and expanded with Left and Right or Space.
+	Groups map[string]string
+	// Preview, if set, returns a preview of the option under the cursor,
+	// such as the contents of a file, which is shown below the list in at
+	// most PreviewHeight lines, or 10 if it is zero. It is called once for
+	// each option previewed.
+	Preview       func(option string) string
+	PreviewHeight int
+	// Columns is the most columns options are laid out in, as for
+	// SelectOptions.
+	Columns int
+}
+
+// check returns why n picked options are not allowed, if they are not.
+func (o CheckboxesOptions) check(n int) error {
+	switch {
+	case o.MinSelected > 0 && n < o.MinSelected:
+		if o.MinSelected == 1 {
+			return &invalidAnswer{err: errorf("pick at least 1 option")}
+		}
+		return &invalidAnswer{err: errorf("pick at least %d options", o.MinSelected)}
+	case o.MaxSelected > 0 && n > o.MaxSelected:
+		if o.MaxSelected == 1 {
+			return &invalidAnswer{err: errorf("pick at most 1 option")}
+		}
+		return &invalidAnswer{err: errorf("pick at most %d options", o.MaxSelected)}
+	}
+	return nil
+}
+
+func (o CheckboxesOptions) apply(c *config) { c.checkboxes = o }
+
+// Checkboxes asks to pick any number of the options using the label
+// and returns the picked ones. The cursor is moved with the up and down
+// arrows and by a page with PageUp and PageDown, Space checks or unchecks
+// the option under it, Alt+A, Alt+N and Alt+I check, uncheck or invert
+// all the options matching the filter, in collapsed groups too, and Enter
+// accepts the checked options, provided there are as many as
+// CheckboxesOptions allows and they pass the validators given with
+// WithValidator, which are given the options joined by commas. Typing
+// filters the options as it does in Select; options checked before they
+// are filtered out stay checked. ? shows help as it does in Select.
+//
+// If the standard input is not a terminal, the next line read from it
+// must list the picked options separated by commas. An empty line keeps
+// the options checked by default.
+//
+// It returns ErrInterrupted if the user presses Ctrl+C.
+func Checkboxes(label string, options []string, opts ...Option) ([]string, error) {
+	return CheckboxesContext(context.Background(), label, options, opts...)
+}
+
+// CheckboxesContext is like Checkboxes but gives up when ctx is done,
+// returning ctx.Err().
+func CheckboxesContext(ctx context.Context, label string, options []string, opts ...Option) ([]string, error) {
+	m, err := checkboxes(ctx, label, options, opts)
+	if err != nil {
+		return nil, err
+	}
+	return m.picked(), nil
+}
+
+// checkboxes asks as CheckboxesContext does and returns the model
+// with the options checked.
+func checkboxes(ctx context.Context, label string, options []string, opts []Option) (*checkboxesModel, error) {
+	c := newConfig(label, opts)
+	m := newCheckboxesModel(c, label, options)
+	if err := run(ctx, c, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func newCheckboxesModel(c *config, label string, options []string) *checkboxesModel {
+	m := &checkboxesModel{
+		label:      label,
+		theme:      &c.theme,
+		keys:       &c.keymap,
+		opts:       c.checkboxes,
+		list:       newOptionList(&c.theme, &c.keymap, options, c.checkboxes.PageSize),
+		checked:    make([]bool, len(options)),
+		numbered:   c.accessible,
+		validators: c.validators,
+	}
+	m.list.descriptions, m.list.help = c.checkboxes.Descriptions, c.checkboxes.Help
+	m.list.disabled = c.checkboxes.Disabled
+	m.list.preview, m.list.previewHeight = c.checkboxes.Preview, c.checkboxes.PreviewHeight
+	m.list.maxColumns = c.checkboxes.Columns
+	m.list.filter.matcher = c.matcher
+	m.list.markWidth = textWidth(c.theme.Checked)
+	if w := textWidth(c.theme.Unchecked); w > m.list.markWidth {
+		m.list.markWidth = w
+	}
+	m.list.init(c.checkboxes.Groups)
+	m.defaults = c.checkboxes.Default
+	if initial, ok := c.initial.([]string); ok {
+		m.defaults = initial
+	}
+	m.checkDefaults(0)
+	return m
+}
+
+// checkDefaults checks the options checked to begin with among those
+// from the index from on.
+func (m *checkboxesModel) checkDefaults(from int) {
+	for len(m.checked) < len(m.list.options) {
+		m.checked = append(m.checked, false)
+	}
+	for _, s := range m.defaults {
+		if i := findOption(m.list.options[from:], s); i >= 0 {
+			m.checked[from+i] = true
+		}
+	}
+}
+
+// tick adds the options streamed since it was last called.
+func (m *checkboxesModel) tick() (bool, error) {
+	from := len(m.list.options)
+	changed := m.list.tick()
+	m.checkDefaults(from)
+	return changed, nil
+}
+
+// wait waits for the options still to be streamed.
+func (m *checkboxesModel) wait() {
+	from := len(m.list.options)
+	m.list.drain()
+	m.checkDefaults(from)
+}
+
+type checkboxesModel struct {
+	label      string
+	theme      *Theme
+	keys       *Keymap
+	opts       CheckboxesOptions
+	list       optionList
+	checked    []bool
+	validators []Validator
+	// defaults are the options checked to begin with.
+	defaults []string
+	// numbered is set as for selectModel.
+	numbered bool
+	// err is why the last answer was rejected.
+	err  error
+	done bool
+}
+
+func (m *checkboxesModel) resize(width, height int) {
+	m.list.resize(width, height, 1)
+}
+
+func (m *checkboxesModel) picked() []string {
+	return m.pickedOf(m.checked)
+}
+
+// pickedOf returns the options checked in checked.
+func (m *checkboxesModel) pickedOf(checked []bool) []string {
+	res := []string{}
+	for i, o := range m.list.options {
+		if checked[i] {
+			res = append(res, o)
+		}
+	}
+	return res
+}
+
+func (m *checkboxesModel) result() interface{} {
+	return m.picked()
+}
+
+func (m *checkboxesModel) prompt() string {
+	m.wait()
+	if m.numbered {
+		hint := m.theme.text("Type the numbers of your choices, separated by commas:")
+		if picked := m.picked(); len(picked) > 0 {
+			hint = m.theme.textf("Type the numbers of your choices, separated by commas, or nothing to keep %s:", strings.Join(picked, ", "))
+		}
+		return m.theme.label(m.label) + "\n" + numberedOptions(m.theme, m.list.options, m.list.disabled) +
+			m.theme.render(m.theme.Hint, hint) + " "
+	}
+	if picked := m.picked(); len(picked) > 0 {
+		return m.theme.label(m.label) + " (" + strings.Join(picked, ", ") + ") "
+	}
+	return m.theme.label(m.label) + " "
+}
+
+// answer checks the options listed in s, separated by commas,
+// or keeps those checked to begin with if s is empty.
+func (m *checkboxesModel) answer(s string) (bool, error) {
+	m.wait()
+	if strings.TrimSpace(s) == "" {
+		return true, m.answerList(m.picked())
+	}
+	var list []string
+	for _, o := range strings.Split(s, ",") {
+		if o = strings.TrimSpace(o); o != "" {
+			if i := optionNumber(o, len(m.list.options)); m.numbered && findOption(m.list.options, o) < 0 && i >= 0 {
+				o = m.list.options[i]
+			}
+			list = append(list, o)
+		}
+	}
+	return true, m.answerList(list)
+}
+
+func (m *checkboxesModel) answerList(list []string) error {
+	m.wait()
+	checked := make([]bool, len(m.list.options))
+	n := 0
+	for _, o := range list {
+		i := findOption(m.list.options, o)
+		if i < 0 {
+			return notAnOption(o)
+		}
+		if !checked[i] && !m.checked[i] {
+			if err := m.list.disabledReason(m.list.options[i]); err != nil {
+				return err
+			}
+		}
+		if !checked[i] {
+			checked[i] = true
+			n++
+		}
+	}
+	if err := m.opts.check(n); err != nil {
+		return err
+	}
+	if err := validate(m.validators, strings.Join(m.pickedOf(checked), ",")); err != nil {
+		return err
+	}
+	m.checked, m.done = checked, true
+	return nil
+}
+
+func (m *checkboxesModel) captures(k key) bool {
+	return m.list.captures(k)
+}
+
+func (m *checkboxesModel) rejected() error {
+	return m.err
+}
+
+func (m *checkboxesModel) update(k key) (bool, error) {
+	switch {
+	case bound(m.keys.Submit, k):
+		picked := m.picked()
+		if m.err = m.opts.check(len(picked)); m.err == nil {
+			m.err = validate(m.validators, strings.Join(picked, ","))
+		}
+		m.done = m.err == nil
+	case bound(m.keys.Toggle, k) && m.list.toggleGroup():
+	case bound(m.keys.Toggle, k):
+		if i := m.list.current(); i >= 0 {
+			m.checked[i] = !m.checked[i]
+			m.err = nil
+		}
+	case bound(m.keys.CheckAll, k):
+		m.checkAll(func(bool) bool { return true })
+	case bound(m.keys.UncheckAll, k):
+		m.checkAll(func(bool) bool { return false })
+	case bound(m.keys.InvertAll, k):
+		m.checkAll(func(checked bool) bool { return !checked })
+	default:
+		m.list.update(k)
+	}
+	return m.done, nil
+}
+
+func (m *checkboxesModel) optionHelp() []string {
+	return m.list.optionHelp()
+}
+
+func (m *checkboxesModel) keyHelp() ([]keyAction, bool) {
+	km := m.keys
+	return m.list.keyActions(
+		act("check or uncheck the option", km.Toggle),
+		act("check all the options matching the filter", km.CheckAll),
+		act("uncheck all the options matching the filter", km.UncheckAll),
+		act("invert the options matching the filter", km.InvertAll),
+		act("accept the checked options", km.Submit),
+	), false
+}
+
+func (m *checkboxesModel) click(line, col int) (bool, error) {
+	if m.list.click(line, col) {
+		i := m.list.current()
+		m.checked[i] = !m.checked[i]
+		m.err = nil
+	}
+	return false, nil
+}
+
+// checkAll sets whether each of the options matching the filter is
+// checked to what check returns for whether it is, including those of
+// collapsed groups.
+func (m *checkboxesModel) checkAll(check func(checked bool) bool) {
+	for _, match := range m.list.filter.matches {
+		if i := match.index; m.list.enabled(i) {
+			m.checked[i] = check(m.checked[i])
+		}
+	}
+	m.err = nil
+}
+
+func (m *checkboxesModel) view() frame {
+	if m.done {
+		return frame{lines: []string{m.theme.answered(m.label, strings.Join(m.picked(), ", "))}}
+	}
+	lines := m.list.lines(m.label, func(i int) string {
+		if m.checked[i] {
+			return m.theme.Checked
+		}
+		return m.theme.Unchecked
+	})
+	if m.err != nil {
+		lines = append(lines, m.theme.errorLine(m.err))
+	}
+	return frame{lines: lines}
+}
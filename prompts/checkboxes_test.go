@@ -0,0 +1,139 @@
+package prompts_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/prompttest"
+	rules "github.com/tidalmigrations/interactive-cli-prompts/prompts/validate"
+)
+
+func TestCheckboxes(t *testing.T) {
+	colors := []string{"red", "green", "blue"}
+	tests := []struct {
+		name string
+		opts prompts.CheckboxesOptions
+		keys string
+		want string
+	}{
+		{"none", prompts.CheckboxesOptions{}, prompttest.Enter, ""},
+		{"space", prompts.CheckboxesOptions{}, prompttest.Space + prompttest.Down + prompttest.Down + prompttest.Space + prompttest.Enter, "red,blue"},
+		{"uncheck", prompts.CheckboxesOptions{}, prompttest.Space + prompttest.Space + prompttest.Enter, ""},
+		{"default", prompts.CheckboxesOptions{Default: []string{"green"}}, prompttest.Enter, "green"},
+		{"default unchecked", prompts.CheckboxesOptions{Default: []string{"green"}}, prompttest.Down + prompttest.Space + prompttest.Enter, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term := prompttest.New(t)
+			res := prompttest.Start(term, func(o prompts.Option) ([]string, error) {
+				return prompts.Checkboxes("Colors?", colors, tt.opts, o)
+			})
+			term.WaitFor("blue")
+			term.Send(tt.keys)
+			got, err := res.Wait()
+			if err != nil || strings.Join(got, ",") != tt.want {
+				t.Fatalf("Checkboxes() = %q, %v, want [%s], nil", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckboxesScreen(t *testing.T) {
+	term := prompttest.New(t)
+	res := prompttest.Start(term, func(o prompts.Option) ([]string, error) {
+		return prompts.Checkboxes("Colors?", []string{"red", "green", "blue"}, o)
+	})
+	term.WaitFor("blue")
+	if got, want := term.Screen(), "Colors?\n> [ ] red\n  [ ] green\n  [ ] blue"; got != want {
+		t.Errorf("screen shows\n%s\nwant\n%s", got, want)
+	}
+	term.Send(prompttest.Space)
+	term.WaitFor("> [x] red")
+	term.Send(prompttest.Enter)
+	if _, err := res.Wait(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckboxesMinSelected(t *testing.T) {
+	term := prompttest.New(t)
+	res := prompttest.Start(term, func(o prompts.Option) ([]string, error) {
+		return prompts.Checkboxes("Colors?", []string{"red", "green", "blue"},
+			prompts.CheckboxesOptions{MinSelected: 2}, o)
+	})
+	term.WaitFor("blue")
+	term.Send(prompttest.Space + prompttest.Enter)
+	term.WaitFor("pick at least 2 options")
+	term.Send(prompttest.Down + prompttest.Space + prompttest.Enter)
+	got, err := res.Wait()
+	if err != nil || strings.Join(got, ",") != "red,green" {
+		t.Fatalf("Checkboxes() = %q, %v, want [red green], nil", got, err)
+	}
+}
+
+func TestCheckboxesCheckAllCollapsed(t *testing.T) {
+	term := prompttest.New(t)
+	res := prompttest.Start(term, func(o prompts.Option) ([]string, error) {
+		return prompts.Checkboxes("Colors?", []string{"red", "orange", "blue"},
+			prompts.CheckboxesOptions{Groups: map[string]string{"red": "warm", "orange": "warm", "blue": "cool"}}, o)
+	})
+	term.WaitFor("blue")
+	// Collapse the group of red, then check all.
+	term.Send(prompttest.Left)
+	term.WaitFor("> + warm")
+	term.Send("\x1ba" + prompttest.Enter)
+	got, err := res.Wait()
+	if err != nil || strings.Join(got, ",") != "red,orange,blue" {
+		t.Fatalf("Checkboxes() = %q, %v, want [red orange blue], nil", got, err)
+	}
+}
+
+func TestCheckboxesValidator(t *testing.T) {
+	var joined []string
+	record := func(s string) error {
+		joined = append(joined, s)
+		return nil
+	}
+	term := prompttest.New(t)
+	res := prompttest.Start(term, func(o prompts.Option) ([]string, error) {
+		return prompts.Checkboxes("Colors?", []string{"red", "green", "blue"},
+			prompts.WithValidator(record), prompts.WithValidator(rules.Required()), o)
+	})
+	term.WaitFor("blue")
+	term.Send(prompttest.Enter)
+	term.WaitFor("a value is required")
+	term.Send(prompttest.Space + prompttest.Down + prompttest.Down + prompttest.Space + prompttest.Enter)
+	got, err := res.Wait()
+	if err != nil || strings.Join(got, ",") != "red,blue" {
+		t.Fatalf("Checkboxes() = %q, %v, want [red blue], nil", got, err)
+	}
+	if want := []string{"", "red,blue"}; strings.Join(joined, "|") != strings.Join(want, "|") {
+		t.Errorf("validator was given %q, want %q", joined, want)
+	}
+}
+
+func TestCheckboxesValidatorLines(t *testing.T) {
+	var out strings.Builder
+	got, err := prompts.Checkboxes("Colors?", []string{"red", "green", "blue"},
+		prompts.WithValidator(rules.Required()),
+		prompts.WithInput(strings.NewReader("\ngreen\n")), prompts.WithOutput(&out))
+	if err != nil || strings.Join(got, ",") != "green" {
+		t.Fatalf("Checkboxes() = %q, %v, want [green], nil", got, err)
+	}
+	if want := "Colors? \na value is required\nColors? \n"; out.String() != want {
+		t.Errorf("output is %q, want %q", out.String(), want)
+	}
+}
+
+func TestCheckboxesLines(t *testing.T) {
+	var out strings.Builder
+	got, err := prompts.Checkboxes("Colors?", []string{"red", "green", "blue"},
+		prompts.WithInput(strings.NewReader("red, purple\nred, Blue\n")), prompts.WithOutput(&out))
+	if err != nil || strings.Join(got, ",") != "red,blue" {
+		t.Fatalf("Checkboxes() = %q, %v, want [red blue], nil", got, err)
+	}
+	if want := "Colors? \n\"purple\" is not one of the options\nColors? \n"; out.String() != want {
+		t.Errorf("output is %q, want %q", out.String(), want)
+	}
+}
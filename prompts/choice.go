@@ -0,0 +1,59 @@
+package prompts
+
+import "context"
+
+// Choice is an option of SelectChoice and CheckboxesChoices, which show
+// the Label, with the Description next to it, and return the Value.
+type Choice[T any] struct {
+	Label       string
+	Value       T
+	Description string
+}
+
+// SelectChoice is like SelectItem but returns the Value of the picked
+// choice, which can be of any type, such as an enum or an ID.
+func SelectChoice[T any](label string, choices []Choice[T], opts ...Option) (T, error) {
+	return SelectChoiceContext(context.Background(), label, choices, opts...)
+}
+
+// SelectChoiceContext is like SelectChoice but gives up when ctx is done,
+// returning ctx.Err().
+func SelectChoiceContext[T any](ctx context.Context, label string, choices []Choice[T], opts ...Option) (T, error) {
+	i, err := selectItem(ctx, label, choiceItems(choices), opts)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return choices[i].Value, nil
+}
+
+// CheckboxesChoices is like CheckboxesItems but returns the Values of
+// the picked choices, which can be of any type.
+func CheckboxesChoices[T any](label string, choices []Choice[T], opts ...Option) ([]T, error) {
+	return CheckboxesChoicesContext(context.Background(), label, choices, opts...)
+}
+
+// CheckboxesChoicesContext is like CheckboxesChoices but gives up when
+// ctx is done, returning ctx.Err().
+func CheckboxesChoicesContext[T any](ctx context.Context, label string, choices []Choice[T], opts ...Option) ([]T, error) {
+	checked, err := checkboxesItems(ctx, label, choiceItems(choices), opts)
+	if err != nil {
+		return nil, err
+	}
+	picked := []T{}
+	for i, ok := range checked {
+		if ok {
+			picked = append(picked, choices[i].Value)
+		}
+	}
+	return picked, nil
+}
+
+// choiceItems returns the items showing choices.
+func choiceItems[T any](choices []Choice[T]) []Item {
+	items := make([]Item, len(choices))
+	for i, c := range choices {
+		items[i] = Item{Label: c.Label, Description: c.Description}
+	}
+	return items
+}
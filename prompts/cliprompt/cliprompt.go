@@ -0,0 +1,237 @@
+// Package cliprompt asks for the required flags of urfave/cli commands that
+// are missing from their command line, as the cobraprompt package does for
+// cobra commands:
+//
+//	cliprompt.Wrap(cmd, cliprompt.Options{Secret: []string{"token"}})
+//
+// Each flag is asked for with a prompt fitting its type: Confirm for
+// booleans, Duration for durations, Tags for lists and Input, checking
+// the answer, for the rest. When the input of the app is not a terminal,
+// or the session is not interactive, as in CI, nothing is asked and the
+// command fails as it would without the prompts, reporting the flags that
+// are missing.
+package cliprompt
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+	rules "github.com/tidalmigrations/interactive-cli-prompts/prompts/validate"
+)
+
+// Options configures Wrap and WrapApp.
+type Options struct {
+	// Secret names the flags asked for as passwords, without echoing
+	// the answer.
+	Secret []string
+	// Prompt are given to every prompt, along with WithKey the name of
+	// its flag, so that answers files can answer them.
+	Prompt []prompts.Option
+}
+
+// Wrap makes cmd and its subcommands ask for their required flags that are
+// not set on the command line before running their Before and Action.
+// Since cli checks required flags before running Before, the flags asked
+// for are no longer marked as required; the command checks them itself
+// when it cannot ask.
+func Wrap(cmd *cli.Command, o Options) {
+	cmd.Before = wrap(&cmd.Flags, cmd.Before, o)
+	for _, sub := range cmd.Subcommands {
+		Wrap(sub, o)
+	}
+}
+
+// WrapApp is like Wrap for the flags of app and all its commands.
+func WrapApp(app *cli.App, o Options) {
+	app.Before = wrap(&app.Flags, app.Before, o)
+	for _, cmd := range app.Commands {
+		Wrap(cmd, o)
+	}
+}
+
+// wrap returns the Before asking for the required flags of flags,
+// then running before, if any.
+func wrap(flags *[]cli.Flag, before cli.BeforeFunc, o Options) cli.BeforeFunc {
+	var required []cli.Flag
+	for _, f := range *flags {
+		if unrequire(f) {
+			required = append(required, f)
+		}
+	}
+	if len(required) == 0 {
+		return before
+	}
+	return func(cCtx *cli.Context) error {
+		if err := askMissing(cCtx, required, o); err != nil {
+			return err
+		}
+		if before != nil {
+			return before(cCtx)
+		}
+		return nil
+	}
+}
+
+// unrequire makes f no longer required and reports whether it was.
+func unrequire(f cli.Flag) bool {
+	rf, ok := f.(cli.RequiredFlag)
+	if !ok || !rf.IsRequired() {
+		return false
+	}
+	// Every flag of cli has a Required field, which no method sets.
+	v := reflect.ValueOf(f)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return false
+	}
+	r := v.Elem().FieldByName("Required")
+	if !r.IsValid() || r.Kind() != reflect.Bool || !r.CanSet() {
+		return false
+	}
+	r.SetBool(false)
+	return true
+}
+
+// askMissing asks for the flags that are not set and sets them to the
+// answers, or, if the input is not a terminal or the session reading it
+// is not interactive, fails as cli does.
+func askMissing(cCtx *cli.Context, flags []cli.Flag, o Options) error {
+	var missing []cli.Flag
+	var names []string
+	for _, f := range flags {
+		if !isSet(cCtx, f) {
+			missing = append(missing, f)
+			names = append(names, f.Names()[0])
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	in, ok := cCtx.App.Reader.(*os.File)
+	if !ok || !term.IsTerminal(int(in.Fd())) || !prompts.InteractivityOf(in).Interactive() {
+		if len(names) == 1 {
+			return fmt.Errorf("Required flag %q not set", names[0])
+		}
+		return fmt.Errorf("Required flags %q not set", strings.Join(names, ", "))
+	}
+	ctx := cCtx.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	opts := []prompts.Option{prompts.WithInput(in)}
+	// Leave the output set with SetOutput alone unless the app has its own.
+	if w := cCtx.App.ErrWriter; w != nil && w != os.Stderr {
+		opts = append(opts, prompts.WithOutput(w))
+	}
+	opts = append(opts, o.Prompt...)
+	for _, f := range missing {
+		name := f.Names()[0]
+		values, err := ask(ctx, cCtx, f, secret(o, f), append(opts, prompts.WithKey(name)))
+		if err != nil {
+			return err
+		}
+		for _, v := range values {
+			if err := cCtx.Set(name, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isSet reports whether f is set by any of its names.
+func isSet(cCtx *cli.Context, f cli.Flag) bool {
+	for _, name := range f.Names() {
+		if cCtx.IsSet(strings.TrimSpace(name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// secret reports whether f is one of the Secret flags of o.
+func secret(o Options, f cli.Flag) bool {
+	for _, s := range o.Secret {
+		for _, name := range f.Names() {
+			if s == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ask asks for the value of f with the prompt fitting its type and
+// returns what to set it to, one value for each item of a list.
+func ask(ctx context.Context, cCtx *cli.Context, f cli.Flag, secret bool, opts []prompts.Option) ([]string, error) {
+	name := f.Names()[0]
+	label, def := name, ""
+	if df, ok := f.(cli.DocGenerationFlag); ok {
+		if df.GetUsage() != "" {
+			label = df.GetUsage()
+		}
+		def = df.GetValue()
+	}
+	hint := "--" + name
+	if secret {
+		s, err := prompts.PasswordPromptContext(ctx, label, append(opts, prompts.PasswordOptions{Hint: hint})...)
+		return []string{s}, err
+	}
+	input := prompts.InputOptions{Default: def, Hint: hint}
+	switch f := f.(type) {
+	case *cli.BoolFlag:
+		b, err := prompts.ConfirmContext(ctx, label, f.Value, opts...)
+		return []string{strconv.FormatBool(b)}, err
+	case *cli.DurationFlag:
+		d, err := prompts.DurationContext(ctx, label, opts...)
+		return []string{d.String()}, err
+	case cli.DocGenerationSliceFlag:
+		if f.IsSliceFlag() {
+			return prompts.TagsContext(ctx, label, opts...)
+		}
+	case *cli.IntFlag, *cli.Int64Flag:
+		input.Default = zeroless(def)
+		opts = append(opts, prompts.WithValidator(rules.IntRange(math.MinInt, math.MaxInt)))
+	case *cli.UintFlag, *cli.Uint64Flag:
+		input.Default = zeroless(def)
+		opts = append(opts, prompts.WithValidator(rules.IntRange(0, math.MaxInt)))
+	case *cli.Float64Flag:
+		input.Default = zeroless(def)
+		opts = append(opts, prompts.WithValidator(isFloat))
+	}
+	if input.Default == "" {
+		opts = append([]prompts.Option{prompts.WithValidator(rules.Required())}, opts...)
+	}
+	// Check the answer as the flag would, so that it is asked again
+	// rather than failing once set.
+	opts = append(opts, prompts.WithValidator(func(s string) error {
+		return cCtx.Set(name, s)
+	}), input)
+	s, err := prompts.InputContext(ctx, label, opts...)
+	return []string{s}, err
+}
+
+// zeroless returns the default value s of a number flag, unless it is
+// zero, which required numbers are not meant to default to.
+func zeroless(s string) string {
+	if f, err := strconv.ParseFloat(s, 64); err == nil && f == 0 {
+		return ""
+	}
+	return s
+}
+
+// isFloat rejects answers that are not numbers.
+func isFloat(s string) error {
+	if _, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err != nil {
+		return &rules.Error{Format: "must be a number"}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package cliprompt_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/cliprompt"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/prompttest"
+)
+
+// newApp returns an app with a required --region flag and a --replicas
+// flag, which asks for them on term and stores them in flags.
+func newApp(term *prompttest.Terminal, flags map[string]interface{}) *cli.App {
+	app := &cli.App{
+		Name: "deploy",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "region", Usage: "Region?", Required: true},
+			&cli.IntFlag{Name: "replicas", Usage: "Replicas?", Required: true},
+		},
+		Action: func(cCtx *cli.Context) error {
+			flags["region"], flags["replicas"] = cCtx.String("region"), cCtx.Int("replicas")
+			return nil
+		},
+		Reader:    term.File(),
+		Writer:    term.File(),
+		ErrWriter: term.File(),
+	}
+	cliprompt.WrapApp(app, cliprompt.Options{})
+	return app
+}
+
+func TestWrapApp(t *testing.T) {
+	term := prompttest.New(t)
+	flags := map[string]interface{}{}
+	app := newApp(term, flags)
+	res := prompttest.Start(term, func(prompts.Option) (bool, error) {
+		return true, app.Run([]string{"deploy"})
+	})
+	term.WaitFor("Region? --region")
+	term.Send("eu-west-1" + prompttest.Enter)
+	term.WaitFor("Replicas? --replicas")
+	term.Send("two" + prompttest.Enter)
+	term.WaitFor("must be a whole number")
+	term.Send(strings.Repeat(prompttest.Backspace, 3) + "3" + prompttest.Enter)
+	if _, err := res.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if flags["region"] != "eu-west-1" || flags["replicas"] != 3 {
+		t.Errorf("flags set to %v, want region eu-west-1 and 3 replicas", flags)
+	}
+}
+
+func TestWrapAppSet(t *testing.T) {
+	term := prompttest.New(t)
+	flags := map[string]interface{}{}
+	app := newApp(term, flags)
+	res := prompttest.Start(term, func(prompts.Option) (bool, error) {
+		return true, app.Run([]string{"deploy", "--region", "us-east-1"})
+	})
+	term.WaitFor("Replicas?")
+	if strings.Contains(term.Screen(), "Region?") {
+		t.Errorf("asked for --region, which is set:\n%s", term.Screen())
+	}
+	term.Send("1" + prompttest.Enter)
+	if _, err := res.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if flags["region"] != "us-east-1" {
+		t.Errorf("--region set to %v, want us-east-1", flags["region"])
+	}
+}
+
+func TestWrapAppNotInteractive(t *testing.T) {
+	tests := []struct {
+		name, env, value string
+	}{
+		{"dumb terminal", "TERM", "dumb"},
+		{"CI", "CI", "true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(tt.env, tt.value)
+			term := prompttest.New(t)
+			app := newApp(term, map[string]interface{}{})
+			res := prompttest.Start(term, func(prompts.Option) (bool, error) {
+				return true, app.Run([]string{"deploy"})
+			})
+			_, err := res.Wait()
+			if err == nil || err.Error() != `Required flags "region, replicas" not set` {
+				t.Fatalf("Run() returned %v, want the error of cli for the missing flags", err)
+			}
+		})
+	}
+}
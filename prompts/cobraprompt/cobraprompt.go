@@ -0,0 +1,157 @@
+// Package cobraprompt asks for the required flags of cobra commands that
+// are missing from their command line, giving existing programs an
+// interactive mode for free:
+//
+//	cmd.MarkFlagRequired("region")
+//	cmd.PreRunE = cobraprompt.PreRunE
+//
+// Each flag is asked for with a prompt fitting its type: Confirm for
+// booleans, Duration for durations, Tags for lists and Input, checking
+// the answer, for the rest. When the input of the command is not
+// a terminal, or the session is not interactive, as in CI, nothing is
+// asked and cobra fails as usual, reporting the flags that are missing.
+package cobraprompt
+
+import (
+	"context"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+	rules "github.com/tidalmigrations/interactive-cli-prompts/prompts/validate"
+)
+
+// secretAnnotation marks the flags asked for as passwords.
+const secretAnnotation = "cobraprompt_secret"
+
+// MarkSecret makes the flag of flags with the name be asked for as
+// a password, without echoing the answer.
+func MarkSecret(flags *pflag.FlagSet, name string) error {
+	return flags.SetAnnotation(name, secretAnnotation, []string{"true"})
+}
+
+// PreRunE asks for the flags of cmd that are missing, as AskMissing does,
+// and is meant to be set as the PreRunE or PersistentPreRunE of commands.
+func PreRunE(cmd *cobra.Command, args []string) error {
+	return AskMissing(cmd)
+}
+
+// AskMissing asks for the required flags of cmd that are not set on its
+// command line, and sets them to the answers. The prompts are given opts,
+// and WithKey the name of their flag, so that answers files can answer
+// them. It does nothing if the input of cmd is not a terminal or
+// prompts.InteractivityOf finds the session reading it is not interactive.
+func AskMissing(cmd *cobra.Command, opts ...prompts.Option) error {
+	return AskMissingContext(cmd.Context(), cmd, opts...)
+}
+
+// AskMissingContext is like AskMissing but gives up when ctx is done,
+// returning ctx.Err().
+func AskMissingContext(ctx context.Context, cmd *cobra.Command, opts ...prompts.Option) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	in, ok := cmd.InOrStdin().(*os.File)
+	if !ok || !term.IsTerminal(int(in.Fd())) || !prompts.InteractivityOf(in).Interactive() {
+		return nil
+	}
+	head := []prompts.Option{prompts.WithInput(in)}
+	// Leave the output set with SetOutput alone unless cmd has its own.
+	if w := cmd.ErrOrStderr(); w != os.Stderr {
+		head = append(head, prompts.WithOutput(w))
+	}
+	opts = append(head, opts...)
+	var missing []*pflag.Flag
+	flags := cmd.Flags()
+	flags.VisitAll(func(f *pflag.Flag) {
+		if required(f) && !f.Changed {
+			missing = append(missing, f)
+		}
+	})
+	for _, f := range missing {
+		values, err := ask(ctx, f, append(opts, prompts.WithKey(f.Name)))
+		if err != nil {
+			return err
+		}
+		for _, v := range values {
+			if err := flags.Set(f.Name, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// required reports whether f is marked as required.
+func required(f *pflag.Flag) bool {
+	v := f.Annotations[cobra.BashCompOneRequiredFlag]
+	return len(v) > 0 && v[0] == "true"
+}
+
+// ask asks for the value of f with the prompt fitting its type and
+// returns what to set it to, one value for each item of a list.
+func ask(ctx context.Context, f *pflag.Flag, opts []prompts.Option) ([]string, error) {
+	label := f.Usage
+	if label == "" {
+		label = f.Name
+	}
+	hint := "--" + f.Name
+	if v := f.Annotations[secretAnnotation]; len(v) > 0 && v[0] == "true" {
+		s, err := prompts.PasswordPromptContext(ctx, label, append(opts, prompts.PasswordOptions{Hint: hint})...)
+		return []string{s}, err
+	}
+	input := prompts.InputOptions{Default: f.DefValue, Hint: hint}
+	switch typ := f.Value.Type(); {
+	case typ == "bool":
+		def, _ := strconv.ParseBool(f.DefValue)
+		b, err := prompts.ConfirmContext(ctx, label, def, opts...)
+		return []string{strconv.FormatBool(b)}, err
+	case typ == "duration":
+		d, err := prompts.DurationContext(ctx, label, opts...)
+		return []string{d.String()}, err
+	case strings.HasSuffix(typ, "Slice") || strings.HasSuffix(typ, "Array"):
+		return prompts.TagsContext(ctx, label, opts...)
+	case strings.HasPrefix(typ, "int"):
+		input.Default = zeroless(f.DefValue)
+		opts = append(opts, prompts.WithValidator(rules.IntRange(math.MinInt, math.MaxInt)))
+	case strings.HasPrefix(typ, "uint"):
+		input.Default = zeroless(f.DefValue)
+		opts = append(opts, prompts.WithValidator(rules.IntRange(0, math.MaxInt)))
+	case strings.HasPrefix(typ, "float"):
+		input.Default = zeroless(f.DefValue)
+		opts = append(opts, prompts.WithValidator(isFloat))
+	}
+	if input.Default == "" {
+		opts = append([]prompts.Option{prompts.WithValidator(rules.Required())}, opts...)
+	}
+	// Check the answer as the flag would, so that it is asked again
+	// rather than failing once set.
+	opts = append(opts, prompts.WithValidator(func(s string) error {
+		return f.Value.Set(s)
+	}), input)
+	s, err := prompts.InputContext(ctx, label, opts...)
+	return []string{s}, err
+}
+
+// zeroless returns the default value s of a number flag, unless it is
+// zero, which required numbers are not meant to default to.
+func zeroless(s string) string {
+	if f, err := strconv.ParseFloat(s, 64); err == nil && f == 0 {
+		return ""
+	}
+	return s
+}
+
+// isFloat rejects answers that are not numbers.
+func isFloat(s string) error {
+	if _, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err != nil {
+		return &rules.Error{Format: "must be a number"}
+	}
+	return nil
+}
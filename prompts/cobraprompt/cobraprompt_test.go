@@ -0,0 +1,96 @@
+package cobraprompt_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/cobraprompt"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/prompttest"
+)
+
+// newCommand returns a command with a required --region flag and
+// a --replicas flag, which asks for them on term.
+func newCommand(term *prompttest.Terminal, args ...string) (*cobra.Command, *string, *int) {
+	var region string
+	var replicas int
+	cmd := &cobra.Command{
+		Use:     "deploy",
+		PreRunE: cobraprompt.PreRunE,
+		RunE:    func(*cobra.Command, []string) error { return nil },
+	}
+	cmd.Flags().StringVar(&region, "region", "", "Region?")
+	cmd.Flags().IntVar(&replicas, "replicas", 0, "Replicas?")
+	cmd.MarkFlagRequired("region")
+	cmd.MarkFlagRequired("replicas")
+	cmd.SetArgs(args)
+	cmd.SetIn(term.File())
+	cmd.SetOut(term.File())
+	cmd.SetErr(term.File())
+	cmd.SilenceUsage = true
+	return cmd, &region, &replicas
+}
+
+func TestAskMissing(t *testing.T) {
+	term := prompttest.New(t)
+	cmd, region, replicas := newCommand(term)
+	res := prompttest.Start(term, func(prompts.Option) (bool, error) {
+		return true, cmd.Execute()
+	})
+	term.WaitFor("Region? --region")
+	term.Send("eu-west-1" + prompttest.Enter)
+	term.WaitFor("Replicas? --replicas")
+	term.Send("two" + prompttest.Enter)
+	term.WaitFor("must be a whole number")
+	term.Send(strings.Repeat(prompttest.Backspace, 3) + "3" + prompttest.Enter)
+	if _, err := res.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if *region != "eu-west-1" || *replicas != 3 {
+		t.Errorf("flags set to --region=%q --replicas=%d, want eu-west-1 and 3", *region, *replicas)
+	}
+}
+
+func TestAskMissingSet(t *testing.T) {
+	term := prompttest.New(t)
+	cmd, region, _ := newCommand(term, "--region", "us-east-1")
+	res := prompttest.Start(term, func(prompts.Option) (bool, error) {
+		return true, cmd.Execute()
+	})
+	term.WaitFor("Replicas?")
+	if strings.Contains(term.Screen(), "Region?") {
+		t.Errorf("asked for --region, which is set:\n%s", term.Screen())
+	}
+	term.Send("1" + prompttest.Enter)
+	if _, err := res.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if *region != "us-east-1" {
+		t.Errorf("--region set to %q, want us-east-1", *region)
+	}
+}
+
+func TestAskMissingNotInteractive(t *testing.T) {
+	tests := []struct {
+		name, env, value string
+	}{
+		{"dumb terminal", "TERM", "dumb"},
+		{"CI", "CI", "true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(tt.env, tt.value)
+			term := prompttest.New(t)
+			cmd, _, _ := newCommand(term)
+			res := prompttest.Start(term, func(prompts.Option) (bool, error) {
+				return true, cmd.Execute()
+			})
+			_, err := res.Wait()
+			if err == nil || !strings.Contains(err.Error(), `required flag(s) "region", "replicas" not set`) {
+				t.Fatalf("Execute() returned %v, want cobra's error for the missing flags", err)
+			}
+		})
+	}
+}
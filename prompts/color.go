@@ -0,0 +1,177 @@
+package prompts
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Color is a terminal color: one of the 16 ANSI colors by name, such as
+// "cyan" or "brightred", an index into the 256-color palette, such as
+// "208", or a 24-bit RGB color, such as "#ff8700". The zero Color, like
+// any invalid one, keeps the default color of the terminal.
+type Color string
+
+var colorNames = map[string]int{
+	"black": 0, "red": 1, "green": 2, "yellow": 3,
+	"blue": 4, "magenta": 5, "cyan": 6, "white": 7,
+}
+
+// sgr returns the SGR parameters setting c as the foreground color,
+// degraded to the closest one the output can show.
+func (c Color) sgr(colors colorProfile) string {
+	if colors < color16 {
+		return ""
+	}
+	s := string(c)
+	if n, ok := colorNames[s]; ok {
+		return strconv.Itoa(30 + n)
+	}
+	if n, ok := colorNames[strings.TrimPrefix(s, "bright")]; ok {
+		return strconv.Itoa(90 + n)
+	}
+	if len(s) == 7 && s[0] == '#' {
+		rgb, err := strconv.ParseUint(s[1:], 16, 32)
+		if err != nil {
+			return ""
+		}
+		r, g, b := int(rgb>>16), int(rgb>>8&0xff), int(rgb&0xff)
+		switch colors {
+		case colorTrue:
+			return "38;2;" + strconv.Itoa(r) + ";" + strconv.Itoa(g) + ";" + strconv.Itoa(b)
+		case color256:
+			return "38;5;" + strconv.Itoa(nearest256(r, g, b))
+		}
+		return sgr16(nearest16(r, g, b))
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 && n < 256 {
+		if colors >= color256 {
+			return "38;5;" + s
+		}
+		if n < 16 {
+			return sgr16(n)
+		}
+		return sgr16(nearest16(rgb256(n)))
+	}
+	return ""
+}
+
+// colorProfile is the range of colors an output can show.
+type colorProfile int
+
+const (
+	// colorAuto is to be detected for the standard error.
+	colorAuto colorProfile = iota
+	// colorNone shows no styles at all.
+	colorNone
+	// colorOff shows styles but no colors, as NO_COLOR asks.
+	colorOff
+	color16
+	color256
+	colorTrue
+)
+
+// detectColors returns the colors w can show. There are none if w is not
+// a terminal or TERM is "dumb", and NO_COLOR turns colors off.
+func detectColors(w io.Writer) colorProfile {
+	t := os.Getenv("TERM")
+	switch {
+	case t == "dumb" || !isTerminal(w):
+		return colorNone
+	case os.Getenv("NO_COLOR") != "":
+		return colorOff
+	}
+	switch ct := strings.ToLower(os.Getenv("COLORTERM")); {
+	case ct == "truecolor" || ct == "24bit":
+		return colorTrue
+	case strings.Contains(t, "256color"):
+		return color256
+	}
+	return color16
+}
+
+// sgr16 returns the SGR parameter of the n-th of the 16 ANSI colors.
+func sgr16(n int) string {
+	if n < 8 {
+		return strconv.Itoa(30 + n)
+	}
+	return strconv.Itoa(90 + n - 8)
+}
+
+// ansi16 holds the RGB values of the 16 ANSI colors as xterm shows them.
+var ansi16 = [16][3]int{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// nearest16 returns the ANSI color closest to r, g, b.
+func nearest16(r, g, b int) int {
+	best, bestDist := 0, -1
+	for i, c := range ansi16 {
+		if d := distance(r, g, b, c[0], c[1], c[2]); bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+// cubeLevels are the levels of each component in the 6×6×6 color cube
+// of the 256-color palette.
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// nearest256 returns the index of the color of the 256-color palette
+// closest to r, g, b among its color cube and gray ramp.
+func nearest256(r, g, b int) int {
+	level := func(v int) int {
+		best := 0
+		for i, l := range cubeLevels {
+			if abs(v-l) < abs(v-cubeLevels[best]) {
+				best = i
+			}
+		}
+		return best
+	}
+	cube := 16 + 36*level(r) + 6*level(g) + level(b)
+	gray := 232
+	if v := (r + g + b) / 3; v > 8 {
+		gray += (v - 8) / 10
+	}
+	if gray > 255 {
+		gray = 255
+	}
+	cr, cg, cb := rgb256(cube)
+	gr, gg, gb := rgb256(gray)
+	if distance(r, g, b, gr, gg, gb) < distance(r, g, b, cr, cg, cb) {
+		return gray
+	}
+	return cube
+}
+
+// rgb256 returns the RGB values of the n-th color of the 256-color palette.
+func rgb256(n int) (int, int, int) {
+	switch {
+	case n < 16:
+		c := ansi16[n]
+		return c[0], c[1], c[2]
+	case n < 232:
+		n -= 16
+		return cubeLevels[n/36], cubeLevels[n/6%6], cubeLevels[n%6]
+	}
+	v := 8 + 10*(n-232)
+	return v, v, v
+}
+
+func distance(r1, g1, b1, r2, g2, b2 int) int {
+	dr, dg, db := r1-r2, g1-g2, b1-b2
+	return dr*dr + dg*dg + db*db
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,176 @@
+package prompts
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+// ConfirmOptions configures Confirm.
+type ConfirmOptions struct {
+	// SingleKey takes the answer as soon as y or n is pressed,
+	// without waiting for Enter.
+	SingleKey bool
+}
+
+func (o ConfirmOptions) apply(c *config) { c.confirm = o }
+
+// Confirm asks a yes/no question using the label. Pressing Enter
+// without typing anything answers def.
+//
+// If the standard input is not a terminal, the answer is read from it
+// one line at a time until a line holds a valid one, explaining why the
+// others are not.
+//
+// It returns ErrInterrupted if the user presses Ctrl+C and io.EOF if the
+// user presses Ctrl+D on an empty line or the input ends.
+func Confirm(label string, def bool, opts ...Option) (bool, error) {
+	return ConfirmContext(context.Background(), label, def, opts...)
+}
+
+// ConfirmContext is like Confirm but gives up when ctx is done,
+// returning ctx.Err().
+func ConfirmContext(ctx context.Context, label string, def bool, opts ...Option) (bool, error) {
+	c := newConfig(label, opts)
+	if b, ok := c.initial.(bool); ok {
+		def = b
+	}
+	m := &confirmModel{label: label, theme: &c.theme, keys: &c.keymap, def: def, opts: c.confirm}
+	if err := run(ctx, c, m); err != nil {
+		return false, err
+	}
+	return m.value, nil
+}
+
+type confirmModel struct {
+	label string
+	theme *Theme
+	keys  *Keymap
+	def   bool
+	opts  ConfirmOptions
+	line  line
+	value bool
+	// err is why the last answer was rejected.
+	err  error
+	done bool
+}
+
+// parseYesNo parses y, yes, n and no in any case, as well as true and false
+// as found in answers files.
+func parseYesNo(s string) (answer, ok bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "y", "yes", "true":
+		return true, true
+	case "n", "no", "false":
+		return false, true
+	}
+	return false, false
+}
+
+// parseYesNo is like the function parseYesNo but also takes the
+// translations of y, yes, n and no.
+func (t *Theme) parseYesNo(s string) (answer, ok bool) {
+	if answer, ok = parseYesNo(s); ok {
+		return answer, ok
+	}
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch {
+	case s == strings.ToLower(t.text("y")) || s == strings.ToLower(t.text("yes")):
+		return true, true
+	case s == strings.ToLower(t.text("n")) || s == strings.ToLower(t.text("no")):
+		return false, true
+	}
+	return false, false
+}
+
+// yesNo renders a yes or no answer.
+func (t *Theme) yesNo(answer bool) string {
+	if answer {
+		return t.text("Yes")
+	}
+	return t.text("No")
+}
+
+func (m *confirmModel) result() interface{} {
+	return m.value
+}
+
+func (m *confirmModel) prompt() string {
+	choices := m.theme.text("[Y/n]")
+	if !m.def {
+		choices = m.theme.text("[y/N]")
+	}
+	return m.theme.label(m.label) + " " + choices + " "
+}
+
+func (m *confirmModel) answer(s string) (bool, error) {
+	if strings.TrimSpace(s) == "" {
+		m.value, m.done = m.def, true
+		return true, nil
+	}
+	if m.value, m.done = m.theme.parseYesNo(s); !m.done {
+		return false, &invalidAnswer{err: errorf("please answer yes or no")}
+	}
+	return true, nil
+}
+
+func (m *confirmModel) update(k key) (bool, error) {
+	if bound(m.keys.Submit, k) {
+		if _, err := m.answer(m.line.String()); err != nil {
+			m.line = line{}
+			m.err = err
+		}
+		return m.done, nil
+	}
+	if k.name == "ctrl+d" && len(m.line.buf) == 0 {
+		return false, io.EOF
+	}
+	if m.opts.SingleKey {
+		if k.r != 0 {
+			m.value, m.done = m.theme.parseYesNo(string(k.r))
+		}
+		return m.done, nil
+	}
+	if m.line.edit(k, m.keys) {
+		m.err = nil
+	}
+	return false, nil
+}
+
+func (m *confirmModel) rejected() error {
+	return m.err
+}
+
+func (m *confirmModel) keyHelp() ([]keyAction, bool) {
+	if m.opts.SingleKey {
+		return []keyAction{note("press y or n to answer")}, false
+	}
+	return append(editActions(m.keys), act("accept the answer", m.keys.Submit)), true
+}
+
+func (m *confirmModel) defaultAnswer() string {
+	value := m.def
+	if s := m.line.String(); s != "" {
+		var ok bool
+		if value, ok = m.theme.parseYesNo(s); !ok {
+			return ""
+		}
+	}
+	return m.theme.yesNo(value)
+}
+
+func (m *confirmModel) view() frame {
+	if m.done {
+		return frame{lines: []string{m.theme.answered(m.label, m.theme.yesNo(m.value))}}
+	}
+	prompt := m.prompt()
+	lines := []string{prompt + m.line.String()}
+	if m.err != nil {
+		lines = append(lines, m.theme.errorLine(m.err))
+	}
+	return frame{
+		lines:      lines,
+		cursorCol:  textWidth(prompt + string(m.line.buf[:m.line.pos])),
+		showCursor: true,
+	}
+}
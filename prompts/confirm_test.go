@@ -0,0 +1,75 @@
+package prompts_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/prompttest"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name   string
+		def    bool
+		opts   []prompts.Option
+		keys   string
+		want   bool
+		screen string
+	}{
+		{"default no", false, nil, prompttest.Enter, false, "Proceed? No"},
+		{"default yes", true, nil, prompttest.Enter, true, "Proceed? Yes"},
+		{"y", false, nil, "y" + prompttest.Enter, true, "Proceed? Yes"},
+		{"yes", false, nil, "yes" + prompttest.Enter, true, "Proceed? Yes"},
+		{"n", true, nil, "n" + prompttest.Enter, false, "Proceed? No"},
+		{"single key", false, []prompts.Option{prompts.ConfirmOptions{SingleKey: true}}, "y", true, "Proceed? Yes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term := prompttest.New(t)
+			res := prompttest.Start(term, func(o prompts.Option) (bool, error) {
+				return prompts.Confirm("Proceed?", tt.def, append(tt.opts, o)...)
+			})
+			term.WaitFor("Proceed?")
+			term.Send(tt.keys)
+			got, err := res.Wait()
+			if err != nil || got != tt.want {
+				t.Fatalf("Confirm() = %v, %v, want %v, nil", got, err, tt.want)
+			}
+			if screen := term.Screen(); screen != tt.screen {
+				t.Errorf("screen shows %q once answered, want %q", screen, tt.screen)
+			}
+		})
+	}
+}
+
+func TestConfirmNotYesOrNo(t *testing.T) {
+	term := prompttest.New(t)
+	res := prompttest.Start(term, func(o prompts.Option) (bool, error) {
+		return prompts.Confirm("Proceed?", false, o)
+	})
+	term.WaitFor("Proceed?")
+	term.Send("maybe" + prompttest.Enter)
+	term.WaitFor("please answer yes or no")
+	term.Send("y")
+	term.WaitFor("Proceed? [y/N] y")
+	if got := term.Screen(); got != "Proceed? [y/N] y" {
+		t.Errorf("screen shows %q after typing, want the error gone", got)
+	}
+	term.Send(prompttest.Enter)
+	if got, err := res.Wait(); err != nil || !got {
+		t.Fatalf("Confirm() = %v, %v, want true, nil", got, err)
+	}
+}
+
+func TestConfirmLines(t *testing.T) {
+	var out strings.Builder
+	got, err := prompts.Confirm("Proceed?", false,
+		prompts.WithInput(strings.NewReader("maybe\nyes\n")), prompts.WithOutput(&out))
+	if err != nil || !got {
+		t.Fatalf("Confirm() = %v, %v, want true, nil", got, err)
+	}
+	if want := "Proceed? [y/N] \nplease answer yes or no\nProceed? [y/N] \n"; out.String() != want {
+		t.Errorf("output is %q, want %q", out.String(), want)
+	}
+}
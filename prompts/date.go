@@ -0,0 +1,282 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateOptions configures Date.
+type DateOptions struct {
+	// Default is the date picked to begin with, today if it is zero.
+	Default time.Time
+	// Min and Max are the earliest and the latest dates that can be
+	// picked. Zero means no limit.
+	Min, Max time.Time
+	// WeekStart is the first day of the weeks of the calendar.
+	WeekStart time.Weekday
+}
+
+func (o DateOptions) apply(c *config) { c.date = o }
+
+// dateLayout is how dates are typed.
+const dateLayout = "2006-01-02"
+
+// Date asks for a date using the label, picked on a calendar of a month.
+// The arrows move to the previous or next day or week, PageUp and
+// PageDown to the previous or next month, and Enter picks the date,
+// provided it passes the validators given with WithValidator, which get
+// it as YYYY-MM-DD. The date can also be typed as YYYY-MM-DD. Dates out
+// of the range given by DateOptions cannot be picked.
+//
+// If the standard input is not a terminal, the next line read from it
+// must be a date written as YYYY-MM-DD, or empty for the default.
+//
+// The date is returned at midnight in the local time zone.
+// It returns ErrInterrupted if the user presses Ctrl+C.
+func Date(label string, opts ...Option) (time.Time, error) {
+	return DateContext(context.Background(), label, opts...)
+}
+
+// DateContext is like Date but gives up when ctx is done,
+// returning ctx.Err().
+func DateContext(ctx context.Context, label string, opts ...Option) (time.Time, error) {
+	c := newConfig(label, opts)
+	m := &dateModel{
+		label:      label,
+		theme:      &c.theme,
+		keys:       &c.keymap,
+		opts:       c.date,
+		validators: c.validators,
+		today:      day(time.Now()),
+	}
+	m.opts.Min, m.opts.Max = day(m.opts.Min), day(m.opts.Max)
+	m.set(m.today)
+	if !m.opts.Default.IsZero() {
+		m.set(day(m.opts.Default))
+	}
+	if t, ok := c.initial.(time.Time); ok {
+		m.set(day(t))
+	} else if s, ok := c.initial.(string); ok {
+		// Remembered answers are read back as JSON strings.
+		if t, err := parseDate(s); err == nil {
+			m.set(t)
+		}
+	}
+	if err := run(ctx, c, m); err != nil {
+		return time.Time{}, err
+	}
+	return m.date, nil
+}
+
+// day returns midnight of the day of t in the local time zone,
+// or the zero time if t is zero.
+func day(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	y, mo, d := t.Date()
+	return time.Date(y, mo, d, 0, 0, 0, 0, time.Local)
+}
+
+type dateModel struct {
+	label      string
+	theme      *Theme
+	keys       *Keymap
+	opts       DateOptions
+	validators []Validator
+	today      time.Time
+	date       time.Time
+	// typed is the date typed, if any.
+	typed line
+	// err is why the last answer was rejected.
+	err  error
+	done bool
+}
+
+// set picks t, or the closest date in range.
+func (m *dateModel) set(t time.Time) {
+	if !m.opts.Min.IsZero() && t.Before(m.opts.Min) {
+		t = m.opts.Min
+	}
+	if !m.opts.Max.IsZero() && t.After(m.opts.Max) {
+		t = m.opts.Max
+	}
+	m.date = t
+}
+
+// check returns why t cannot be picked, if it cannot.
+func (m *dateModel) check(t time.Time) error {
+	switch {
+	case !m.opts.Min.IsZero() && t.Before(m.opts.Min):
+		return &invalidAnswer{err: errorf("must be %s or later", m.opts.Min.Format(dateLayout))}
+	case !m.opts.Max.IsZero() && t.After(m.opts.Max):
+		return &invalidAnswer{err: errorf("must be %s or earlier", m.opts.Max.Format(dateLayout))}
+	}
+	return validate(m.validators, t.Format(dateLayout))
+}
+
+// parseDate reads a date written as YYYY-MM-DD, or as an RFC 3339 time,
+// as answers were recorded before, of which it takes the date written.
+func parseDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	t, err := time.ParseInLocation(dateLayout, s, time.Local)
+	if err != nil {
+		if rt, rerr := time.Parse(time.RFC3339, s); rerr == nil {
+			y, mo, d := rt.Date()
+			return time.Date(y, mo, d, 0, 0, 0, 0, time.Local), nil
+		}
+		return t, &invalidAnswer{err: errorf("must be a date written as YYYY-MM-DD")}
+	}
+	return t, nil
+}
+
+// result returns the date as it is typed, so that answers files
+// recorded with it can be replayed.
+func (m *dateModel) result() interface{} {
+	return m.date.Format(dateLayout)
+}
+
+func (m *dateModel) prompt() string {
+	return m.theme.label(m.label) + " (" + m.date.Format(dateLayout) + ") "
+}
+
+func (m *dateModel) answer(s string) (bool, error) {
+	t := m.date
+	if strings.TrimSpace(s) != "" {
+		var err error
+		if t, err = parseDate(s); err != nil {
+			return false, err
+		}
+	}
+	if err := m.check(t); err != nil {
+		return false, err
+	}
+	m.date, m.done = t, true
+	return true, nil
+}
+
+func (m *dateModel) rejected() error {
+	return m.err
+}
+
+func (m *dateModel) update(k key) (bool, error) {
+	m.err = nil
+	switch {
+	case bound(m.keys.Submit, k):
+		t := m.date
+		if len(m.typed.buf) > 0 {
+			if t, m.err = parseDate(m.typed.String()); m.err != nil {
+				break
+			}
+		}
+		if m.err = m.check(t); m.err == nil {
+			m.date, m.done = t, true
+		}
+	case bound(m.keys.Left, k):
+		m.move(0, -1)
+	case bound(m.keys.Right, k):
+		m.move(0, 1)
+	case bound(m.keys.Up, k):
+		m.move(0, -7)
+	case bound(m.keys.Down, k):
+		m.move(0, 7)
+	case bound(m.keys.PageUp, k):
+		m.move(-1, 0)
+	case bound(m.keys.PageDown, k):
+		m.move(1, 0)
+	case k.r >= '0' && k.r <= '9' || k.r == '-' || k.name == "backspace":
+		m.typed.edit(k, m.keys)
+		if t, err := parseDate(m.typed.String()); err == nil {
+			m.set(t)
+		}
+	}
+	return m.done, nil
+}
+
+func (m *dateModel) keyHelp() ([]keyAction, bool) {
+	km := m.keys
+	return []keyAction{
+		act("move by a day", km.Left, km.Right),
+		act("move by a week", km.Up, km.Down),
+		act("move by a month", km.PageUp, km.PageDown),
+		note("type a date as YYYY-MM-DD"),
+		act("pick the date", km.Submit),
+	}, false
+}
+
+// move moves the date by months and days, clearing what was typed.
+// Moving by months keeps the day of the month if the month has it.
+func (m *dateModel) move(months, days int) {
+	t := m.date.AddDate(0, 0, days)
+	if months != 0 {
+		first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, time.Local)
+		last := first.AddDate(0, 1, -1).Day()
+		d := t.Day()
+		if d > last {
+			d = last
+		}
+		t = first.AddDate(0, 0, d-1)
+	}
+	m.set(t)
+	m.typed = line{}
+}
+
+func (m *dateModel) view() frame {
+	t := m.theme
+	if m.done {
+		return frame{lines: []string{t.answered(m.label, m.date.Format(dateLayout))}}
+	}
+	head := t.label(m.label) + " "
+	if len(m.typed.buf) > 0 {
+		head += m.typed.String()
+	} else {
+		head += t.render(t.Hint, m.date.Format(dateLayout))
+	}
+	lines := []string{head}
+	month := t.text(m.date.Month().String()) + " " + strconv.Itoa(m.date.Year())
+	lines = append(lines, strings.Repeat(" ", (28-textWidth(month))/2)+t.render(Style{Bold: true}, month))
+	var b strings.Builder
+	for i := 0; i < 7; i++ {
+		// Days are headed by the first two characters of their names.
+		day := []rune(t.text(((m.opts.WeekStart + time.Weekday(i)) % 7).String()))
+		if len(day) > 2 {
+			day = day[:2]
+		}
+		b.WriteString(" " + string(day) + strings.Repeat(" ", 3-textWidth(string(day))))
+	}
+	lines = append(lines, t.render(t.Hint, b.String()))
+
+	first := time.Date(m.date.Year(), m.date.Month(), 1, 0, 0, 0, 0, time.Local)
+	b.Reset()
+	b.WriteString(strings.Repeat("    ", (int(first.Weekday())-int(m.opts.WeekStart)+7)%7))
+	for d := first; d.Month() == first.Month(); d = d.AddDate(0, 0, 1) {
+		if d.Weekday() == m.opts.WeekStart && d.Day() > 1 {
+			lines = append(lines, b.String())
+			b.Reset()
+		}
+		b.WriteString(m.cell(d))
+	}
+	lines = append(lines, b.String())
+	if m.err != nil {
+		lines = append(lines, t.errorLine(m.err))
+	}
+	return frame{lines: lines}
+}
+
+// cell renders the day d of the calendar.
+func (m *dateModel) cell(d time.Time) string {
+	t := m.theme
+	s := fmt.Sprintf("%2d", d.Day())
+	switch {
+	case d.Equal(m.date):
+		return t.render(t.Highlight, "["+s+"]")
+	case !m.opts.Min.IsZero() && d.Before(m.opts.Min), !m.opts.Max.IsZero() && d.After(m.opts.Max):
+		return " " + t.render(t.Hint, s) + " "
+	case d.Equal(m.today):
+		return " " + t.render(Style{Bold: true, Underline: true}, s) + " "
+	}
+	return " " + s + " "
+}
@@ -0,0 +1,87 @@
+package prompts_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/prompttest"
+)
+
+var march4 = time.Date(2026, time.March, 4, 0, 0, 0, 0, time.Local)
+
+func TestDate(t *testing.T) {
+	tests := []struct {
+		name string
+		keys string
+		want time.Time
+	}{
+		{"default", prompttest.Enter, march4},
+		{"right", prompttest.Right + prompttest.Enter, march4.AddDate(0, 0, 1)},
+		{"down", prompttest.Down + prompttest.Enter, march4.AddDate(0, 0, 7)},
+		{"typed", "2026-12-25" + prompttest.Enter, time.Date(2026, time.December, 25, 0, 0, 0, 0, time.Local)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term := prompttest.New(t)
+			res := prompttest.Start(term, func(o prompts.Option) (time.Time, error) {
+				return prompts.Date("When?", prompts.DateOptions{Default: march4}, o)
+			})
+			term.WaitFor("March 2026")
+			term.Send(tt.keys)
+			got, err := res.Wait()
+			if err != nil || !got.Equal(tt.want) {
+				t.Fatalf("Date() = %v, %v, want %v, nil", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateRecordReplay(t *testing.T) {
+	for _, name := range []string{"answers.yaml", "answers.json"} {
+		t.Run(name, func(t *testing.T) {
+			r := prompts.NewRecorder()
+			term := prompttest.New(t)
+			res := prompttest.Start(term, func(o prompts.Option) (time.Time, error) {
+				return prompts.Date("When?", prompts.DateOptions{Default: march4}, prompts.WithRecorder(r), o)
+			})
+			term.WaitFor("March 2026")
+			term.Send(prompttest.Right + prompttest.Enter)
+			if _, err := res.Wait(); err != nil {
+				t.Fatal(err)
+			}
+			path := filepath.Join(t.TempDir(), name)
+			if err := r.WriteFile(path); err != nil {
+				t.Fatal(err)
+			}
+			got, err := prompts.Date("When?", prompts.WithAnswersFile(path))
+			if want := march4.AddDate(0, 0, 1); err != nil || !got.Equal(want) {
+				t.Fatalf("replayed Date() = %v, %v, want %v, nil", got, err, want)
+			}
+		})
+	}
+}
+
+func TestDateAnswersFile(t *testing.T) {
+	tests := []struct {
+		name, file string
+	}{
+		{"unquoted YAML date", "When?: 2026-03-04\n"},
+		{"quoted YAML date", "When?: \"2026-03-04\"\n"},
+		{"RFC 3339 time", "When?: \"2026-03-04T00:00:00Z\"\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "answers.yaml")
+			if err := os.WriteFile(path, []byte(tt.file), 0600); err != nil {
+				t.Fatal(err)
+			}
+			got, err := prompts.Date("When?", prompts.WithAnswersFile(path))
+			if err != nil || !got.Equal(march4) {
+				t.Fatalf("Date() = %v, %v, want %v, nil", got, err, march4)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package prompts
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DirectoryTreeOptions configures DirectoryTree.
+type DirectoryTreeOptions struct {
+	// PageSize is the number of entries shown at a time,
+	// 7 if it is not positive.
+	PageSize int
+	// Files lists files too, so that they can be picked as well.
+	Files bool
+	// Hidden lists the entries whose names start with a dot.
+	Hidden bool
+}
+
+func (o DirectoryTreeOptions) apply(c *config) { c.directoryTree = o }
+
+// DirectoryTree asks for a directory under root by showing the directory
+// tree. Up and Down move through the entries, Right expands a directory or
+// moves into it once expanded, and Left collapses it or moves to the parent
+// directory. Enter picks the highlighted entry, whose path is checked by
+// the validators given with WithValidator.
+//
+// If the standard input is not a terminal, the answer is the path read
+// from the next line, which must be an existing directory or, with
+// DirectoryTreeOptions.Files, file.
+//
+// It returns ErrInterrupted if the user presses Ctrl+C.
+func DirectoryTree(label, root string, opts ...Option) (string, error) {
+	return DirectoryTreeContext(context.Background(), label, root, opts...)
+}
+
+// DirectoryTreeContext is like DirectoryTree but gives up when ctx is done,
+// returning ctx.Err().
+func DirectoryTreeContext(ctx context.Context, label, root string, opts ...Option) (string, error) {
+	c := newConfig(label, opts)
+	m := &dirTreeModel{
+		label:      label,
+		theme:      &c.theme,
+		keys:       &c.keymap,
+		opts:       c.directoryTree,
+		validators: c.validators,
+		pageSize:   c.directoryTree.PageSize,
+	}
+	if m.pageSize <= 0 {
+		m.pageSize = defaultPageSize
+	}
+	m.maxPage = m.pageSize
+	rootNode := &dirNode{path: root, name: root, dir: true}
+	m.expand(rootNode)
+	m.visible = m.flatten(rootNode, nil)
+	if err := run(ctx, c, m); err != nil {
+		return "", err
+	}
+	return m.picked, nil
+}
+
+// dirNode is an entry of a directory tree.
+type dirNode struct {
+	path, name string
+	depth      int
+	dir        bool
+	parent     *dirNode
+	// children is nil until the directory is first expanded.
+	children []*dirNode
+	expanded bool
+}
+
+type dirTreeModel struct {
+	label      string
+	theme      *Theme
+	keys       *Keymap
+	opts       DirectoryTreeOptions
+	validators []Validator
+	// visible holds the entries of expanded directories, in order.
+	visible  []*dirNode
+	cursor   int
+	top      int
+	pageSize int
+	// maxPage is the page size configured, as for optionList.
+	maxPage int
+	picked  string
+	// err is why the last answer was rejected.
+	err  error
+	done bool
+}
+
+// expand reads the entries of the directory n, if not done yet,
+// and shows them.
+func (m *dirTreeModel) expand(n *dirNode) {
+	n.expanded = true
+	if n.children != nil {
+		return
+	}
+	n.children = []*dirNode{}
+	entries, err := ioutil.ReadDir(n.path)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if strings.HasPrefix(name, ".") && !m.opts.Hidden {
+			continue
+		}
+		path := filepath.Join(n.path, name)
+		dir := isDir(path, e)
+		if dir || m.opts.Files {
+			n.children = append(n.children, &dirNode{path: path, name: name, depth: n.depth + 1, dir: dir, parent: n})
+		}
+	}
+}
+
+// flatten appends n and the entries shown under it to list.
+func (m *dirTreeModel) flatten(n *dirNode, list []*dirNode) []*dirNode {
+	list = append(list, n)
+	if n.expanded {
+		for _, c := range n.children {
+			list = m.flatten(c, list)
+		}
+	}
+	return list
+}
+
+// moveTo highlights the entry n.
+func (m *dirTreeModel) moveTo(n *dirNode) {
+	m.visible = m.flatten(m.visible[0], nil)
+	for i, v := range m.visible {
+		if v == n {
+			m.cursor = i
+		}
+	}
+}
+
+func (m *dirTreeModel) result() interface{} {
+	return m.picked
+}
+
+func (m *dirTreeModel) prompt() string {
+	return m.theme.label(m.label) + " "
+}
+
+func (m *dirTreeModel) answer(s string) (bool, error) {
+	path := expandHome(strings.TrimSpace(s))
+	fi, err := os.Stat(path)
+	switch {
+	case err != nil:
+		return false, &invalidAnswer{err: errorf("no such file or directory")}
+	case !fi.IsDir() && !m.opts.Files:
+		return false, &invalidAnswer{err: errorf("not a directory")}
+	}
+	if err := validate(m.validators, path); err != nil {
+		return false, err
+	}
+	m.picked, m.done = path, true
+	return true, nil
+}
+
+func (m *dirTreeModel) rejected() error {
+	return m.err
+}
+
+func (m *dirTreeModel) update(k key) (bool, error) {
+	n := m.visible[m.cursor]
+	switch {
+	case bound(m.keys.Up, k):
+		m.cursor = (m.cursor + len(m.visible) - 1) % len(m.visible)
+	case bound(m.keys.Down, k):
+		m.cursor = (m.cursor + 1) % len(m.visible)
+	case bound(m.keys.Right, k):
+		if n.dir && !n.expanded {
+			m.expand(n)
+			m.moveTo(n)
+		} else if n.dir && len(n.children) > 0 {
+			m.moveTo(n.children[0])
+		}
+	case bound(m.keys.Left, k):
+		if n.dir && n.expanded && n.parent != nil {
+			n.expanded = false
+			m.moveTo(n)
+		} else if n.parent != nil {
+			m.moveTo(n.parent)
+		}
+	case bound(m.keys.Submit, k):
+		if m.err = validate(m.validators, n.path); m.err == nil {
+			m.picked, m.done = n.path, true
+		}
+		return m.done, nil
+	default:
+		return false, nil
+	}
+	m.err = nil
+	m.top = scrollTop(m.top, m.cursor, m.pageSize)
+	return false, nil
+}
+
+func (m *dirTreeModel) keyHelp() ([]keyAction, bool) {
+	km := m.keys
+	return []keyAction{
+		act("move the cursor", km.Up, km.Down),
+		act("collapse or expand a directory", km.Left, km.Right),
+		act("pick the directory", km.Submit),
+	}, false
+}
+
+func (m *dirTreeModel) resize(width, height int) {
+	m.pageSize = fitPage(m.maxPage, height, 1)
+	m.top = scrollTop(m.top, m.cursor, m.pageSize)
+}
+
+func (m *dirTreeModel) view() frame {
+	if m.done {
+		return frame{lines: []string{m.theme.answered(m.label, m.picked)}}
+	}
+	lines := []string{m.theme.label(m.label)}
+	end := m.top + m.pageSize
+	if end > len(m.visible) {
+		end = len(m.visible)
+	}
+	for i := m.top; i < end; i++ {
+		n := m.visible[i]
+		mark, name := strings.Repeat(" ", textWidth(m.theme.Collapsed)), n.name
+		if n.dir {
+			mark = m.theme.Collapsed
+			if n.expanded {
+				mark = m.theme.Expanded
+			}
+			name += string(os.PathSeparator)
+		}
+		lines = append(lines, m.theme.option(strings.Repeat("  ", n.depth)+mark+" "+name, "", nil, i == m.cursor))
+	}
+	if m.err != nil {
+		lines = append(lines, m.theme.errorLine(m.err))
+	}
+	return frame{lines: lines}
+}
@@ -0,0 +1,85 @@
+package prompts
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Driver runs interactive prompts inside another UI, which owns the
+// terminal: the UI sends the Driver the keys the user presses, and the
+// prompts show their frames with the Driver's Renderer. The prompts run
+// in their own goroutines, given WithDriver, and take no terminal of
+// their own, so they neither read their input nor touch its mode.
+type Driver struct {
+	r    Renderer
+	keys chan string
+}
+
+// NewDriver returns a Driver showing the frames of its prompts with r.
+func NewDriver(r Renderer) *Driver {
+	return &Driver{r: r, keys: make(chan string, 16)}
+}
+
+// Send passes keys to the prompt being driven, as the bytes a terminal
+// sends for them, e.g. "\x1b[A" for the up arrow. Each call is read as
+// separate input, so an escape sent alone is the Esc key.
+func (d *Driver) Send(keys string) {
+	d.keys <- keys
+}
+
+// WithDriver makes a prompt be driven by d instead of asking on
+// a terminal. Answers preset by answers files and environment variables
+// are still taken without asking.
+func WithDriver(d *Driver) Option {
+	return optionFunc(func(c *config) { c.driver = d })
+}
+
+// errNoTerminal is returned by driven prompts that need to hand
+// the terminal over to something else, such as an editor.
+var errNoTerminal = errors.New("prompts: a driven prompt cannot run programs on the terminal")
+
+// driveAsk drives m with the keys sent to the driver of c until it is done.
+func driveAsk(ctx context.Context, c *config, m model) (err error) {
+	c.theme.colors = detectColors(c.writer())
+	r := customRenderer{c.driver.r}
+	defer func() {
+		if err == errBack {
+			r.clear()
+			return
+		}
+		r.done()
+	}()
+	dr := &driverReader{ctx: ctx, keys: c.driver.keys}
+	suspend := func(suspendFunc) (bool, error) { return false, errNoTerminal }
+	return interact(c, m, r, dr, &dr.idle, suspend, nil)
+}
+
+// driverReader reads the keys sent to a Driver until its context is done.
+type driverReader struct {
+	ctx  context.Context
+	keys <-chan string
+	// idle is called as for a contextReader.
+	idle func() error
+	// rest is what did not fit in the last read.
+	rest string
+}
+
+func (r *driverReader) Read(b []byte) (int, error) {
+	for r.rest == "" {
+		select {
+		case <-r.ctx.Done():
+			return 0, r.ctx.Err()
+		case r.rest = <-r.keys:
+		case <-time.After(pollInterval):
+			if r.idle != nil {
+				if err := r.idle(); err != nil {
+					return 0, err
+				}
+			}
+		}
+	}
+	n := copy(b, r.rest)
+	r.rest = r.rest[n:]
+	return n, nil
+}
@@ -0,0 +1,111 @@
+package prompts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration asks for a duration using the label, as Input does, written as
+// time.ParseDuration reads them, such as "90s" or "2h30m". Answers that
+// are not durations are rejected. The options of Input apply as well.
+//
+// If the standard input is not a terminal, the answer is the next line
+// read from it.
+//
+// It returns ErrInterrupted if the user presses Ctrl+C and io.EOF if the
+// user presses Ctrl+D on an empty line or the input ends.
+func Duration(label string, opts ...Option) (time.Duration, error) {
+	return DurationContext(context.Background(), label, opts...)
+}
+
+// DurationContext is like Duration but gives up when ctx is done,
+// returning ctx.Err().
+func DurationContext(ctx context.Context, label string, opts ...Option) (time.Duration, error) {
+	c := newConfig(label, opts)
+	c.validators = append([]Validator{validDuration}, c.validators...)
+	if d, ok := c.initial.(time.Duration); ok {
+		c.initial = d.String()
+	}
+	m := newInputModel(label, c)
+	if err := run(ctx, c, m); err != nil {
+		return 0, err
+	}
+	return time.ParseDuration(strings.TrimSpace(m.value()))
+}
+
+func validDuration(s string) error {
+	if _, err := time.ParseDuration(strings.TrimSpace(s)); err != nil {
+		return errors.New(`must be a duration such as "90s" or "2h30m"`)
+	}
+	return nil
+}
+
+// TimeOfDay is a time of the day to the minute.
+type TimeOfDay struct {
+	Hour, Minute int
+}
+
+// String writes t as HH:MM.
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", t.Hour, t.Minute)
+}
+
+// On returns the time t on the day of d, in the location of d.
+func (t TimeOfDay) On(d time.Time) time.Time {
+	y, m, day := d.Date()
+	return time.Date(y, m, day, t.Hour, t.Minute, 0, 0, d.Location())
+}
+
+// ParseTimeOfDay reads a time of the day written as HH:MM,
+// from 00:00 to 23:59.
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	errTime := errorf("must be a time written as HH:MM")
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) != 2 || len(parts[0]) < 1 || len(parts[0]) > 2 || len(parts[1]) != 2 {
+		return TimeOfDay{}, errTime
+	}
+	h, err := strconv.ParseUint(parts[0], 10, 8)
+	if err != nil || h > 23 {
+		return TimeOfDay{}, errTime
+	}
+	m, err := strconv.ParseUint(parts[1], 10, 8)
+	if err != nil || m > 59 {
+		return TimeOfDay{}, errTime
+	}
+	return TimeOfDay{Hour: int(h), Minute: int(m)}, nil
+}
+
+// Time asks for a time of the day using the label, as Input does,
+// written as HH:MM. Answers that are not times are rejected.
+// The options of Input apply as well.
+//
+// If the standard input is not a terminal, the answer is the next line
+// read from it.
+//
+// It returns ErrInterrupted if the user presses Ctrl+C and io.EOF if the
+// user presses Ctrl+D on an empty line or the input ends.
+func Time(label string, opts ...Option) (TimeOfDay, error) {
+	return TimeContext(context.Background(), label, opts...)
+}
+
+// TimeContext is like Time but gives up when ctx is done,
+// returning ctx.Err().
+func TimeContext(ctx context.Context, label string, opts ...Option) (TimeOfDay, error) {
+	c := newConfig(label, opts)
+	c.validators = append([]Validator{func(s string) error {
+		_, err := ParseTimeOfDay(s)
+		return err
+	}}, c.validators...)
+	if t, ok := c.initial.(TimeOfDay); ok {
+		c.initial = t.String()
+	}
+	m := newInputModel(label, c)
+	if err := run(ctx, c, m); err != nil {
+		return TimeOfDay{}, err
+	}
+	return ParseTimeOfDay(m.value())
+}
@@ -0,0 +1,189 @@
+package prompts
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// EditorOptions configures Editor.
+type EditorOptions struct {
+	// Extension is the extension of the file edited, such as ".md",
+	// which editors may use to highlight its syntax.
+	Extension string
+}
+
+func (o EditorOptions) apply(c *config) { c.editor = o }
+
+// Editor asks for text using the label, which the user writes in their
+// editor: pressing Enter opens a temporary file holding initial in the
+// editor named by $VISUAL or $EDITOR, or vi (notepad on Windows) if
+// neither is set, and the answer is what the file holds once the editor
+// exits. The answer is cleaned up by the transforms given with
+// WithTransform and then checked by the validators given with
+// WithValidator; if it does not pass, Enter opens the editor again.
+//
+// If the standard input is not a terminal, the answer is the next line
+// read from it, or initial if the line is empty.
+//
+// It returns ErrInterrupted if the user presses Ctrl+C.
+func Editor(label, initial string, opts ...Option) (string, error) {
+	return EditorContext(context.Background(), label, initial, opts...)
+}
+
+// EditorContext is like Editor but gives up when ctx is done,
+// returning ctx.Err().
+func EditorContext(ctx context.Context, label, initial string, opts ...Option) (string, error) {
+	c := newConfig(label, opts)
+	if s, ok := c.initial.(string); ok {
+		initial = s
+	}
+	m := &editorModel{
+		label:      label,
+		theme:      &c.theme,
+		keys:       &c.keymap,
+		opts:       c.editor,
+		transforms: c.transforms,
+		validators: c.validators,
+		text:       initial,
+	}
+	if err := run(ctx, c, m); err != nil {
+		return "", err
+	}
+	return m.text, nil
+}
+
+type editorModel struct {
+	label      string
+	theme      *Theme
+	keys       *Keymap
+	opts       EditorOptions
+	transforms []Transform
+	validators []Validator
+	text       string
+	// edit is set when the editor is to be opened
+	// and editing while it is open.
+	edit, editing bool
+	// err is why the last answer was rejected.
+	err  error
+	done bool
+}
+
+func (m *editorModel) result() interface{} {
+	return m.text
+}
+
+func (m *editorModel) prompt() string {
+	return m.theme.label(m.label) + " "
+}
+
+func (m *editorModel) answer(s string) (bool, error) {
+	if s != "" {
+		m.text = s
+	}
+	m.text = transform(m.transforms, m.text)
+	if err := validate(m.validators, m.text); err != nil {
+		return false, err
+	}
+	m.done = true
+	return true, nil
+}
+
+func (m *editorModel) rejected() error {
+	return m.err
+}
+
+func (m *editorModel) update(k key) (bool, error) {
+	m.edit = bound(m.keys.Submit, k)
+	return false, nil
+}
+
+func (m *editorModel) keyHelp() ([]keyAction, bool) {
+	return []keyAction{act("open the editor", m.keys.Submit)}, false
+}
+
+func (m *editorModel) suspended() suspendFunc {
+	if !m.edit {
+		return nil
+	}
+	m.edit, m.editing = false, true
+	return func(in, out *os.File) (bool, error) {
+		defer func() { m.editing = false }()
+		text, err := edit(in, out, m.text, m.opts.Extension)
+		if err != nil {
+			return false, err
+		}
+		m.text = transform(m.transforms, text)
+		m.err = validate(m.validators, m.text)
+		m.done = m.err == nil
+		return m.done, nil
+	}
+}
+
+func (m *editorModel) view() frame {
+	if m.done {
+		return frame{lines: []string{m.theme.answered(m.label, summary(m.text))}}
+	}
+	hint := m.theme.textf("[%s to open your editor]", keyLabel(m.keys.Submit))
+	if m.editing {
+		hint = m.theme.text("[waiting for your editor to close the file]")
+	}
+	lines := []string{m.theme.label(m.label) + " " + m.theme.render(m.theme.Hint, hint)}
+	if m.err != nil {
+		lines = append(lines, m.theme.errorLine(m.err))
+	}
+	return frame{lines: lines}
+}
+
+// summary returns the first line of s, followed by an ellipsis
+// if there are more.
+func summary(s string) string {
+	s = strings.TrimRight(s, "\n")
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i] + "..."
+	}
+	return s
+}
+
+// edit opens a temporary file with the extension ext holding text in
+// the editor of the user, on the terminal whose input and output are in
+// and out, and returns what it holds once the editor exits.
+func edit(in, out *os.File, text, ext string) (string, error) {
+	f, err := ioutil.TempFile("", "prompt-*"+ext)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(text)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", err
+	}
+	args := append(editorCommand(), f.Name())
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = in, out, out
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+	b, err := ioutil.ReadFile(f.Name())
+	return string(b), err
+}
+
+// editorCommand returns the command that runs the editor of the user,
+// with its arguments.
+func editorCommand() []string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if args := strings.Fields(os.Getenv(name)); len(args) > 0 {
+			return args
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
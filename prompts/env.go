@@ -0,0 +1,20 @@
+package prompts
+
+import "os"
+
+// WithEnvFallback makes a prompt take its answer from the environment
+// variable name, if it is set, instead of asking for it. The value is
+// given the way it would be typed when the standard input is not
+// a terminal, e.g. "yes" for Confirm or "Go,Rust" for Checkboxes.
+func WithEnvFallback(name string) Option {
+	return optionFunc(func(c *config) { c.envVar = name })
+}
+
+// envAnswer returns the answer set in the environment variable
+// configured by c, if any.
+func envAnswer(c *config) (string, bool) {
+	if c.envVar == "" {
+		return "", false
+	}
+	return os.LookupEnv(c.envVar)
+}
@@ -0,0 +1,45 @@
+package prompts_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+)
+
+func TestEnvFallback(t *testing.T) {
+	t.Setenv("PROMPTS_NAME", "Gopher")
+	t.Setenv("PROMPTS_CONTINUE", "yes")
+	t.Setenv("PROMPTS_LANGUAGES", "Go, Rust")
+	// Nothing is read: the input would end at once.
+	in := prompts.WithInput(strings.NewReader(""))
+	name, err := prompts.Input("Name?", prompts.WithEnvFallback("PROMPTS_NAME"), in)
+	if err != nil || name != "Gopher" {
+		t.Errorf("Input() = %q, %v, want \"Gopher\", nil", name, err)
+	}
+	ok, err := prompts.Confirm("Continue?", false, prompts.WithEnvFallback("PROMPTS_CONTINUE"), in)
+	if err != nil || !ok {
+		t.Errorf("Confirm() = %v, %v, want true, nil", ok, err)
+	}
+	langs, err := prompts.Checkboxes("Languages?", []string{"Go", "Python", "Rust"}, prompts.WithEnvFallback("PROMPTS_LANGUAGES"), in)
+	if err != nil || strings.Join(langs, ",") != "Go,Rust" {
+		t.Errorf("Checkboxes() = %q, %v, want [Go Rust], nil", langs, err)
+	}
+}
+
+func TestEnvFallbackUnset(t *testing.T) {
+	got, err := prompts.Input("Name?", prompts.WithEnvFallback("PROMPTS_UNSET_NAME"),
+		prompts.WithInput(strings.NewReader("Gopher\n")), prompts.WithOutput(&strings.Builder{}))
+	if err != nil || got != "Gopher" {
+		t.Fatalf("Input() = %q, %v, want \"Gopher\", nil", got, err)
+	}
+}
+
+func TestEnvFallbackInvalid(t *testing.T) {
+	t.Setenv("PROMPTS_COLOR", "purple")
+	_, _, err := prompts.Select("Color?", []string{"red", "green"}, prompts.WithEnvFallback("PROMPTS_COLOR"),
+		prompts.WithInput(strings.NewReader("red\n")))
+	if want := `prompts: invalid answer in $PROMPTS_COLOR: "purple" is not one of the options`; err == nil || err.Error() != want {
+		t.Fatalf("Select() returned %v, want %s", err, want)
+	}
+}
@@ -0,0 +1,80 @@
+package prompts
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var (
+	// ErrInterrupted is returned when the user interrupts a prompt,
+	// e.g. by pressing Ctrl+C.
+	ErrInterrupted = errors.New("prompts: interrupted")
+
+	// ErrNotATerminal is returned when a prompt needs a terminal
+	// but the standard input is not one and the prompt cannot
+	// read its answer from it as text.
+	ErrNotATerminal = errors.New("prompts: not a terminal")
+
+	// ErrNoOptions is returned when a prompt is given no options to pick from.
+	ErrNoOptions = errors.New("prompts: no options")
+
+	// ErrNoAnswer is returned when a prompt is expected to take
+	// its answer from an answers file but there is none for it.
+	ErrNoAnswer = errors.New("prompts: no answer")
+
+	// ErrNoInput is returned by prompts that would ask while the InputMode
+	// is NoInput, or that have no default answer to take while it is
+	// DefaultInput.
+	ErrNoInput = errors.New("prompts: input is disabled")
+
+	// ErrTooManyAttempts is returned by prompts given WithMaxRetries
+	// once they have rejected as many answers as allowed.
+	ErrTooManyAttempts = errors.New("prompts: too many attempts")
+)
+
+// Exit codes returned by ExitCode, for programs to exit with when a prompt
+// fails, so that scripts running them can tell what happened.
+const (
+	// ExitError is for errors with no code of their own.
+	ExitError = 1
+	// ExitTooManyAttempts is for ErrTooManyAttempts: no valid answer
+	// was given.
+	ExitTooManyAttempts = 3
+	// ExitNotATerminal is for ErrNotATerminal: the prompt could not be
+	// shown at all.
+	ExitNotATerminal = 4
+	// ExitNoInput is for io.EOF, ErrNoAnswer and ErrNoInput: the input
+	// ended, the answers file had no answer or input was disabled before
+	// the prompt was answered.
+	ExitNoInput = 5
+	// ExitInterrupted is for ErrInterrupted and context.Canceled: the
+	// user cancelled the prompt. It is the code shells give commands
+	// killed by SIGINT.
+	ExitInterrupted = 130
+)
+
+// ExitCode returns the code a program should exit with after a prompt
+// failed with err, or 0 if err is nil. Errors wrapping those of this
+// package are given their codes too.
+//
+//	name, err := prompts.Input("What is your name?")
+//	if err != nil {
+//		fmt.Fprintln(os.Stderr, err)
+//		os.Exit(prompts.ExitCode(err))
+//	}
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, ErrInterrupted), errors.Is(err, context.Canceled):
+		return ExitInterrupted
+	case errors.Is(err, ErrTooManyAttempts):
+		return ExitTooManyAttempts
+	case errors.Is(err, ErrNotATerminal):
+		return ExitNotATerminal
+	case errors.Is(err, io.EOF), errors.Is(err, ErrNoAnswer), errors.Is(err, ErrNoInput):
+		return ExitNoInput
+	}
+	return ExitError
+}
@@ -0,0 +1,216 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Form asks a series of questions and binds the answers to a struct
+// or a map:
+//
+//	var cfg struct {
+//		Name  string
+//		Langs []string
+//	}
+//	form := prompts.NewForm()
+//	form.Add("name", prompts.InputQuestion("What is your name?"))
+//	form.Add("langs", prompts.CheckboxesQuestion("Languages?", langs))
+//	err := form.Run(&cfg)
+type Form struct {
+	opts   []Option
+	fields []formField
+}
+
+type formField struct {
+	name string
+	q    *Question
+}
+
+// NewForm returns an empty Form. The options apply to all its questions,
+// which can override them with their own.
+func NewForm(opts ...Option) *Form {
+	return &Form{opts: opts}
+}
+
+// Add appends a question answering the field name and returns f.
+// The name is also the key of the question in answers files.
+func (f *Form) Add(name string, q *Question) *Form {
+	f.fields = append(f.fields, formField{name: name, q: q})
+	return f
+}
+
+// Run asks the questions in order and stores the answers in v, which must
+// be a pointer to a struct or to a map[string]interface{}. A struct field
+// is bound to the question with the name in its "form" tag or, lacking
+// one, to the question whose name matches its own ignoring case. The
+// answer must be assignable or convertible to the type of the field,
+// or be a string holding a number for number fields.
+//
+// Questions with a When condition that does not hold are skipped.
+// With WithReview, the answers are reviewed before they are stored.
+// Run stops at the first question that fails and returns its error,
+// without storing any answers.
+func (f *Form) Run(v interface{}) error {
+	return f.RunContext(context.Background(), v)
+}
+
+// RunContext is like Run but gives up when ctx is done,
+// returning ctx.Err().
+func (f *Form) RunContext(ctx context.Context, v interface{}) error {
+	rv, err := target(v)
+	if err != nil {
+		return err
+	}
+	answers := Answers{}
+	if err := fill(ctx, f.opts, f.fields, answers); err != nil {
+		return err
+	}
+	if err := review(ctx, f.opts, f.fields, answers); err != nil {
+		return err
+	}
+	if err := printAnswers(f.opts, f.fields, answers); err != nil {
+		return err
+	}
+	return bindAll(rv, f.fields, answers)
+}
+
+// fieldOptions returns the options of the question of the named field,
+// added to opts given for all of them.
+func fieldOptions(opts []Option, name string) []Option {
+	return append(opts[:len(opts):len(opts)], WithKey(name))
+}
+
+// fill asks the questions of fields that have no answer yet and whose
+// conditions hold, and forgets the answers of those whose conditions
+// no longer hold.
+func fill(ctx context.Context, opts []Option, fields []formField, answers Answers) error {
+	for _, field := range fields {
+		opts := fieldOptions(opts, field.name)
+		if !field.q.asks(answers, opts) {
+			delete(answers, field.name)
+			continue
+		}
+		if _, ok := answers[field.name]; ok {
+			continue
+		}
+		answer, err := field.q.Ask(ctx, opts...)
+		if err != nil {
+			return err
+		}
+		answers[field.name] = answer
+	}
+	return nil
+}
+
+// bindAll stores the answers of fields in the struct or map rv.
+func bindAll(rv reflect.Value, fields []formField, answers Answers) error {
+	for _, field := range fields {
+		if answer, ok := answers[field.name]; ok {
+			if err := bind(rv, field.name, answer); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Answers maps the names of the questions of a Form or Wizard
+// to the answers given to them so far.
+type Answers map[string]interface{}
+
+// When makes a question of a Form or Wizard only be asked if cond reports
+// true for the answers given to the earlier questions. Questions that are
+// not asked leave their fields alone.
+//
+//	form.Add("external", prompts.ConfirmQuestion("Use an external database?", false))
+//	form.Add("host", prompts.InputQuestion("Which database host?",
+//		prompts.When(func(a prompts.Answers) bool { return a["external"] == true })))
+func When(cond func(Answers) bool) Option {
+	return optionFunc(func(c *config) { c.when = cond })
+}
+
+// target returns the struct or map the pointer v points to,
+// making the map if it is nil.
+func target(v interface{}) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return rv, fmt.Errorf("prompts: answers need a non-nil pointer, not %T", v)
+	}
+	rv = rv.Elem()
+	switch {
+	case rv.Kind() == reflect.Struct:
+	case rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String:
+		if rv.IsNil() {
+			rv.Set(reflect.MakeMap(rv.Type()))
+		}
+	default:
+		return rv, fmt.Errorf("prompts: cannot store answers in %T", v)
+	}
+	return rv, nil
+}
+
+// bind stores answer under name in the struct or map rv.
+func bind(rv reflect.Value, name string, answer interface{}) error {
+	av := reflect.ValueOf(answer)
+	if rv.Kind() == reflect.Map {
+		t := rv.Type().Elem()
+		if !av.Type().AssignableTo(t) {
+			if !av.Type().ConvertibleTo(t) {
+				return fmt.Errorf("prompts: cannot store %T answer to %q in %s", answer, name, rv.Type())
+			}
+			av = av.Convert(t)
+		}
+		rv.SetMapIndex(reflect.ValueOf(name).Convert(rv.Type().Key()), av)
+		return nil
+	}
+	fv := fieldByName(rv, name)
+	if !fv.IsValid() {
+		return fmt.Errorf("prompts: no field for %q in %s", name, rv.Type())
+	}
+	if err := setValue(fv, av); err != nil {
+		return fmt.Errorf("prompts: cannot store %T answer to %q in %s: %w", answer, name, fv.Type(), err)
+	}
+	return nil
+}
+
+// fieldByName returns the field of the struct rv bound to name.
+func fieldByName(rv reflect.Value, name string) reflect.Value {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if tag, ok := t.Field(i).Tag.Lookup("form"); ok && tag == name {
+			return rv.Field(i)
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if _, ok := sf.Tag.Lookup("form"); !ok && sf.PkgPath == "" && strings.EqualFold(sf.Name, name) {
+			return rv.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+// setValue sets fv to av, converting it or, for slices, its elements.
+func setValue(fv, av reflect.Value) error {
+	switch t := fv.Type(); {
+	case av.Type().AssignableTo(t):
+		fv.Set(av)
+	case av.Kind() == reflect.String && t.Kind() != reflect.String && t.Kind() != reflect.Slice:
+		return setNumber(fv, av.String())
+	case av.Type().ConvertibleTo(t):
+		fv.Set(av.Convert(t))
+	case av.Kind() == reflect.Slice && t.Kind() == reflect.Slice:
+		s := reflect.MakeSlice(t, av.Len(), av.Len())
+		for i := 0; i < av.Len(); i++ {
+			if err := setValue(s.Index(i), av.Index(i)); err != nil {
+				return err
+			}
+		}
+		fv.Set(s)
+	default:
+		return fmt.Errorf("incompatible types")
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package prompts_test
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/prompttest"
+)
+
+// newProjectForm returns a form asking for a project.
+func newProjectForm(opts ...prompts.Option) *prompts.Form {
+	form := prompts.NewForm(opts...)
+	form.Add("name", prompts.InputQuestion("Name?"))
+	form.Add("port", prompts.InputQuestion("Port?"))
+	form.Add("langs", prompts.CheckboxesQuestion("Languages?", []string{"Go", "Python", "Rust"}))
+	return form
+}
+
+func TestForm(t *testing.T) {
+	var project struct {
+		Name  string
+		Port  int
+		Langs []string `form:"langs"`
+	}
+	term := prompttest.New(t)
+	res := prompttest.Start(term, func(o prompts.Option) (bool, error) {
+		return true, newProjectForm(o).Run(&project)
+	})
+	term.WaitFor("Name?")
+	term.Send("billing" + prompttest.Enter)
+	term.WaitFor("Port?")
+	term.Send("8080" + prompttest.Enter)
+	term.WaitFor("Rust")
+	term.Send(prompttest.Space + prompttest.Enter)
+	if _, err := res.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if project.Name != "billing" || project.Port != 8080 || strings.Join(project.Langs, ",") != "Go" {
+		t.Errorf("Run() stored %+v, want {Name:billing Port:8080 Langs:[Go]}", project)
+	}
+}
+
+func TestFormMap(t *testing.T) {
+	answers := map[string]interface{}{}
+	form := newProjectForm(prompts.WithInput(strings.NewReader("billing\n8080\nGo,Rust\n")), prompts.WithOutput(&strings.Builder{}))
+	if err := form.Run(&answers); err != nil {
+		t.Fatal(err)
+	}
+	if answers["name"] != "billing" || answers["port"] != "8080" || strings.Join(answers["langs"].([]string), ",") != "Go,Rust" {
+		t.Errorf("Run() stored %v, want map[langs:[Go Rust] name:billing port:8080]", answers)
+	}
+}
+
+func TestFormFails(t *testing.T) {
+	project := struct{ Name, Port string }{Name: "unchanged"}
+	// The input ends before the last question is answered.
+	form := newProjectForm(prompts.WithInput(strings.NewReader("billing\n8080\n")), prompts.WithOutput(&strings.Builder{}))
+	if err := form.Run(&project); !errors.Is(err, io.EOF) {
+		t.Fatalf("Run() returned %v, want io.EOF", err)
+	}
+	if project.Name != "unchanged" {
+		t.Errorf("Run() stored Name %q, want no answers stored", project.Name)
+	}
+}
+
+func TestFormNotAPointer(t *testing.T) {
+	var project struct{ Name string }
+	if err := newProjectForm().Run(project); err == nil {
+		t.Fatal("Run() returned nil, want an error")
+	}
+}
+
+func TestFormWhen(t *testing.T) {
+	tests := []struct {
+		name, lines string
+		want        map[string]interface{}
+	}{
+		{"asked", "y\ndb.internal\n", map[string]interface{}{"external": true, "host": "db.internal"}},
+		{"skipped", "n\n", map[string]interface{}{"external": false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := prompts.NewForm(prompts.WithInput(strings.NewReader(tt.lines)), prompts.WithOutput(&strings.Builder{}))
+			form.Add("external", prompts.ConfirmQuestion("Use an external database?", false))
+			form.Add("host", prompts.InputQuestion("Which database host?",
+				prompts.When(func(a prompts.Answers) bool { return a["external"] == true })))
+			answers := map[string]interface{}{}
+			if err := form.Run(&answers); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(answers, tt.want) {
+				t.Errorf("Run() stored %v, want %v", answers, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package prompts
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// optionFilter narrows a list of options down to those that match the
+// text typed so far with its matcher, fuzzily if it is nil, best matches
+// first, and those matching as well in order, or alphabetically if sorted.
+type optionFilter struct {
+	text    []rune
+	matcher Matcher
+	sorted  bool
+	// matches are the options shown, all of them while text is empty,
+	// and applied the text they match.
+	matches []optionMatch
+	applied string
+}
+
+// optionMatch is an option matching the text of a filter.
+type optionMatch struct {
+	// index is the index of the option in the list.
+	index int
+	// positions are the indexes of the matched runes of the option.
+	positions []int
+	score     int
+}
+
+// apply filters options with the current text. Typing more only narrows
+// the matches down, so when the text starts with the one applied before,
+// only the options that matched it are matched again.
+func (f *optionFilter) apply(options []string) {
+	text := string(f.text)
+	if text == "" || f.applied == "" || !strings.HasPrefix(text, f.applied) {
+		if cap(f.matches) < len(options) {
+			f.matches = make([]optionMatch, 0, len(options))
+		}
+		f.matches = f.matches[:0]
+		for i := range options {
+			f.matches = append(f.matches, optionMatch{index: i})
+		}
+	}
+	f.applied = text
+	if text != "" {
+		f.matches = f.match(options, f.matches)
+	}
+	f.sort(options, f.matches)
+}
+
+// extend adds the options from the index from on, which were added since
+// the filter was applied, to its matches.
+func (f *optionFilter) extend(options []string, from int) {
+	added := make([]optionMatch, 0, len(options)-from)
+	for i := from; i < len(options); i++ {
+		added = append(added, optionMatch{index: i})
+	}
+	if f.applied != "" {
+		added = f.match(options, added)
+	}
+	f.sort(options, added)
+	// Merge the matches added in, rather than sort them all again.
+	merged := make([]optionMatch, 0, len(f.matches)+len(added))
+	i, j := 0, 0
+	for i < len(f.matches) && j < len(added) {
+		if f.less(options, added[j], f.matches[i]) {
+			merged, j = append(merged, added[j]), j+1
+		} else {
+			merged, i = append(merged, f.matches[i]), i+1
+		}
+	}
+	merged = append(merged, f.matches[i:]...)
+	f.matches = append(merged, added[j:]...)
+}
+
+// match returns the candidates matching the text applied, reusing them.
+func (f *optionFilter) match(options []string, candidates []optionMatch) []optionMatch {
+	pattern, kept := []rune(f.applied), candidates[:0]
+	for _, m := range candidates {
+		var positions []int
+		var score int
+		var ok bool
+		if f.matcher == nil {
+			positions, score, ok = fuzzyMatch(pattern, options[m.index])
+		} else {
+			positions, score, ok = f.matcher.Match(f.applied, options[m.index])
+		}
+		if ok {
+			kept = append(kept, optionMatch{index: m.index, positions: positions, score: score})
+		}
+	}
+	return kept
+}
+
+// sort puts matches in the order they are listed in.
+func (f *optionFilter) sort(options []string, matches []optionMatch) {
+	if f.applied == "" && !f.sorted {
+		return
+	}
+	sort.Slice(matches, func(i, j int) bool { return f.less(options, matches[i], matches[j]) })
+}
+
+// less reports whether a is listed before b.
+func (f *optionFilter) less(options []string, a, b optionMatch) bool {
+	switch {
+	case a.score != b.score:
+		return a.score > b.score
+	case f.sorted && options[a.index] != options[b.index]:
+		return options[a.index] < options[b.index]
+	}
+	return a.index < b.index
+}
+
+// edit applies k to the text of the filter and reports whether it did.
+// Printable characters are typed, Backspace deletes the last one and the
+// DeleteWord and DeleteToStart keys clear the text.
+func (f *optionFilter) edit(k key, km *Keymap) bool {
+	switch {
+	case bound(km.DeleteWord, k) || bound(km.DeleteToStart, k):
+		if len(f.text) == 0 {
+			return false
+		}
+		f.text = f.text[:0]
+	case k.name == "backspace":
+		if len(f.text) == 0 {
+			return false
+		}
+		f.text = f.text[:len(f.text)-1]
+	case k.r != 0:
+		f.text = append(f.text, k.r)
+	case k.name == "paste":
+		for _, r := range k.paste {
+			if !unicode.IsControl(r) {
+				f.text = append(f.text, r)
+			}
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// noMatches is shown instead of the options when none match the filter.
+const noMatches = "No matching options"
+
+// filterLabel renders the label of a prompt whose options are filtered
+// by f, followed by the text typed so far.
+func (t *Theme) filterLabel(label string, f *optionFilter) string {
+	if len(f.text) == 0 {
+		return t.label(label)
+	}
+	return t.label(label) + " " + string(f.text)
+}
+
+// fuzzyMatch reports whether the runes of pattern appear in s in order,
+// ignoring case, and returns where and how well they do. Matches score
+// higher the more of them are consecutive or start words.
+func fuzzyMatch(pattern []rune, s string) (positions []int, score int, ok bool) {
+	if len(pattern) == 0 {
+		return nil, 0, true
+	}
+	p, want := 0, foldRune(pattern[0])
+	i, prev := 0, rune(0)
+	for _, r := range s {
+		if p == len(pattern) {
+			break
+		}
+		if foldRune(r) == want {
+			if positions == nil {
+				positions = make([]int, 0, len(pattern))
+			}
+			score++
+			switch {
+			case len(positions) > 0 && positions[len(positions)-1] == i-1:
+				score += 5
+			case i == 0 || !isWordRune(prev) || unicode.IsUpper(r) && !unicode.IsUpper(prev):
+				score += 8
+			}
+			positions = append(positions, i)
+			if p++; p < len(pattern) {
+				want = foldRune(pattern[p])
+			}
+		}
+		i, prev = i+1, r
+	}
+	if p < len(pattern) {
+		return nil, 0, false
+	}
+	// Prefer matches that are close together.
+	score -= (positions[len(positions)-1] - positions[0] + 1 - len(positions)) / 2
+	return positions, score, true
+}
+
+// foldRune returns r in lower case, quickly for ASCII.
+func foldRune(r rune) rune {
+	if r < utf8.RuneSelf {
+		if 'A' <= r && r <= 'Z' {
+			r += 'a' - 'A'
+		}
+		return r
+	}
+	return unicode.ToLower(r)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
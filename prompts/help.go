@@ -0,0 +1,136 @@
+package prompts
+
+import "strings"
+
+// A keyAction is something keys do in a prompt, as the help overlay
+// lists it: keys names the first key of each binding doing it, as hints
+// do. Actions of unbound keys are not listed, unless they are notes,
+// which name no keys to begin with.
+type keyAction struct {
+	keys string
+	does string
+	note bool
+}
+
+// act returns the action of bindings.
+func act(does string, bindings ...[]string) keyAction {
+	var names []string
+	for _, b := range bindings {
+		if len(b) > 0 {
+			names = append(names, keyLabel(b))
+		}
+	}
+	return keyAction{keys: strings.Join(names, "/"), does: does}
+}
+
+// note returns an action done by no key in particular, such as typing.
+func note(does string) keyAction {
+	return keyAction{does: does, note: true}
+}
+
+// A keyHelper is a model whose keys are listed by the help overlay.
+type keyHelper interface {
+	// keyHelp returns what the keys of the prompt do, and whether it takes
+	// typed text, to which printable keys bound to Help are typed instead
+	// of showing the overlay.
+	keyHelp() (actions []keyAction, typing bool)
+}
+
+// An optionHelper is a model with help of its own for the overlay to show
+// above its keys, such as that of the option under the cursor.
+type optionHelper interface {
+	optionHelp() []string
+}
+
+// editActions are the keys editing the text of a line.
+func editActions(km *Keymap) []keyAction {
+	return []keyAction{
+		act("move the cursor", km.Left, km.Right),
+		act("move the cursor by a word", km.WordLeft, km.WordRight),
+		act("move to the start or the end", km.Home, km.End),
+		act("delete the word before the cursor", km.DeleteWord),
+		act("delete everything before the cursor", km.DeleteToStart),
+		act("delete everything after the cursor", km.DeleteToEnd),
+	}
+}
+
+// helpModel shows the help overlay of its model below it when a Help key
+// is pressed, until the next key.
+type helpModel struct {
+	model
+	helper keyHelper
+	theme  *Theme
+	keys   *Keymap
+	// back is set if Back goes back to the previous step of a Wizard.
+	back  bool
+	shown bool
+}
+
+func (m *helpModel) update(k key) (bool, error) {
+	if m.shown {
+		if k.name == "position" || k.name == "mouse" {
+			// Not pressed by the user.
+			return m.model.update(k)
+		}
+		m.shown = false
+		return false, nil
+	}
+	if bound(m.keys.Help, k) {
+		if k.r == 0 || m.helper == nil {
+			m.shown = true
+			return false, nil
+		}
+		if _, typing := m.helper.keyHelp(); !typing {
+			m.shown = true
+			return false, nil
+		}
+	}
+	return m.model.update(k)
+}
+
+func (m *helpModel) view() frame {
+	f := m.model.view()
+	if m.shown {
+		f.lines = append(append([]string(nil), f.lines...), m.overlay()...)
+	}
+	return f
+}
+
+// overlay renders the help of the prompt: the help of its own, if any,
+// and what its keys do, with those of every prompt last.
+func (m *helpModel) overlay() []string {
+	t, km := m.theme, m.keys
+	var lines []string
+	var actions []keyAction
+	if m.helper != nil {
+		if oh, ok := m.helper.(optionHelper); ok {
+			lines = append(lines, oh.optionHelp()...)
+		}
+		actions, _ = m.helper.keyHelp()
+	}
+	// Name all the Help keys, since text prompts type printable ones.
+	var help [][]string
+	for _, k := range km.Help {
+		help = append(help, []string{k})
+	}
+	actions = append(actions, act("show this help", help...))
+	if m.back {
+		actions = append(actions, act("go back to the previous question", km.Back))
+	}
+	actions = append(actions, act("cancel", km.Cancel))
+
+	width := 0
+	for _, a := range actions {
+		if w := textWidth(a.keys); w > width {
+			width = w
+		}
+	}
+	for _, a := range actions {
+		if a.keys == "" && !a.note {
+			continue
+		}
+		keys := a.keys + strings.Repeat(" ", width-textWidth(a.keys))
+		lines = append(lines, "  "+t.render(t.Highlight, keys)+"  "+t.text(a.does))
+	}
+	return append(lines, t.render(t.Hint, t.text("Press any key to close this help")))
+}
@@ -0,0 +1,141 @@
+package prompts
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// HistoryOptions configures WithHistory.
+type HistoryOptions struct {
+	// Size is the number of answers kept, 100 if zero.
+	Size int
+	// InMemory keeps the history for the life of the process only,
+	// so that sensitive answers are never written to disk.
+	InMemory bool
+}
+
+const defaultHistorySize = 100
+
+// WithHistory makes Input remember the answers typed into it, which the
+// Up and Down keys then recall. Each prompt has its own history, found by
+// its key as set by WithKey. Unless o.InMemory is set, the history is kept
+// across runs of the program in a file under $XDG_STATE_HOME/<program>/history,
+// or ~/.local/state/<program>/history if XDG_STATE_HOME is not set.
+func WithHistory(o HistoryOptions) Option {
+	return optionFunc(func(c *config) { c.history = &o })
+}
+
+// history is the list of earlier answers to a prompt, oldest first.
+type history struct {
+	key     string
+	opts    HistoryOptions
+	entries []string
+}
+
+// inMemoryHistories holds the histories that are not written to disk,
+// by key.
+var inMemoryHistories = struct {
+	sync.Mutex
+	m map[string][]string
+}{m: map[string][]string{}}
+
+// loadHistory returns the history of the prompt with the key,
+// which is empty if it cannot be read.
+func loadHistory(key string, opts HistoryOptions) *history {
+	if opts.Size <= 0 {
+		opts.Size = defaultHistorySize
+	}
+	h := &history{key: key, opts: opts}
+	if opts.InMemory {
+		inMemoryHistories.Lock()
+		h.entries = append([]string(nil), inMemoryHistories.m[key]...)
+		inMemoryHistories.Unlock()
+		return h
+	}
+	b, err := ioutil.ReadFile(historyFile(key))
+	if err == nil {
+		for _, s := range strings.Split(string(b), "\n") {
+			if s != "" {
+				h.entries = append(h.entries, s)
+			}
+		}
+	}
+	return h
+}
+
+// add appends s to the history, dropping earlier copies of it and the
+// oldest answers beyond the size, and saves the history. Errors saving
+// it are ignored, as history is only a convenience.
+func (h *history) add(s string) {
+	if s == "" || strings.Contains(s, "\n") {
+		return
+	}
+	entries := h.entries[:0:0]
+	for _, e := range h.entries {
+		if e != s {
+			entries = append(entries, e)
+		}
+	}
+	entries = append(entries, s)
+	if len(entries) > h.opts.Size {
+		entries = entries[len(entries)-h.opts.Size:]
+	}
+	h.entries = entries
+	if h.opts.InMemory {
+		inMemoryHistories.Lock()
+		inMemoryHistories.m[h.key] = append([]string(nil), entries...)
+		inMemoryHistories.Unlock()
+		return
+	}
+	name := historyFile(h.key)
+	if err := os.MkdirAll(filepath.Dir(name), 0700); err != nil {
+		return
+	}
+	ioutil.WriteFile(name, []byte(strings.Join(entries, "\n")+"\n"), 0600)
+}
+
+// historyFile returns the name of the file holding the history
+// of the prompt with the key.
+func historyFile(key string) string {
+	return stateFile("history", sanitizeFileName(key))
+}
+
+// stateFile returns the name of a file the program keeps its state in,
+// under the state directory of the user.
+func stateFile(elem ...string) string {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		if runtime.GOOS == "windows" {
+			dir, _ = os.UserCacheDir()
+		} else if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, ".local", "state")
+		}
+	}
+	program := strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe")
+	return filepath.Join(append([]string{dir, program}, elem...)...)
+}
+
+// sanitizeFileName replaces the characters of s that are not safe
+// in file names. If it has to, it appends a hash of s, so that keys
+// such as "db host" and "db/host" keep apart.
+func sanitizeFileName(s string) string {
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		}
+		return '_'
+	}, s)
+	if safe == s && s != "" && s != "." && s != ".." {
+		return s
+	}
+	h := fnv.New32a()
+	h.Write([]byte(s))
+	return fmt.Sprintf("%s-%08x", safe, h.Sum32())
+}
@@ -0,0 +1,229 @@
+package prompts
+
+import (
+	"context"
+	"io"
+)
+
+// InputOptions configures Input.
+type InputOptions struct {
+	// Default is shown next to the label and returned
+	// when the user presses Enter without typing anything.
+	Default string
+	// Placeholder is shown dimmed while nothing is typed.
+	Placeholder string
+	// Hint is shown dimmed after the label while the answer is asked,
+	// such as "(leave blank to skip)".
+	Hint string
+	// EditMode selects emacs or vi keys for editing the answer.
+	EditMode EditMode
+}
+
+func (o InputOptions) apply(c *config) { c.input = o }
+
+// Input asks for a single line of text using the label. The line is edited
+// with the usual readline keys, such as Ctrl+A, Ctrl+E, Alt+B, Alt+F,
+// Ctrl+W and Ctrl+U, which the Keymap can change. With WithHistory, Up and
+// Down recall earlier answers. The answer is cleaned up by the transforms
+// given with WithTransform and then checked by the validators given with
+// WithValidator.
+//
+// If the standard input is not a terminal, the answer is the next line
+// read from it.
+//
+// It returns ErrInterrupted if the user presses Ctrl+C and io.EOF if the
+// user presses Ctrl+D on an empty line or the input ends.
+func Input(label string, opts ...Option) (string, error) {
+	return InputContext(context.Background(), label, opts...)
+}
+
+// InputContext is like Input but gives up when ctx is done,
+// returning ctx.Err().
+func InputContext(ctx context.Context, label string, opts ...Option) (string, error) {
+	c := newConfig(label, opts)
+	m := newInputModel(label, c)
+	if err := run(ctx, c, m); err != nil {
+		return "", err
+	}
+	return m.value(), nil
+}
+
+func newInputModel(label string, c *config) *inputModel {
+	m := &inputModel{
+		label:      label,
+		theme:      &c.theme,
+		keys:       &c.keymap,
+		opts:       c.input,
+		transforms: c.transforms,
+		validators: c.validators,
+	}
+	if s, ok := c.initial.(string); ok && c.remembered {
+		// Offer the last answer as the default rather than typing it.
+		m.opts.Default = s
+	} else if ok {
+		m.line = line{buf: []rune(s), pos: len([]rune(s))}
+	}
+	if c.history != nil {
+		m.history = loadHistory(c.key, *c.history)
+		m.recalled = len(m.history.entries)
+	}
+	if mode := c.input.EditMode; mode == EditVi || mode == EditAuto && viEnabled() {
+		m.vi = &viLine{}
+	}
+	return m
+}
+
+type inputModel struct {
+	label      string
+	theme      *Theme
+	keys       *Keymap
+	opts       InputOptions
+	transforms []Transform
+	validators []Validator
+	line       line
+	// vi is set when editing with vi keys.
+	vi *viLine
+	// history is set when earlier answers can be recalled. recalled is the
+	// index of the one being edited, or the number of them for a new
+	// answer, which draft then holds while earlier ones are recalled.
+	history  *history
+	recalled int
+	draft    string
+	// err is why the last answer was rejected.
+	err  error
+	done bool
+}
+
+func (m *inputModel) value() string {
+	s := transform(m.transforms, m.line.String())
+	if s == "" {
+		return m.opts.Default
+	}
+	return s
+}
+
+func (m *inputModel) defaultAnswer() string {
+	return m.value()
+}
+
+func (m *inputModel) result() interface{} {
+	return m.value()
+}
+
+func (m *inputModel) prompt() string {
+	label := m.theme.hinted(m.label, m.opts.Hint)
+	if m.opts.Default != "" {
+		return label + " (" + m.opts.Default + ") "
+	}
+	return label + " "
+}
+
+func (m *inputModel) answer(s string) (bool, error) {
+	m.line = line{buf: []rune(s)}
+	if err := validate(m.validators, m.value()); err != nil {
+		return false, err
+	}
+	m.done = true
+	return true, nil
+}
+
+// captures reports whether the prompt takes k before a Wizard gets it.
+func (m *inputModel) captures(k key) bool {
+	return m.vi != nil && m.vi.captures(k)
+}
+
+func (m *inputModel) rejected() error {
+	return m.err
+}
+
+func (m *inputModel) update(k key) (bool, error) {
+	if m.history != nil {
+		viNormal := m.vi != nil && m.vi.normal && m.vi.pending == ""
+		switch {
+		case bound(m.keys.Up, k) || viNormal && k.name == "k":
+			m.recall(m.recalled - 1)
+			return false, nil
+		case bound(m.keys.Down, k) || viNormal && k.name == "j":
+			m.recall(m.recalled + 1)
+			return false, nil
+		}
+	}
+	if m.vi != nil && m.vi.edit(&m.line, k, m.keys) {
+		m.err = nil
+		return false, nil
+	}
+	switch {
+	case bound(m.keys.Submit, k):
+		if m.err = validate(m.validators, m.value()); m.err != nil {
+			return false, nil
+		}
+		m.done = true
+		if m.history != nil {
+			m.history.add(transform(m.transforms, m.line.String()))
+		}
+		return true, nil
+	case k.name == "ctrl+d":
+		if len(m.line.buf) == 0 {
+			return false, io.EOF
+		}
+	}
+	if m.line.edit(k, m.keys) {
+		m.err = nil
+	}
+	return false, nil
+}
+
+func (m *inputModel) keyHelp() ([]keyAction, bool) {
+	var actions []keyAction
+	if m.history != nil {
+		actions = append(actions, act("recall earlier answers", m.keys.Up, m.keys.Down))
+	}
+	actions = append(actions, editActions(m.keys)...)
+	return append(actions, act("accept the answer", m.keys.Submit)), true
+}
+
+// recall replaces the line with the i-th earlier answer,
+// or with the draft of a new one past the last of them.
+func (m *inputModel) recall(i int) {
+	n := len(m.history.entries)
+	if i < 0 || i > n || i == m.recalled {
+		return
+	}
+	if m.recalled == n {
+		m.draft = m.line.String()
+	}
+	s := m.draft
+	if i < n {
+		s = m.history.entries[i]
+	}
+	m.recalled = i
+	m.line = line{buf: []rune(s), pos: len([]rune(s))}
+	if m.vi != nil && m.vi.normal {
+		m.line.left()
+	}
+	m.err = nil
+}
+
+func (m *inputModel) view() frame {
+	if m.done {
+		return frame{lines: []string{m.theme.answered(m.label, m.value())}}
+	}
+	return m.editing(m.prompt())
+}
+
+// editing renders the answer being typed after prompt.
+func (m *inputModel) editing(prompt string) frame {
+	text := m.line.String()
+	if text == "" && m.opts.Placeholder != "" {
+		text = m.theme.render(m.theme.Hint, m.opts.Placeholder)
+	}
+	lines := []string{prompt + text}
+	if m.err != nil {
+		lines = append(lines, m.theme.errorLine(m.err))
+	}
+	return frame{
+		lines:      lines,
+		cursorCol:  textWidth(prompt + string(m.line.buf[:m.line.pos])),
+		showCursor: true,
+	}
+}
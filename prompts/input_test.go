@@ -0,0 +1,56 @@
+package prompts_test
+
+import (
+	"testing"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/prompttest"
+	rules "github.com/tidalmigrations/interactive-cli-prompts/prompts/validate"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []prompts.Option
+		keys string
+		want string
+	}{
+		{"typed", nil, "Gopher" + prompttest.Enter, "Gopher"},
+		{"default", []prompts.Option{prompts.InputOptions{Default: "Gopher"}}, prompttest.Enter, "Gopher"},
+		{"typed over default", []prompts.Option{prompts.InputOptions{Default: "Gopher"}}, "Go" + prompttest.Enter, "Go"},
+		{"backspace", nil, "Gophet" + prompttest.Backspace + "r" + prompttest.Enter, "Gopher"},
+		{"insert", nil, "Gpher" + prompttest.Left + prompttest.Left + prompttest.Left + prompttest.Left + "o" + prompttest.Enter, "Gopher"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term := prompttest.New(t)
+			res := prompttest.Start(term, func(o prompts.Option) (string, error) {
+				return prompts.Input("Name?", append(tt.opts, o)...)
+			})
+			term.WaitFor("Name?")
+			term.Send(tt.keys)
+			got, err := res.Wait()
+			if err != nil || got != tt.want {
+				t.Fatalf("Input() = %q, %v, want %q, nil", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestInputValidator(t *testing.T) {
+	term := prompttest.New(t)
+	res := prompttest.Start(term, func(o prompts.Option) (string, error) {
+		return prompts.Input("Name?", prompts.WithValidator(rules.Required()), o)
+	})
+	term.WaitFor("Name?")
+	term.Send(prompttest.Enter)
+	term.WaitFor("a value is required")
+	term.Send("Gopher" + prompttest.Enter)
+	got, err := res.Wait()
+	if err != nil || got != "Gopher" {
+		t.Fatalf("Input() = %q, %v, want %q, nil", got, err, "Gopher")
+	}
+	if screen := term.Screen(); screen != "Name? Gopher" {
+		t.Errorf("screen shows %q once answered, want the answer only", screen)
+	}
+}
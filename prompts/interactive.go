@@ -0,0 +1,209 @@
+package prompts
+
+import (
+	"flag"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+// An InteractivityMode is whether prompts ask their questions on the
+// terminal.
+type InteractivityMode int
+
+const (
+	// NonInteractive sessions have nobody to answer on a terminal: prompts
+	// read their answers as lines, as they do from piped input.
+	NonInteractive InteractivityMode = iota
+	// Interactive sessions have terminals for input and output.
+	Interactive
+	// ForcedInteractive sessions are interactive because the environment
+	// says so, whatever the checks would find: prompts ask on the
+	// controlling terminal when the standard input is not one.
+	ForcedInteractive
+)
+
+func (m InteractivityMode) String() string {
+	switch m {
+	case Interactive:
+		return "interactive"
+	case ForcedInteractive:
+		return "forced"
+	}
+	return "non-interactive"
+}
+
+// InteractivityResult is what Interactivity finds.
+type InteractivityResult struct {
+	Mode InteractivityMode
+	// Reason tells why the session is not interactive, or forced,
+	// e.g. "TERM is dumb".
+	Reason string
+}
+
+// Interactive reports whether prompts ask on the terminal.
+func (r InteractivityResult) Interactive() bool {
+	return r.Mode != NonInteractive
+}
+
+// ciVariables are set by continuous integration services.
+var ciVariables = []string{
+	"CONTINUOUS_INTEGRATION", "BUILD_NUMBER", "RUN_ID", "GITHUB_ACTIONS",
+	"GITLAB_CI", "TRAVIS", "CIRCLECI", "JENKINS_URL", "TEAMCITY_VERSION",
+	"BUILDKITE", "TF_BUILD", "APPVEYOR", "DRONE", "BITBUCKET_BUILD_NUMBER",
+	"CODEBUILD_BUILD_ID",
+}
+
+// Interactivity finds whether the session is interactive: it is unless
+// the standard input is not a terminal, as with "ssh -T" or piped input,
+// neither the standard output nor the standard error is one, TERM is
+// "dumb", or CI is set to anything but "false" or the variables of known
+// CI services are set. The InputMode overrides what it finds: ForceInput
+// makes it forced, and NoInput and DefaultInput not interactive.
+//
+// Prompts reading the standard input consult it: unless the session is
+// interactive, they read their answers as lines, as WithAccessible makes
+// them, and prompts that cannot fail with ErrNotATerminal.
+func Interactivity() InteractivityResult {
+	return InteractivityOf(os.Stdin)
+}
+
+// InteractivityOf is like Interactivity for prompts reading r, as given
+// with WithInput, which consult it in the same way. Unless r is the
+// standard input, it checks that r is a terminal instead of the standard
+// input and output.
+func InteractivityOf(r io.Reader) InteractivityResult {
+	switch mode, reason := currentInputMode(); mode {
+	case ForceInput:
+		return InteractivityResult{ForcedInteractive, reason}
+	case NoInput, DefaultInput:
+		return InteractivityResult{NonInteractive, reason}
+	}
+	f, ok := terminalFile(r)
+	stdin := f == os.Stdin
+	if !ok {
+		if stdin {
+			return InteractivityResult{NonInteractive, "the standard input is not a terminal"}
+		}
+		return InteractivityResult{NonInteractive, "the input is not a terminal"}
+	}
+	if stdin && !term.IsTerminal(int(os.Stdout.Fd())) && !term.IsTerminal(int(os.Stderr.Fd())) {
+		return InteractivityResult{NonInteractive, "the output is not a terminal"}
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return InteractivityResult{NonInteractive, "TERM is dumb"}
+	}
+	if ci := strings.ToLower(os.Getenv("CI")); ci != "" && ci != "false" && ci != "0" {
+		return InteractivityResult{NonInteractive, "CI is set"}
+	}
+	for _, name := range ciVariables {
+		if os.Getenv(name) != "" {
+			return InteractivityResult{NonInteractive, name + " is set"}
+		}
+	}
+	return InteractivityResult{Mode: Interactive}
+}
+
+// IsInteractive reports whether it is fine to use prompts, as Interactivity
+// finds.
+func IsInteractive() bool {
+	return Interactivity().Interactive()
+}
+
+// An InputMode is whether prompts ask for input at all.
+type InputMode int
+
+const (
+	// AutoInput prompts ask as Interactivity finds they can.
+	AutoInput InputMode = iota
+	// NoInput prompts fail with ErrNoInput rather than ask, for runs
+	// nobody is there to answer.
+	NoInput
+	// DefaultInput prompts take the answer they would if Enter was pressed
+	// at once, such as the default of Input or the option Select starts on,
+	// and fail with ErrNoInput if that is no answer.
+	DefaultInput
+	// ForceInput prompts ask on the terminal even when Interactivity finds
+	// the session is not interactive.
+	ForceInput
+)
+
+var (
+	inputMu                sync.Mutex
+	inputMode, inputReason = environmentInputMode()
+)
+
+// SetInputMode sets the InputMode of all prompts. Answers preset with
+// WithEnvFallback or WithAnswersFile are taken in every mode. It defaults
+// to NoInput when NO_INPUT is set to anything but the empty string, or
+// DefaultInput when it is set to "defaults", and to ForceInput when
+// FORCE_INTERACTIVE is set to anything but the empty string.
+func SetInputMode(m InputMode) {
+	setInputMode(m, "the input mode is set")
+}
+
+func setInputMode(m InputMode, reason string) {
+	inputMu.Lock()
+	defer inputMu.Unlock()
+	inputMode, inputReason = m, reason
+}
+
+// currentInputMode returns the InputMode and what set it.
+func currentInputMode() (InputMode, string) {
+	inputMu.Lock()
+	defer inputMu.Unlock()
+	return inputMode, inputReason
+}
+
+// environmentInputMode returns the InputMode set by the environment.
+func environmentInputMode() (InputMode, string) {
+	switch v := os.Getenv("NO_INPUT"); {
+	case v == "defaults":
+		return DefaultInput, "NO_INPUT is set"
+	case v != "":
+		return NoInput, "NO_INPUT is set"
+	case os.Getenv("FORCE_INTERACTIVE") != "":
+		return ForceInput, "FORCE_INTERACTIVE is set"
+	}
+	return AutoInput, ""
+}
+
+// InputFlags defines the flags --no-input, --defaults and
+// --force-interactive in fs, which set the InputMode to NoInput,
+// DefaultInput and ForceInput when given. Programs using the pflag package
+// can add them with AddGoFlagSet.
+func InputFlags(fs *flag.FlagSet) {
+	fs.Var(&inputFlag{mode: NoInput, name: "no-input"}, "no-input", "do not ask for input, failing instead")
+	fs.Var(&inputFlag{mode: DefaultInput, name: "defaults"}, "defaults", "take the default answers instead of asking")
+	fs.Var(&inputFlag{mode: ForceInput, name: "force-interactive"}, "force-interactive", "ask for input even if the terminal does not seem interactive")
+}
+
+// inputFlag is a boolean flag setting the InputMode to mode.
+type inputFlag struct {
+	mode InputMode
+	name string
+	on   bool
+}
+
+func (f *inputFlag) IsBoolFlag() bool { return true }
+
+func (f *inputFlag) String() string {
+	return strconv.FormatBool(f != nil && f.on)
+}
+
+func (f *inputFlag) Set(s string) error {
+	on, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	if f.on = on; on {
+		setInputMode(f.mode, "--"+f.name+" is given")
+	} else if mode, _ := currentInputMode(); mode == f.mode {
+		setInputMode(AutoInput, "")
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package prompts_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/prompttest"
+)
+
+func TestInteractivityWithInput(t *testing.T) {
+	tests := []struct {
+		name, env, value string
+	}{
+		{"dumb terminal", "TERM", "dumb"},
+		{"CI", "CI", "true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(tt.env, tt.value)
+			term := prompttest.New(t)
+			res := prompttest.Start(term, func(prompts.Option) (string, error) {
+				s, _, err := prompts.Select("Color?", []string{"red", "green", "blue"},
+					prompts.WithInput(term.File()), prompts.WithOutput(term.File()))
+				return s, err
+			})
+			term.WaitFor("Color?")
+			// Asked a line at a time, the options are not listed to
+			// move through.
+			if got := term.Screen(); got != "Color?" {
+				t.Fatalf("screen shows\n%s\nwant the label only", got)
+			}
+			term.Send("green\n")
+			color, err := res.Wait()
+			if err != nil || color != "green" {
+				t.Fatalf("Select() = %q, %v, want %q, nil", color, err, "green")
+			}
+		})
+	}
+}
+
+func TestInteractivityWithTerminal(t *testing.T) {
+	t.Setenv("TERM", "dumb")
+	t.Setenv("CI", "true")
+	term := prompttest.New(t)
+	res := prompttest.Start(term, func(o prompts.Option) (string, error) {
+		return prompts.Input("Name?", o)
+	})
+	term.WaitFor("Name?")
+	term.Send("Gopher" + prompttest.Enter)
+	if name, err := res.Wait(); err != nil || name != "Gopher" {
+		t.Fatalf("Input() = %q, %v, want %q, nil", name, err, "Gopher")
+	}
+}
+
+func TestInteractivityOf(t *testing.T) {
+	t.Setenv("TERM", "dumb")
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	tests := []struct {
+		name   string
+		in     func() *os.File
+		reason string
+	}{
+		{"pipe", func() *os.File { return r }, "the input is not a terminal"},
+		{"dumb terminal", func() *os.File { return prompttest.New(t).File() }, "TERM is dumb"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := prompts.InteractivityOf(tt.in())
+			if got.Interactive() || got.Reason != tt.reason {
+				t.Errorf("InteractivityOf() = %+v, want non-interactive because %s", got, tt.reason)
+			}
+		})
+	}
+	if got := prompts.InteractivityOf(strings.NewReader("")); got.Interactive() {
+		t.Errorf("InteractivityOf() of a strings.Reader = %+v, want non-interactive", got)
+	}
+}
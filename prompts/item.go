@@ -0,0 +1,105 @@
+package prompts
+
+import "context"
+
+// Item is an option of SelectItem and CheckboxesItems. The prompt shows
+// the Label, with the Description next to it, and returns the whole item,
+// so that the Value can identify it whatever the label says.
+type Item struct {
+	Label       string
+	Value       string
+	Description string
+}
+
+// SelectItem is like Select but picks one of items, which it returns.
+// Options given with SelectOptions apply to the labels of the items,
+// and the descriptions of the items are added to their Descriptions.
+func SelectItem(label string, items []Item, opts ...Option) (Item, error) {
+	return SelectItemContext(context.Background(), label, items, opts...)
+}
+
+// SelectItemContext is like SelectItem but gives up when ctx is done,
+// returning ctx.Err().
+func SelectItemContext(ctx context.Context, label string, items []Item, opts ...Option) (Item, error) {
+	i, err := selectItem(ctx, label, items, opts)
+	if err != nil {
+		return Item{}, err
+	}
+	return items[i], nil
+}
+
+// selectItem asks as SelectItemContext does and returns the index
+// of the picked item.
+func selectItem(ctx context.Context, label string, items []Item, opts []Option) (int, error) {
+	labels, descriptions := itemLabels(items)
+	opts = append(opts, optionFunc(func(c *config) {
+		c.selectOptions.Descriptions = mergeDescriptions(c.selectOptions.Descriptions, descriptions)
+	}))
+	_, i, err := SelectContext(ctx, label, labels, opts...)
+	return i, err
+}
+
+// CheckboxesItems is like Checkboxes but picks any number of items,
+// which it returns. Options given with CheckboxesOptions apply to the
+// labels of the items, and the descriptions of the items are added to
+// their Descriptions.
+func CheckboxesItems(label string, items []Item, opts ...Option) ([]Item, error) {
+	return CheckboxesItemsContext(context.Background(), label, items, opts...)
+}
+
+// CheckboxesItemsContext is like CheckboxesItems but gives up when ctx
+// is done, returning ctx.Err().
+func CheckboxesItemsContext(ctx context.Context, label string, items []Item, opts ...Option) ([]Item, error) {
+	checked, err := checkboxesItems(ctx, label, items, opts)
+	if err != nil {
+		return nil, err
+	}
+	picked := []Item{}
+	for i, ok := range checked {
+		if ok {
+			picked = append(picked, items[i])
+		}
+	}
+	return picked, nil
+}
+
+// checkboxesItems asks as CheckboxesItemsContext does and returns
+// whether each item is checked.
+func checkboxesItems(ctx context.Context, label string, items []Item, opts []Option) ([]bool, error) {
+	labels, descriptions := itemLabels(items)
+	opts = append(opts, optionFunc(func(c *config) {
+		c.checkboxes.Descriptions = mergeDescriptions(c.checkboxes.Descriptions, descriptions)
+	}))
+	m, err := checkboxes(ctx, label, labels, opts)
+	if err != nil {
+		return nil, err
+	}
+	return m.checked, nil
+}
+
+// itemLabels returns the labels of items and their descriptions
+// by label.
+func itemLabels(items []Item) ([]string, map[string]string) {
+	labels := make([]string, len(items))
+	descriptions := map[string]string{}
+	for i, it := range items {
+		labels[i] = it.Label
+		if it.Description != "" {
+			descriptions[it.Label] = it.Description
+		}
+	}
+	return labels, descriptions
+}
+
+// mergeDescriptions returns the descriptions of both d and more,
+// preferring those of d.
+func mergeDescriptions(d, more map[string]string) map[string]string {
+	res := make(map[string]string, len(d)+len(more))
+	for k, v := range more {
+		res[k] = v
+	}
+	for k, v := range d {
+		res[k] = v
+	}
+	return res
+}
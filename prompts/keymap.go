@@ -0,0 +1,139 @@
+package prompts
+
+import (
+	"strings"
+	"sync"
+)
+
+// Keymap binds keys to the actions of prompts. Each action lists the names
+// of the keys bound to it: printable characters as typed, such as "j", and
+// "space", "enter", "tab", "shift+tab", "esc", "backspace", "delete",
+// "up", "down", "left", "right", "home", "end", "pgup", "pgdown",
+// "f1" to "f4", or letters and arrows with modifiers, such as "ctrl+n",
+// "alt+b" or "shift+up".
+//
+// Binding a printable character to an action keeps it from being typed
+// in text prompts. SetKeymap installs a keymap for all prompts and
+// WithKeymap for a single prompt.
+type Keymap struct {
+	// Up and Down move through the options of Select and Checkboxes,
+	// the suggestions of Autocomplete and the history of Input.
+	Up, Down []string
+	// PageUp and PageDown move through the options of Select and
+	// Checkboxes by a page.
+	PageUp, PageDown []string
+	// Left and Right collapse and expand the directories of DirectoryTree
+	// and the groups of Select and Checkboxes, and move the cursor of text
+	// prompts by a character, WordLeft and WordRight by a word, and Home
+	// and End to the start and the end of the line.
+	Left, Right         []string
+	WordLeft, WordRight []string
+	Home, End           []string
+	// DeleteWord deletes the word before the cursor of text prompts,
+	// DeleteToStart everything before it and DeleteToEnd everything after.
+	DeleteWord, DeleteToStart, DeleteToEnd []string
+	// Toggle checks or unchecks an option of Checkboxes. CheckAll checks
+	// all the options matching the filter, UncheckAll unchecks them and
+	// InvertAll checks those that are not checked and unchecks the others.
+	Toggle                          []string
+	CheckAll, UncheckAll, InvertAll []string
+	// MoveUp and MoveDown move the option under the cursor of Reorder
+	// up and down.
+	MoveUp, MoveDown []string
+	// Sort sorts the rows of SelectTable by the next column.
+	Sort []string
+	// SwitchPane moves the cursor between the lists of Transfer.
+	SwitchPane []string
+	// Complete takes the highlighted suggestion of Autocomplete,
+	// or the first one, as the text typed so far.
+	Complete []string
+	// Help shows what the keys of the prompt do, with the help of the
+	// option under the cursor of Select and Checkboxes, until the next key
+	// is pressed. Printable keys bound to it are typed in text prompts.
+	Help []string
+	// Submit accepts the answer.
+	Submit []string
+	// Cancel gives up, making the prompt return ErrInterrupted.
+	Cancel []string
+	// Back goes back to the previous step of a Wizard.
+	Back []string
+}
+
+// DefaultKeymap returns the keymap prompts use unless told otherwise.
+func DefaultKeymap() Keymap {
+	return Keymap{
+		Up:            []string{"up"},
+		Down:          []string{"down"},
+		PageUp:        []string{"pgup"},
+		PageDown:      []string{"pgdown"},
+		Left:          []string{"left", "ctrl+b"},
+		Right:         []string{"right", "ctrl+f"},
+		WordLeft:      []string{"alt+b", "alt+left", "ctrl+left"},
+		WordRight:     []string{"alt+f", "alt+right", "ctrl+right"},
+		Home:          []string{"home", "ctrl+a"},
+		End:           []string{"end", "ctrl+e"},
+		DeleteWord:    []string{"ctrl+w", "alt+backspace"},
+		DeleteToStart: []string{"ctrl+u"},
+		DeleteToEnd:   []string{"ctrl+k"},
+		Toggle:        []string{"space"},
+		CheckAll:      []string{"alt+a"},
+		UncheckAll:    []string{"alt+n"},
+		InvertAll:     []string{"alt+i"},
+		MoveUp:        []string{"ctrl+up", "alt+up"},
+		MoveDown:      []string{"ctrl+down", "alt+down"},
+		Sort:          []string{"ctrl+s"},
+		SwitchPane:    []string{"tab"},
+		Complete:      []string{"tab"},
+		Help:          []string{"?", "f1"},
+		Submit:        []string{"enter"},
+		Cancel:        []string{"ctrl+c"},
+		Back:          []string{"esc"},
+	}
+}
+
+var (
+	keymapMu sync.Mutex
+	keymap   = DefaultKeymap()
+)
+
+// SetKeymap makes km the keymap of all prompts not given WithKeymap.
+func SetKeymap(km Keymap) {
+	keymapMu.Lock()
+	defer keymapMu.Unlock()
+	keymap = km
+}
+
+func currentKeymap() Keymap {
+	keymapMu.Lock()
+	defer keymapMu.Unlock()
+	return keymap
+}
+
+// WithKeymap makes a prompt use the keys of km instead of the installed keymap.
+func WithKeymap(km Keymap) Option {
+	return optionFunc(func(c *config) { c.keymap = km })
+}
+
+// bound reports whether k is one of keys.
+func bound(keys []string, k key) bool {
+	for _, name := range keys {
+		if name == k.name {
+			return true
+		}
+	}
+	return false
+}
+
+// keyLabel returns how the first of keys is shown in hints, e.g. "Ctrl+B".
+func keyLabel(keys []string) string {
+	if len(keys) == 0 {
+		return ""
+	}
+	parts := strings.Split(keys[0], "+")
+	for i, p := range parts {
+		if len(p) > 1 || i > 0 {
+			parts[i] = strings.ToUpper(p[:1]) + p[1:]
+		}
+	}
+	return strings.Join(parts, "+")
+}
@@ -0,0 +1,260 @@
+package prompts
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// key is a single key press read from the terminal.
+type key struct {
+	// name identifies the key, e.g. "a", "space", "enter",
+	// "ctrl+a", "alt+b" or "shift+up".
+	name string
+	// r is the typed character of printable keys and zero otherwise.
+	r rune
+	// x and y are the screen column and row, counted from 1, of mouse
+	// events, named "click", "wheelup", "wheeldown" or "mouse", and of
+	// replies to where the cursor is, named "position".
+	x, y int
+	// paste is the text of a bracketed paste, named "paste".
+	paste []rune
+}
+
+// Escape sequences turning bracketed paste on and off. While it is on,
+// terminals send what is pasted between pasteStart and pasteEnd, so that
+// it is read as text rather than as keys.
+const (
+	pasteOn    = "\x1b[?2004h"
+	pasteOff   = "\x1b[?2004l"
+	pasteStart = "\x1b[200~"
+	pasteEnd   = "\x1b[201~"
+)
+
+// keyReader decodes key presses from raw terminal input.
+// It zeroes the input it is done with, as it may be a password.
+type keyReader struct {
+	r     io.Reader
+	buf   []byte
+	chunk [256]byte
+}
+
+func newKeyReader(r io.Reader) *keyReader {
+	return &keyReader{r: r}
+}
+
+// readKey returns the next key press, reading more input when needed.
+func (kr *keyReader) readKey() (key, error) {
+	for {
+		if len(kr.buf) > 0 {
+			k, n := decodeKey(kr.buf)
+			if n > 0 {
+				rest := copy(kr.buf, kr.buf[n:])
+				Wipe(kr.buf[rest:])
+				kr.buf = kr.buf[:rest]
+				if k.name == pasteStart {
+					return kr.readPaste()
+				}
+				return k, nil
+			}
+		}
+		if err := kr.fill(); err != nil {
+			if len(kr.buf) > 0 {
+				// Flush an incomplete sequence as is.
+				k := key{name: string(kr.buf)}
+				Wipe(kr.buf)
+				kr.buf = kr.buf[:0]
+				return k, nil
+			}
+			return key{}, err
+		}
+	}
+}
+
+// readPaste reads the text pasted up to the end of a bracketed paste,
+// or up to the end of the input if the paste is cut short.
+func (kr *keyReader) readPaste() (key, error) {
+	for {
+		end := bytes.Index(kr.buf, []byte(pasteEnd))
+		if end < 0 {
+			if err := kr.fill(); err == nil {
+				continue
+			} else if len(kr.buf) == 0 {
+				return key{}, err
+			}
+			end = len(kr.buf)
+		}
+		var text []rune
+		for b := kr.buf[:end]; len(b) > 0; {
+			r, n := utf8.DecodeRune(b)
+			text = append(text, r)
+			b = b[n:]
+		}
+		n := end + len(pasteEnd)
+		if n > len(kr.buf) {
+			n = len(kr.buf)
+		}
+		rest := copy(kr.buf, kr.buf[n:])
+		Wipe(kr.buf[rest:])
+		kr.buf = kr.buf[:rest]
+		return key{name: "paste", paste: text}, nil
+	}
+}
+
+func (kr *keyReader) fill() error {
+	n, err := kr.r.Read(kr.chunk[:])
+	kr.buf = append(kr.buf, kr.chunk[:n]...)
+	Wipe(kr.chunk[:n])
+	if n > 0 {
+		return nil
+	}
+	if err == nil {
+		err = io.ErrNoProgress
+	}
+	return err
+}
+
+// decodeKey decodes the key at the start of b and returns it with the
+// number of bytes it takes. It returns zero when b holds an incomplete
+// character and more input is needed.
+//
+// Escape sequences are expected to arrive in a single read, so an escape
+// at the end of b is the Esc key rather than the start of a sequence.
+func decodeKey(b []byte) (key, int) {
+	switch c := b[0]; {
+	case c == 0x1b:
+		if len(b) == 1 {
+			return key{name: "esc"}, 1
+		}
+		switch b[1] {
+		case '[':
+			return decodeCSI(b)
+		case 'O':
+			if len(b) > 2 {
+				if name, ok := ss3Keys[b[2]]; ok {
+					return key{name: name}, 3
+				}
+			}
+		case 0x1b:
+			return key{name: "esc"}, 1
+		}
+		k, n := decodeKey(b[1:])
+		if n == 0 {
+			return key{}, 0
+		}
+		return key{name: "alt+" + k.name}, n + 1
+	case c == '\r' || c == '\n':
+		return key{name: "enter"}, 1
+	case c == '\t':
+		return key{name: "tab"}, 1
+	case c == 0x7f || c == 0x08:
+		return key{name: "backspace"}, 1
+	case c == 0:
+		return key{name: "ctrl+space"}, 1
+	case c < 0x1b:
+		return key{name: "ctrl+" + string(rune('a'+c-1))}, 1
+	case c < 0x20:
+		return key{name: "ctrl+" + string(rune('\\'+c-0x1c))}, 1
+	case c == ' ':
+		return key{name: "space", r: ' '}, 1
+	}
+	if !utf8.FullRune(b) {
+		return key{}, 0
+	}
+	r, n := utf8.DecodeRune(b)
+	return key{name: string(r), r: r}, n
+}
+
+var ss3Keys = map[byte]string{
+	'A': "up", 'B': "down", 'C': "right", 'D': "left",
+	'H': "home", 'F': "end",
+	'P': "f1", 'Q': "f2", 'R': "f3", 'S': "f4",
+}
+
+var csiLetterKeys = map[byte]string{
+	'A': "up", 'B': "down", 'C': "right", 'D': "left",
+	'H': "home", 'F': "end", 'Z': "shift+tab",
+}
+
+var csiTildeKeys = map[int]string{
+	1: "home", 2: "insert", 3: "delete", 4: "end",
+	5: "pgup", 6: "pgdown", 7: "home", 8: "end",
+}
+
+// decodeCSI decodes a control sequence such as "\x1b[1;5A".
+func decodeCSI(b []byte) (key, int) {
+	i := 2
+	for i < len(b) && (b[i] < 0x40 || b[i] > 0x7e) {
+		i++
+	}
+	if i == len(b) {
+		return key{name: string(b)}, len(b)
+	}
+	n := i + 1
+	params := strings.Split(string(b[2:i]), ";")
+	if strings.HasPrefix(params[0], "<") && (b[i] == 'M' || b[i] == 'm') && len(params) == 3 {
+		return decodeMouse(params, b[i] == 'M'), n
+	}
+	if b[i] == 'R' && len(params) == 2 {
+		y, _ := strconv.Atoi(params[0])
+		x, _ := strconv.Atoi(params[1])
+		return key{name: "position", x: x, y: y}, n
+	}
+	var name string
+	if b[i] == '~' {
+		code, _ := strconv.Atoi(params[0])
+		name = csiTildeKeys[code]
+	} else {
+		name = csiLetterKeys[b[i]]
+	}
+	if name == "" {
+		return key{name: string(b[:n])}, n
+	}
+	if len(params) > 1 {
+		name = modifierPrefix(params[1]) + name
+	}
+	return key{name: name}, n
+}
+
+// decodeMouse decodes the parameters of an SGR mouse event, such as
+// "<0;12;5", for a press or, unless pressed, a release.
+func decodeMouse(params []string, pressed bool) key {
+	button, _ := strconv.Atoi(params[0][1:])
+	x, _ := strconv.Atoi(params[1])
+	y, _ := strconv.Atoi(params[2])
+	name := "mouse"
+	// Shift, Alt and Ctrl add 4, 8 and 16 to the button.
+	switch button &^ 28 {
+	case 0:
+		if pressed {
+			name = "click"
+		}
+	case 64:
+		name = "wheelup"
+	case 65:
+		name = "wheeldown"
+	}
+	return key{name: name, x: x, y: y}
+}
+
+// modifierPrefix converts an xterm modifier parameter to a key name prefix.
+func modifierPrefix(param string) string {
+	m, err := strconv.Atoi(param)
+	if err != nil || m < 2 {
+		return ""
+	}
+	m--
+	var prefix string
+	if m&4 != 0 {
+		prefix += "ctrl+"
+	}
+	if m&2 != 0 {
+		prefix += "alt+"
+	}
+	if m&1 != 0 {
+		prefix += "shift+"
+	}
+	return prefix
+}
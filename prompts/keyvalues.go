@@ -0,0 +1,302 @@
+package prompts
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+)
+
+// KeyValuesOptions configures KeyValues.
+type KeyValuesOptions struct {
+	// Default holds the pairs to begin with.
+	Default map[string]string
+	// Keys and Values, if set, check the keys and the values of pairs
+	// before they are added.
+	Keys, Values Validator
+}
+
+func (o KeyValuesOptions) apply(c *config) { c.keyValues = o }
+
+// KeyValues asks for pairs of keys and values using the label, such as
+// environment variables or labels, and returns them. Pairs are typed as
+// KEY=VALUE and added with Enter, replacing the pair with the same key if
+// there is one, and Enter with nothing typed accepts the pairs. Up and Down
+// move to the pairs listed above, Enter takes the pair under the cursor
+// out of the list to edit it and Backspace or Delete removes it. The
+// validators given with WithValidator are given each pair as KEY=VALUE.
+//
+// If the standard input is not a terminal, the pairs are the lines read
+// from it up to an empty line or the end of the input. An empty line
+// right away keeps the default pairs. An answer preset as a single value,
+// such as in an environment variable, lists the pairs one per line, or
+// separated by commas, such as A=1,B=2.
+//
+// It returns ErrInterrupted if the user presses Ctrl+C.
+func KeyValues(label string, opts ...Option) (map[string]string, error) {
+	return KeyValuesContext(context.Background(), label, opts...)
+}
+
+// KeyValuesContext is like KeyValues but gives up when ctx is done,
+// returning ctx.Err().
+func KeyValuesContext(ctx context.Context, label string, opts ...Option) (map[string]string, error) {
+	c := newConfig(label, opts)
+	m := &keyValuesModel{
+		label:      label,
+		theme:      &c.theme,
+		keys:       &c.keymap,
+		opts:       c.keyValues,
+		validators: c.validators,
+		values:     map[string]string{},
+		cursor:     -1,
+	}
+	pairs := c.keyValues.Default
+	if initial, ok := c.initial.(map[string]string); ok {
+		pairs = initial
+	}
+	keys := make([]string, 0, len(pairs))
+	for k := range pairs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		m.set(k, pairs[k])
+	}
+	if err := run(ctx, c, m); err != nil {
+		return nil, err
+	}
+	return m.values, nil
+}
+
+type keyValuesModel struct {
+	label      string
+	theme      *Theme
+	keys       *Keymap
+	opts       KeyValuesOptions
+	validators []Validator
+	// order lists the keys of values in the order they were added.
+	order  []string
+	values map[string]string
+	line   line
+	// cursor is the index of the pair under the cursor,
+	// or -1 when it is on the line.
+	cursor int
+	// read is set once a pair has been read from a line of input.
+	read bool
+	// err is why the last pair or answer was rejected.
+	err  error
+	done bool
+}
+
+// set adds the pair of k and v, or replaces the value of k.
+func (m *keyValuesModel) set(k, v string) {
+	if _, ok := m.values[k]; !ok {
+		m.order = append(m.order, k)
+	}
+	m.values[k] = v
+}
+
+// remove removes the pair of the i-th key.
+func (m *keyValuesModel) remove(i int) {
+	delete(m.values, m.order[i])
+	m.order = append(m.order[:i:i], m.order[i+1:]...)
+}
+
+// parse splits s, written as KEY=VALUE, into a key and a value
+// and checks them.
+func (m *keyValuesModel) parse(s string) (string, string, error) {
+	k, v, ok := strings.Cut(s, "=")
+	k = strings.TrimSpace(k)
+	switch {
+	case !ok:
+		return "", "", &invalidAnswer{err: errorf("must be written as KEY=VALUE")}
+	case k == "":
+		return "", "", &invalidAnswer{err: errorf("the key must not be empty")}
+	}
+	if m.opts.Keys != nil {
+		if err := validate([]Validator{m.opts.Keys}, k); err != nil {
+			return "", "", err
+		}
+	}
+	if m.opts.Values != nil {
+		if err := validate([]Validator{m.opts.Values}, v); err != nil {
+			return "", "", err
+		}
+	}
+	if err := validate(m.validators, k+"="+v); err != nil {
+		return "", "", err
+	}
+	return k, v, nil
+}
+
+func (m *keyValuesModel) result() interface{} {
+	return m.values
+}
+
+func (m *keyValuesModel) prompt() string {
+	if m.read {
+		return ""
+	}
+	if len(m.order) > 0 {
+		return m.theme.label(m.label) + " (" + m.summary() + ") "
+	}
+	return m.theme.label(m.label) + " "
+}
+
+// summary lists the pairs separated by commas.
+func (m *keyValuesModel) summary() string {
+	pairs := make([]string, len(m.order))
+	for i, k := range m.order {
+		pairs[i] = k + "=" + m.values[k]
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// answer adds the pair s, until s is empty.
+func (m *keyValuesModel) answer(s string) (bool, error) {
+	if strings.TrimSpace(s) == "" {
+		m.done = true
+		return true, nil
+	}
+	k, v, err := m.parse(s)
+	if err != nil {
+		return false, err
+	}
+	if !m.read {
+		m.order, m.values = nil, map[string]string{}
+	}
+	m.set(k, v)
+	m.read = true
+	return false, nil
+}
+
+// eof accepts the pairs read when the input ends.
+func (m *keyValuesModel) eof() (bool, error) {
+	if !m.read {
+		return false, io.EOF
+	}
+	m.done = true
+	return true, nil
+}
+
+// answerText takes the pairs of s, written as KEY=VALUE and separated by
+// newlines, or by commas if it is a single line. An empty s keeps the
+// pairs there are.
+func (m *keyValuesModel) answerText(s string) error {
+	sep := ","
+	if strings.Contains(s, "\n") {
+		sep = "\n"
+	}
+	var list []string
+	for _, p := range strings.Split(s, sep) {
+		if p = strings.TrimSpace(p); p != "" {
+			list = append(list, p)
+		}
+	}
+	if list == nil {
+		m.done = true
+		return nil
+	}
+	return m.answerList(list)
+}
+
+func (m *keyValuesModel) answerList(list []string) error {
+	order, values := m.order, m.values
+	m.order, m.values = nil, map[string]string{}
+	for _, s := range list {
+		k, v, err := m.parse(s)
+		if err != nil {
+			m.order, m.values = order, values
+			return err
+		}
+		m.set(k, v)
+	}
+	m.done = true
+	return nil
+}
+
+func (m *keyValuesModel) rejected() error {
+	return m.err
+}
+
+func (m *keyValuesModel) update(k key) (bool, error) {
+	m.err = nil
+	n := len(m.order)
+	switch {
+	case m.cursor >= 0 && bound(m.keys.Submit, k):
+		key := m.order[m.cursor]
+		s := key + "=" + m.values[key]
+		m.remove(m.cursor)
+		m.line = line{buf: []rune(s), pos: len([]rune(s))}
+		m.cursor = -1
+	case m.cursor >= 0 && (k.name == "backspace" || k.name == "delete"):
+		m.remove(m.cursor)
+		if m.cursor >= len(m.order) {
+			m.cursor = len(m.order) - 1
+		}
+	case bound(m.keys.Submit, k):
+		s := m.line.String()
+		if strings.TrimSpace(s) == "" {
+			m.done = true
+			return true, nil
+		}
+		var key, value string
+		if key, value, m.err = m.parse(s); m.err == nil {
+			m.set(key, value)
+			m.line = line{}
+		}
+	case bound(m.keys.Up, k) && n > 0:
+		if m.cursor < 0 {
+			m.cursor = n
+		}
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case bound(m.keys.Down, k) && m.cursor >= 0:
+		if m.cursor++; m.cursor >= n {
+			m.cursor = -1
+		}
+	default:
+		if m.line.edit(k, m.keys) {
+			m.cursor = -1
+		}
+	}
+	return false, nil
+}
+
+func (m *keyValuesModel) keyHelp() ([]keyAction, bool) {
+	return append(editActions(m.keys),
+		act("move through the pairs entered", m.keys.Up, m.keys.Down),
+		act("add the pair typed, edit the one under the cursor, or accept the pairs if nothing is typed", m.keys.Submit),
+		act("remove the pair under the cursor", []string{"backspace", "delete"}),
+	), true
+}
+
+func (m *keyValuesModel) view() frame {
+	t := m.theme
+	if m.done {
+		return frame{lines: []string{t.answered(m.label, m.summary())}}
+	}
+	lines := []string{t.label(m.label)}
+	for i, k := range m.order {
+		lines = append(lines, t.option(k+"="+m.values[k], "", nil, i == m.cursor))
+	}
+	prompt := strings.Repeat(" ", textWidth(t.Pointer)+1)
+	if m.cursor < 0 {
+		prompt = t.render(t.Highlight, t.Pointer) + " "
+	}
+	text := m.line.String()
+	if text == "" {
+		text = t.render(t.Hint, t.text("KEY=VALUE, or Enter when done"))
+	}
+	lines = append(lines, prompt+text)
+	if m.err != nil {
+		lines = append(lines, t.errorLine(m.err))
+	}
+	return frame{
+		lines:      lines,
+		cursorRow:  len(m.order) + 1,
+		cursorCol:  textWidth(prompt + string(m.line.buf[:m.line.pos])),
+		showCursor: m.cursor < 0,
+	}
+}
@@ -0,0 +1,86 @@
+package prompts_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/prompttest"
+)
+
+func TestKeyValues(t *testing.T) {
+	term := prompttest.New(t)
+	res := prompttest.Start(term, func(o prompts.Option) (map[string]string, error) {
+		return prompts.KeyValues("Labels?", o)
+	})
+	term.WaitFor("Labels?")
+	term.Send("A=1" + prompttest.Enter + "B" + prompttest.Enter)
+	term.WaitFor("must be written as KEY=VALUE")
+	term.Send("=2" + prompttest.Enter + "A=3" + prompttest.Enter + prompttest.Enter)
+	got, err := res.Wait()
+	if want := map[string]string{"A": "3", "B": "2"}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Fatalf("KeyValues() = %v, %v, want %v, nil", got, err, want)
+	}
+	if got, want := term.Screen(), "Labels? A=3, B=2"; got != want {
+		t.Errorf("screen shows %q once answered, want %q", got, want)
+	}
+}
+
+func TestKeyValuesEnvFallback(t *testing.T) {
+	defaults := prompts.KeyValuesOptions{Default: map[string]string{"Z": "0"}}
+	tests := []struct {
+		name, env string
+		want      map[string]string
+	}{
+		{"one pair", "A=1", map[string]string{"A": "1"}},
+		{"commas", "A=1,B=2", map[string]string{"A": "1", "B": "2"}},
+		{"commas and spaces", "A=1, B=2 ,", map[string]string{"A": "1", "B": "2"}},
+		{"newlines", "A=1\nB=2,3\n", map[string]string{"A": "1", "B": "2,3"}},
+		{"empty keeps the default", "", map[string]string{"Z": "0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LABELS", tt.env)
+			got, err := prompts.KeyValues("Labels?", defaults, prompts.WithEnvFallback("LABELS"))
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("KeyValues() = %v, %v, want %v, nil", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyValuesEnvFallbackInvalid(t *testing.T) {
+	t.Setenv("LABELS", "A=1,B")
+	_, err := prompts.KeyValues("Labels?", prompts.WithEnvFallback("LABELS"))
+	if err == nil || !strings.Contains(err.Error(), "invalid answer in $LABELS") {
+		t.Fatalf("KeyValues() returned %v, want the answer of $LABELS rejected", err)
+	}
+}
+
+func TestKeyValuesRecordReplay(t *testing.T) {
+	for _, name := range []string{"answers.yaml", "answers.json"} {
+		t.Run(name, func(t *testing.T) {
+			r := prompts.NewRecorder()
+			term := prompttest.New(t)
+			res := prompttest.Start(term, func(o prompts.Option) (map[string]string, error) {
+				return prompts.KeyValues("Labels?", prompts.WithRecorder(r), o)
+			})
+			term.WaitFor("Labels?")
+			term.Send("A=1" + prompttest.Enter + "B=2,3" + prompttest.Enter + prompttest.Enter)
+			want, err := res.Wait()
+			if err != nil {
+				t.Fatal(err)
+			}
+			path := filepath.Join(t.TempDir(), name)
+			if err := r.WriteFile(path); err != nil {
+				t.Fatal(err)
+			}
+			got, err := prompts.KeyValues("Labels?", prompts.WithAnswersFile(path))
+			if err != nil || !reflect.DeepEqual(got, want) {
+				t.Fatalf("replayed KeyValues() = %v, %v, want %v, nil", got, err, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package prompts
+
+import (
+	"unicode"
+
+	"github.com/rivo/uniseg"
+)
+
+// line is an editable line of text with a cursor.
+type line struct {
+	buf []rune
+	// pos is the cursor position as an index into buf.
+	pos int
+	// secret lines zero the memory they stop using,
+	// so that no copies of passwords are left behind.
+	secret bool
+}
+
+func (l *line) String() string {
+	return string(l.buf)
+}
+
+// insert types r at the cursor.
+func (l *line) insert(r rune) {
+	if l.secret && len(l.buf) == cap(l.buf) {
+		buf := make([]rune, len(l.buf), 2*cap(l.buf)+16)
+		copy(buf, l.buf)
+		wipeRunes(l.buf)
+		l.buf = buf
+	}
+	l.buf = append(l.buf, 0)
+	copy(l.buf[l.pos+1:], l.buf[l.pos:])
+	l.buf[l.pos] = r
+	l.pos++
+}
+
+// backspace deletes the character before the cursor.
+func (l *line) backspace() {
+	if l.pos > 0 {
+		l.deleteRange(l.before(l.pos), l.pos)
+	}
+}
+
+// delete deletes the character under the cursor.
+func (l *line) delete() {
+	if l.pos < len(l.buf) {
+		l.deleteRange(l.pos, l.after(l.pos))
+	}
+}
+
+// deleteRange deletes the characters from i to j,
+// leaving the cursor where they were.
+func (l *line) deleteRange(i, j int) {
+	l.buf = append(l.buf[:i], l.buf[j:]...)
+	l.pos = i
+	if l.secret {
+		wipeRunes(l.buf[len(l.buf):cap(l.buf)])
+	}
+}
+
+// wipe zeroes and empties the line.
+func (l *line) wipe() {
+	wipeRunes(l.buf[:cap(l.buf)])
+	l.buf, l.pos = l.buf[:0], 0
+}
+
+func wipeRunes(rs []rune) {
+	for i := range rs {
+		rs[i] = 0
+	}
+}
+
+// paste types text at the cursor as it was pasted, with its line breaks
+// and tabs as spaces and without other control characters, except for
+// the line breaks it ends with.
+func (l *line) paste(text []rune) {
+	n := len(text)
+	for n > 0 && (text[n-1] == '\r' || text[n-1] == '\n') {
+		n--
+	}
+	for i, r := range text[:n] {
+		switch {
+		case r == '\n' && i > 0 && text[i-1] == '\r':
+		case r == '\r' || r == '\n':
+			l.insert(' ')
+		case r == '\t':
+			for j := 0; j < 4; j++ {
+				l.insert(' ')
+			}
+		case !unicode.IsControl(r):
+			l.insert(r)
+		}
+	}
+	if l.secret {
+		wipeRunes(text)
+	}
+}
+
+func (l *line) left() {
+	l.pos = l.before(l.pos)
+}
+
+func (l *line) right() {
+	l.pos = l.after(l.pos)
+}
+
+// before returns the index in buf of the character before index i, and
+// after that of the character after it, where characters are grapheme
+// clusters: what users see as one character, such as a letter followed
+// by combining marks or emoji joined into one. Secret lines, which are
+// not copied, are taken a rune at a time.
+func (l *line) before(i int) int {
+	start := 0
+	for _, b := range l.boundaries() {
+		if b >= i {
+			break
+		}
+		start = b
+	}
+	return start
+}
+
+func (l *line) after(i int) int {
+	for _, b := range l.boundaries() {
+		if b > i {
+			return b
+		}
+	}
+	return len(l.buf)
+}
+
+// boundaries returns the indexes in buf at which its grapheme clusters
+// end.
+func (l *line) boundaries() []int {
+	var bounds []int
+	if l.secret {
+		for i := range l.buf {
+			bounds = append(bounds, i+1)
+		}
+		return bounds
+	}
+	g := uniseg.NewGraphemes(string(l.buf))
+	for end := 0; g.Next(); {
+		end += len(g.Runes())
+		bounds = append(bounds, end)
+	}
+	return bounds
+}
+
+// wordStart returns the start of the word before the cursor,
+// where words are made of letters and digits, unless space is set,
+// in which case they are made of anything but white space.
+func (l *line) wordStart(space bool) int {
+	i := l.pos
+	for i > 0 && !inWord(l.buf[i-1], space) {
+		i--
+	}
+	for i > 0 && inWord(l.buf[i-1], space) {
+		i--
+	}
+	return i
+}
+
+// wordEnd returns the end of the word after the cursor.
+func (l *line) wordEnd() int {
+	i := l.pos
+	for i < len(l.buf) && !inWord(l.buf[i], false) {
+		i++
+	}
+	for i < len(l.buf) && inWord(l.buf[i], false) {
+		i++
+	}
+	return i
+}
+
+func inWord(r rune, space bool) bool {
+	if space {
+		return !unicode.IsSpace(r)
+	}
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// edit applies an editing key bound in km to the line
+// and reports whether it was one.
+func (l *line) edit(k key, km *Keymap) bool {
+	switch {
+	case bound(km.Left, k):
+		l.left()
+	case bound(km.Right, k):
+		l.right()
+	case bound(km.WordLeft, k):
+		l.pos = l.wordStart(false)
+	case bound(km.WordRight, k):
+		l.pos = l.wordEnd()
+	case bound(km.Home, k):
+		l.pos = 0
+	case bound(km.End, k):
+		l.pos = len(l.buf)
+	case bound(km.DeleteWord, k):
+		l.deleteRange(l.wordStart(true), l.pos)
+	case bound(km.DeleteToStart, k):
+		l.deleteRange(0, l.pos)
+	case bound(km.DeleteToEnd, k):
+		l.deleteRange(l.pos, len(l.buf))
+	case k.r != 0:
+		l.insert(k.r)
+	case k.name == "paste":
+		l.paste(k.paste)
+	case k.name == "backspace":
+		l.backspace()
+	case k.name == "delete":
+		l.delete()
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,742 @@
+package prompts
+
+import (
+	"strings"
+	"time"
+)
+
+// defaultPageSize is the number of options shown at once
+// when no page size is configured.
+const defaultPageSize = 7
+
+// defaultPreviewHeight is the most lines of a preview shown
+// when no preview height is configured.
+const defaultPreviewHeight = 10
+
+// columnGap is the number of spaces between the columns of options.
+const columnGap = 2
+
+// streamBatch is the most options streamed to a list that are added to it
+// at once, so that it keeps responding to keys while they pour in.
+const streamBatch = 10000
+
+// optionList is the list of options of Select and Checkboxes as shown:
+// filtered by what is typed, listed under the headers of their groups,
+// a page at a time, with a cursor on one of the rows shown. Short options
+// without groups may be laid out in columns, across and then down, each
+// row of the list being a cell of the grid and each line of the page
+// holding a row of cells.
+type optionList struct {
+	theme   *Theme
+	keys    *Keymap
+	options []string
+	// descriptions and help describe options, shortly and at length.
+	descriptions, help map[string]string
+	// disabled maps the options that cannot be picked to why not.
+	disabled map[string]string
+	// groups are the names of the groups of options in the order they
+	// are listed, group the index of the group of each option, or -1,
+	// or nil if there are no groups, and collapsed whether each group is
+	// collapsed. groupOf maps options to the names of their groups.
+	groups    []string
+	group     []int
+	collapsed []bool
+	groupOf   map[string]string
+	filter    optionFilter
+	// rows are what is shown, cursor and top are indexes into them.
+	rows     []listRow
+	cursor   int
+	top      int
+	pageSize int
+	// maxPage is the page size configured, which pageSize is cut down to
+	// when the terminal is too short to show it.
+	maxPage int
+	// columns is the number of columns the rows are laid out in, cell
+	// the width of each and width that of the terminal, or zero if unknown.
+	// maxColumns is the most columns allowed, zero meaning no limit, and
+	// markWidth the width of the marks shown before the options, and
+	// widest that of the widest of the first measured options.
+	columns, cell, width  int
+	maxColumns, markWidth int
+	widest, measured      int
+	// preview, if set, previews the option under the cursor in at most
+	// previewHeight lines. previews caches its lines by option.
+	preview       func(option string) string
+	previewHeight int
+	previews      map[int][]string
+	// stream, if set, sends more options until it is closed, which are
+	// added as they arrive, with a spinner of frames shown since start.
+	stream <-chan string
+	frames []string
+	start  time.Time
+	frame  int
+}
+
+// listRow is a row of an optionList: the header of a group,
+// or an option matching the filter.
+type listRow struct {
+	group int
+	// match is the index of the option among the matches of the filter,
+	// or -1 for a header.
+	match int
+}
+
+func newOptionList(t *Theme, km *Keymap, options []string, pageSize int) optionList {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	return optionList{theme: t, keys: km, options: options, pageSize: pageSize, maxPage: pageSize, columns: 1, maxColumns: 1}
+}
+
+// resize fits the page of l on a terminal width columns wide and height
+// rows tall, along with reserved other lines of the view.
+func (l *optionList) resize(width, height, reserved int) {
+	l.width = width
+	if l.preview != nil {
+		height := l.previewHeight
+		if height <= 0 {
+			height = defaultPreviewHeight
+		}
+		reserved += height + 1
+	}
+	l.pageSize = fitPage(l.maxPage, height, reserved)
+	l.fitColumns()
+	l.scroll()
+}
+
+// fitColumns lays the rows out in as many columns as fit side by side on
+// the terminal, up to maxColumns, if there are more options than fit on
+// a page in one. Options in groups, with descriptions or previews, or
+// rendered by a template stay in one column.
+func (l *optionList) fitColumns() {
+	l.columns = 1
+	if l.maxColumns == 1 || l.width <= 0 || len(l.options) <= l.pageSize ||
+		len(l.groups) > 0 || len(l.descriptions) > 0 || l.preview != nil || l.theme.Templates.Option != nil {
+		return
+	}
+	t := l.theme
+	// Options are measured once however often the terminal is resized.
+	for _, o := range l.options[l.measured:] {
+		w := textWidth(o)
+		if reason := l.disabled[o]; reason != "" {
+			w += 2 + textWidth("("+reason+")")
+		}
+		if w > l.widest {
+			l.widest = w
+		}
+	}
+	l.measured = len(l.options)
+	l.cell = l.widest + textWidth(t.Pointer) + 1
+	if l.markWidth > 0 {
+		l.cell += l.markWidth + 1
+	}
+	// Keep off the last column of the terminal, where the cursor waits
+	// to wrap.
+	n := (l.width - 1 + columnGap) / (l.cell + columnGap)
+	if l.maxColumns > 0 && n > l.maxColumns {
+		n = l.maxColumns
+	}
+	if n > 1 {
+		l.columns = n
+	}
+}
+
+// scroll moves the page to keep the cursor on it.
+func (l *optionList) scroll() {
+	c := l.columns
+	l.top = scrollTop(l.top/c, l.cursor/c, l.pageSize) * c
+}
+
+// init shows all the options, listed under the group groups maps them to,
+// with the cursor on the first one enabled.
+func (l *optionList) init(groups map[string]string) {
+	l.groupOf = groups
+	l.assignGroups(0)
+	l.refilter()
+}
+
+// assignGroups finds the groups of the options from the index from on.
+func (l *optionList) assignGroups(from int) {
+	if len(l.groupOf) == 0 {
+		return
+	}
+	index := map[string]int{}
+	for g, name := range l.groups {
+		index[name] = g
+	}
+	for _, o := range l.options[from:] {
+		name, ok := l.groupOf[o]
+		if !ok {
+			l.group = append(l.group, -1)
+			continue
+		}
+		g, ok := index[name]
+		if !ok {
+			g = len(l.groups)
+			index[name] = g
+			l.groups = append(l.groups, name)
+			l.collapsed = append(l.collapsed, false)
+		}
+		l.group = append(l.group, g)
+	}
+}
+
+// streamFrom makes the list add the options sent on ch as they arrive.
+func (l *optionList) streamFrom(ch <-chan string) {
+	l.stream, l.start = ch, time.Now()
+	l.frames = SpinnerDots
+	if l.theme.ascii {
+		l.frames = SpinnerLine
+	}
+}
+
+// tick adds the options streamed since it was last called and reports
+// whether the list changed.
+func (l *optionList) tick() bool {
+	if l.stream == nil {
+		return false
+	}
+	var options []string
+receive:
+	for len(options) < streamBatch {
+		select {
+		case o, ok := <-l.stream:
+			if !ok {
+				l.stream = nil
+				l.add(options)
+				return true
+			}
+			options = append(options, o)
+		default:
+			break receive
+		}
+	}
+	frame := spinnerFrame(l.start, len(l.frames))
+	changed := len(options) > 0 || frame != l.frame
+	l.frame = frame
+	l.add(options)
+	return changed
+}
+
+// drain waits for the options still to be streamed and adds them.
+func (l *optionList) drain() {
+	if l.stream == nil {
+		return
+	}
+	var options []string
+	for o := range l.stream {
+		options = append(options, o)
+	}
+	l.stream = nil
+	l.add(options)
+}
+
+// add adds options to the list, keeping the cursor where it is.
+func (l *optionList) add(options []string) {
+	if len(options) == 0 {
+		return
+	}
+	i, g := l.current(), l.currentGroup()
+	from := len(l.options)
+	l.options = append(l.options, options...)
+	l.assignGroups(from)
+	l.filter.extend(l.options, from)
+	l.layout()
+	switch {
+	case i >= 0:
+		l.moveTo(i)
+	case g >= 0:
+		l.cursor = l.headerRow(g)
+	default:
+		l.settle(1)
+	}
+	l.fitColumns()
+	l.scroll()
+}
+
+// current returns the index of the option under the cursor, or -1 if
+// there is no enabled option under it.
+func (l *optionList) current() int {
+	if l.cursor >= len(l.rows) || !l.selectable(l.cursor) || l.rows[l.cursor].match < 0 {
+		return -1
+	}
+	return l.filter.matches[l.rows[l.cursor].match].index
+}
+
+// currentGroup returns the index of the group whose header is under
+// the cursor, or -1 if there is no header under it.
+func (l *optionList) currentGroup() int {
+	if l.cursor >= len(l.rows) || l.rows[l.cursor].match >= 0 {
+		return -1
+	}
+	return l.rows[l.cursor].group
+}
+
+// enabled reports whether the option with index i can be picked.
+func (l *optionList) enabled(i int) bool {
+	_, ok := l.disabled[l.options[i]]
+	return !ok
+}
+
+// selectable reports whether the cursor can be on row j,
+// which it can unless the row is a disabled option.
+func (l *optionList) selectable(j int) bool {
+	r := l.rows[j]
+	return r.match < 0 || l.enabled(l.filter.matches[r.match].index)
+}
+
+// disabledReason returns why the option s cannot be picked,
+// if it cannot.
+func (l *optionList) disabledReason(s string) error {
+	reason, ok := l.disabled[s]
+	switch {
+	case !ok:
+		return nil
+	case reason == "":
+		return &invalidAnswer{err: errorf("%s is not available", s)}
+	}
+	return &invalidAnswer{err: errorf("%s is not available: %s", s, reason)}
+}
+
+// stepColumn moves the cursor to the next selectable row of its column in
+// the direction dir, wrapping around, if there is one.
+func (l *optionList) stepColumn(dir int) {
+	n, c := len(l.rows), l.columns
+	lines := (n + c - 1) / c
+	line, col := l.cursor/c, l.cursor%c
+	for i := 0; i < lines; i++ {
+		line = (line + dir + lines) % lines
+		if j := line*c + col; j < n && l.selectable(j) {
+			l.cursor = j
+			return
+		}
+	}
+}
+
+// step moves the cursor to the next selectable row in the direction dir,
+// wrapping around, if there is one.
+func (l *optionList) step(dir int) {
+	n := len(l.rows)
+	for j, i := l.cursor, 0; i < n; i++ {
+		j = (j + dir + n) % n
+		if l.selectable(j) {
+			l.cursor = j
+			return
+		}
+	}
+}
+
+// settle moves the cursor from a row it cannot be on to the closest one
+// it can, looking in the direction dir first.
+func (l *optionList) settle(dir int) {
+	n := len(l.rows)
+	for _, d := range []int{dir, -dir} {
+		for j := l.cursor; j >= 0 && j < n; j += d {
+			if l.selectable(j) {
+				l.cursor = j
+				return
+			}
+		}
+	}
+}
+
+// moveTo puts the cursor on the option with index i,
+// or the closest one enabled.
+func (l *optionList) moveTo(i int) {
+	for j, r := range l.rows {
+		if r.match >= 0 && l.filter.matches[r.match].index == i {
+			l.cursor = j
+			l.settle(1)
+			l.scroll()
+			return
+		}
+	}
+}
+
+// captures reports whether the list takes k before a Wizard gets it,
+// which it does to clear the filter.
+func (l *optionList) captures(k key) bool {
+	return bound(l.keys.Back, k) && len(l.filter.text) > 0
+}
+
+// update moves the cursor, edits the filter, collapses or expands groups
+// or shows help as k says and reports whether k did any of those.
+func (l *optionList) update(k key) bool {
+	n := len(l.rows)
+	switch {
+	case bound(l.keys.Up, k) && l.columns > 1:
+		l.stepColumn(-1)
+	case bound(l.keys.Down, k) && l.columns > 1:
+		l.stepColumn(1)
+	case bound(l.keys.Up, k):
+		l.step(-1)
+	case bound(l.keys.Down, k):
+		l.step(1)
+	case bound(l.keys.PageUp, k):
+		l.cursor = pageMove(l.keys, k, l.cursor, l.pageSize*l.columns, n)
+		l.settle(-1)
+	case bound(l.keys.PageDown, k):
+		l.cursor = pageMove(l.keys, k, l.cursor, l.pageSize*l.columns, n)
+		l.settle(1)
+	case bound(l.keys.Left, k) && l.columns > 1:
+		l.step(-1)
+	case bound(l.keys.Right, k) && l.columns > 1:
+		l.step(1)
+	case bound(l.keys.Left, k) && n > 0 && l.rows[l.cursor].group >= 0:
+		l.collapse(l.rows[l.cursor].group, true)
+	case bound(l.keys.Right, k) && l.currentGroup() >= 0:
+		l.collapse(l.currentGroup(), false)
+	case l.captures(k):
+		l.filter.text = l.filter.text[:0]
+		l.refilter()
+	case l.filter.edit(k, l.keys):
+		l.refilter()
+	default:
+		return false
+	}
+	l.scroll()
+	return true
+}
+
+// click moves the cursor to the row shown on the line of the view with
+// index line at the column col, if it can be on it, collapsing or
+// expanding the group of a header. It reports whether the row is an
+// option.
+func (l *optionList) click(line, col int) bool {
+	c := l.columns
+	top := l.top / c
+	i := top + line - 1
+	if l.top > 0 {
+		// Skip the line marking the options above.
+		i--
+	}
+	if i < top || i >= top+l.pageSize {
+		return false
+	}
+	j := i * c
+	if c > 1 {
+		k := col / (l.cell + columnGap)
+		if k >= c || col%(l.cell+columnGap) >= l.cell {
+			return false
+		}
+		j += k
+	}
+	if j >= len(l.rows) || !l.selectable(j) {
+		return false
+	}
+	l.cursor = j
+	if l.toggleGroup() {
+		return false
+	}
+	l.scroll()
+	return true
+}
+
+// toggleGroup collapses the group whose header is under the cursor if it
+// is expanded and expands it otherwise, and reports whether there is one.
+func (l *optionList) toggleGroup() bool {
+	g := l.currentGroup()
+	if g < 0 {
+		return false
+	}
+	l.collapse(g, !l.collapsed[g])
+	return true
+}
+
+// collapse collapses or expands the group g, leaving the cursor on its
+// header.
+func (l *optionList) collapse(g int, collapsed bool) {
+	l.collapsed[g] = collapsed
+	l.layout()
+	l.cursor = l.headerRow(g)
+	l.scroll()
+}
+
+// headerRow returns the row of the header of group g.
+func (l *optionList) headerRow(g int) int {
+	for j, r := range l.rows {
+		if r.match < 0 && r.group == g {
+			return j
+		}
+	}
+	return l.cursor
+}
+
+// refilter applies the filter after its text changed.
+func (l *optionList) refilter() {
+	l.filter.apply(l.options)
+	l.layout()
+	l.cursor, l.top = 0, 0
+	l.settle(1)
+	l.scroll()
+}
+
+// layout lists the rows: the options matching the filter that are not in
+// a group, then the header of each group with matching options followed
+// by them, unless the group is collapsed and the filter empty.
+func (l *optionList) layout() {
+	if n := len(l.filter.matches) + len(l.groups); cap(l.rows) < n {
+		l.rows = make([]listRow, 0, n)
+	}
+	l.rows = l.rows[:0]
+	// Sort the matches into their groups first, so that lists of many
+	// options in many groups are laid out in one pass over them.
+	byGroup := make([][]int, len(l.groups)+1)
+	for j, match := range l.filter.matches {
+		g := 0
+		if l.group != nil {
+			g = l.group[match.index] + 1
+		}
+		byGroup[g] = append(byGroup[g], j)
+	}
+	for g := -1; g < len(l.groups); g++ {
+		matches := byGroup[g+1]
+		if len(matches) == 0 {
+			continue
+		}
+		if g >= 0 {
+			l.rows = append(l.rows, listRow{group: g, match: -1})
+			if l.collapsed[g] && len(l.filter.text) == 0 {
+				continue
+			}
+		}
+		for _, j := range matches {
+			l.rows = append(l.rows, listRow{group: g, match: j})
+		}
+	}
+	if l.cursor >= len(l.rows) {
+		l.cursor = 0
+	}
+}
+
+// lines renders the list under the label, with the mark returned by mark
+// for the option with index i shown before it.
+func (l *optionList) lines(label string, mark func(i int) string) []string {
+	t := l.theme
+	lines := []string{t.filterLabel(label, &l.filter)}
+	if l.stream != nil {
+		lines[0] += " " + t.render(t.Highlight, l.frames[l.frame])
+		if len(l.options) == 0 {
+			lines[0] += " " + t.render(t.Hint, t.text("Loading options..."))
+		}
+	}
+	row := func(j int) string {
+		r := l.rows[j]
+		if r.match < 0 {
+			mark := t.Expanded
+			if l.collapsed[r.group] && len(l.filter.text) == 0 {
+				mark = t.Collapsed
+			}
+			return t.option(t.render(Style{Bold: true}, l.groups[r.group]), mark, nil, j == l.cursor)
+		}
+		match := l.filter.matches[r.match]
+		o, mark := l.options[match.index], mark(match.index)
+		if r.group >= 0 {
+			// Line the options of groups up with the names of the groups.
+			indent := strings.Repeat(" ", textWidth(t.Expanded))
+			if mark == "" {
+				mark = indent
+			} else {
+				mark = indent + " " + mark
+			}
+		}
+		if reason, ok := l.disabled[o]; ok {
+			s := t.render(t.Hint, t.option(o, mark, nil, false))
+			if reason != "" {
+				s += "  " + t.render(t.Hint, "("+reason+")")
+			}
+			return s
+		}
+		s := t.option(o, mark, match.positions, j == l.cursor)
+		if d := l.descriptions[o]; d != "" {
+			s += "  " + t.render(t.Hint, d)
+		}
+		return s
+	}
+	if l.columns > 1 {
+		lines = append(lines, l.grid(row)...)
+	} else {
+		lines = append(lines, t.page(l.top, l.pageSize, len(l.rows), row)...)
+	}
+	if len(l.filter.matches) == 0 && len(l.options) > 0 {
+		lines = append(lines, t.render(t.Hint, t.text(noMatches)))
+	}
+	if i := l.current(); l.preview != nil && i >= 0 {
+		lines = append(lines, l.previewLines(i)...)
+	}
+	return lines
+}
+
+// grid returns the lines of the page of rows laid out in columns,
+// rendered by row, with a line marking the options above and below the
+// page if there are any, as page does.
+func (l *optionList) grid(row func(j int) string) []string {
+	t, c, n := l.theme, l.columns, len(l.rows)
+	end := l.top + l.pageSize*c
+	if end > n {
+		end = n
+	}
+	var lines []string
+	if l.top > 0 {
+		lines = append(lines, t.moreLine(t.Above, l.top))
+	}
+	for j := l.top; j < end; j += c {
+		var b strings.Builder
+		for k := j; k < j+c && k < end; k++ {
+			s := row(k)
+			b.WriteString(s)
+			if k+1 < j+c && k+1 < end {
+				b.WriteString(strings.Repeat(" ", l.cell+columnGap-textWidth(s)))
+			}
+		}
+		lines = append(lines, b.String())
+	}
+	if end < n {
+		lines = append(lines, t.moreLine(t.Below, n-end))
+	}
+	return lines
+}
+
+// optionHelp returns the lines of the help of the option under the cursor,
+// for the help overlay, if the options have any.
+func (l *optionList) optionHelp() []string {
+	i := l.current()
+	if l.help == nil || i < 0 {
+		return nil
+	}
+	t := l.theme
+	indent := strings.Repeat(" ", textWidth(t.Pointer)+1)
+	help := l.help[l.options[i]]
+	if help == "" {
+		help = t.render(t.Hint, t.text("No help for this option"))
+	}
+	lines := strings.Split(help, "\n")
+	for j, s := range lines {
+		lines[j] = indent + s
+	}
+	return lines
+}
+
+// keyActions returns what the keys moving through the list and
+// filtering it do, with more between the two.
+func (l *optionList) keyActions(more ...keyAction) []keyAction {
+	km := l.keys
+	actions := []keyAction{
+		act("move the cursor", km.Up, km.Down),
+		act("move by a page", km.PageUp, km.PageDown),
+	}
+	if len(l.groups) > 0 {
+		actions = append(actions, act("collapse or expand a group", km.Left, km.Right))
+	}
+	if l.columns > 1 {
+		actions = append(actions, act("move between the columns", km.Left, km.Right))
+	}
+	actions = append(actions, more...)
+	return append(actions,
+		note("type to filter the options"),
+		act("clear the filter", km.Back, km.DeleteWord, km.DeleteToStart))
+}
+
+// previewLines renders the preview of the option i beside a border,
+// cutting it short if it is higher than previewHeight.
+func (l *optionList) previewLines(i int) []string {
+	t := l.theme
+	preview, ok := l.previews[i]
+	if !ok {
+		s := strings.ReplaceAll(strings.TrimRight(l.preview(l.options[i]), "\n"), "\t", "    ")
+		preview = strings.Split(strings.ReplaceAll(s, "\r", ""), "\n")
+		if l.previews == nil {
+			l.previews = map[int][]string{}
+		}
+		l.previews[i] = preview
+	}
+	height := l.previewHeight
+	if height <= 0 {
+		height = defaultPreviewHeight
+	}
+	border := t.render(t.Hint, "│") + " "
+	if t.ascii {
+		border = t.render(t.Hint, "|") + " "
+	}
+	var lines []string
+	for j, s := range preview {
+		if j == height {
+			lines = append(lines, border+t.render(t.Hint, t.textf("(%d more lines)", len(preview)-height)))
+			break
+		}
+		lines = append(lines, border+s)
+	}
+	return lines
+}
+
+// page returns the lines of the options from top on that fit on a page
+// of pageSize, out of n, rendered by option, with a line marking those
+// above and below the page if there are any.
+func (t *Theme) page(top, pageSize, n int, option func(i int) string) []string {
+	end := top + pageSize
+	if end > n {
+		end = n
+	}
+	var lines []string
+	if top > 0 {
+		lines = append(lines, t.moreLine(t.Above, top))
+	}
+	for i := top; i < end; i++ {
+		lines = append(lines, option(i))
+	}
+	if end < n {
+		lines = append(lines, t.moreLine(t.Below, n-end))
+	}
+	return lines
+}
+
+// moreLine renders the line saying how many options are off the page.
+func (t *Theme) moreLine(mark string, n int) string {
+	return strings.Repeat(" ", textWidth(t.Pointer)+1) + t.render(t.Hint, t.textf("%s %d more", mark, n))
+}
+
+// pageMove returns where the cursor moves to among n options when
+// k, a PageUp or PageDown key of km, is pressed.
+func pageMove(km *Keymap, k key, cursor, pageSize, n int) int {
+	if bound(km.PageUp, k) {
+		cursor -= pageSize
+	} else {
+		cursor += pageSize
+	}
+	if cursor >= n {
+		cursor = n - 1
+	}
+	if cursor < 0 {
+		cursor = 0
+	}
+	return cursor
+}
+
+// fitPage returns pageSize, or as many rows as fit if that many do not fit
+// on a terminal height rows tall along with reserved other lines and the
+// lines marking options off the page. A height of zero is unknown.
+func fitPage(pageSize, height, reserved int) int {
+	if height <= 0 {
+		return pageSize
+	}
+	room := height - reserved - 2
+	if room < 1 {
+		room = 1
+	}
+	if room < pageSize {
+		return room
+	}
+	return pageSize
+}
+
+// scrollTop returns the index of the first visible option
+// that keeps the cursor within a page starting at top.
+func scrollTop(top, cursor, pageSize int) int {
+	if cursor < top {
+		return cursor
+	}
+	if cursor >= top+pageSize {
+		return cursor - pageSize + 1
+	}
+	return top
+}
@@ -0,0 +1,326 @@
+package prompts
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+)
+
+// OptionsLoader loads the options of SelectAsync and CheckboxesAsync
+// while the prompt is shown, e.g. by listing them with an API call.
+// It should give up when ctx is done.
+type OptionsLoader func(ctx context.Context) ([]string, error)
+
+// OptionsFrom returns an OptionsLoader of the options sent on ch,
+// which are loaded once ch is closed.
+func OptionsFrom(ch <-chan string) OptionsLoader {
+	return func(ctx context.Context) ([]string, error) {
+		options := []string{}
+		for {
+			select {
+			case o, ok := <-ch:
+				if !ok {
+					return options, nil
+				}
+				options = append(options, o)
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+	}
+}
+
+// SelectAsync is like Select but shows a spinner next to the label while
+// load loads the options, and asks to pick one once they are loaded.
+// If loading fails, the prompt shows the error and returns it.
+func SelectAsync(label string, load OptionsLoader, opts ...Option) (string, int, error) {
+	return SelectAsyncContext(context.Background(), label, load, opts...)
+}
+
+// SelectAsyncContext is like SelectAsync but gives up when ctx is done,
+// returning ctx.Err(). The context given to load is done as well when
+// the prompt ends before the options are loaded.
+func SelectAsyncContext(ctx context.Context, label string, load OptionsLoader, opts ...Option) (string, int, error) {
+	c := newConfig(label, opts)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var sm *selectModel
+	m := newLoadingModel(ctx, c, label, load, func(options []string) (model, error) {
+		if len(options) == 0 {
+			return nil, ErrNoOptions
+		}
+		sm = newSelectModel(c, label, options)
+		return sm, nil
+	})
+	if err := run(ctx, c, m); err != nil {
+		return "", -1, err
+	}
+	return sm.list.options[sm.picked], sm.picked, nil
+}
+
+// CheckboxesAsync is like Checkboxes but shows a spinner next to the label
+// while load loads the options, and asks to pick them once they are loaded.
+// If loading fails, the prompt shows the error and returns it.
+func CheckboxesAsync(label string, load OptionsLoader, opts ...Option) ([]string, error) {
+	return CheckboxesAsyncContext(context.Background(), label, load, opts...)
+}
+
+// CheckboxesAsyncContext is like CheckboxesAsync but gives up when ctx is
+// done, returning ctx.Err(). The context given to load is done as well
+// when the prompt ends before the options are loaded.
+func CheckboxesAsyncContext(ctx context.Context, label string, load OptionsLoader, opts ...Option) ([]string, error) {
+	c := newConfig(label, opts)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var cm *checkboxesModel
+	m := newLoadingModel(ctx, c, label, load, func(options []string) (model, error) {
+		cm = newCheckboxesModel(c, label, options)
+		return cm, nil
+	})
+	if err := run(ctx, c, m); err != nil {
+		return nil, err
+	}
+	return cm.picked(), nil
+}
+
+// StreamOptions configures SelectStream and CheckboxesStream.
+type StreamOptions struct {
+	// Sort lists the options in alphabetical order as they arrive,
+	// rather than in the order they do.
+	Sort bool
+}
+
+func (o StreamOptions) apply(c *config) { c.stream = o }
+
+// SelectStream is like Select but takes its options from ch while the
+// prompt is shown, adding them to the list as they arrive, with a spinner
+// next to the label until ch is closed. The options there so far can be
+// filtered and picked meanwhile, and the cursor stays on the option it is
+// on as more arrive. The index returned is that of the picked option in
+// the order the options were sent.
+//
+// If the standard input is not a terminal, it waits for ch to be closed
+// before reading the answer. It returns ErrNoOptions if ch is closed
+// without sending any option.
+func SelectStream(label string, ch <-chan string, opts ...Option) (string, int, error) {
+	return SelectStreamContext(context.Background(), label, ch, opts...)
+}
+
+// SelectStreamContext is like SelectStream but gives up when ctx is done,
+// returning ctx.Err().
+func SelectStreamContext(ctx context.Context, label string, ch <-chan string, opts ...Option) (string, int, error) {
+	c := newConfig(label, opts)
+	m := newSelectModel(c, label, nil)
+	m.list.filter.sorted = c.stream.Sort
+	m.list.streamFrom(ch)
+	m.initial = c.initialOption()
+	if err := run(ctx, c, m); err != nil {
+		return "", -1, err
+	}
+	return m.list.options[m.picked], m.picked, nil
+}
+
+// CheckboxesStream is like Checkboxes but takes its options from ch while
+// the prompt is shown, as SelectStream does. The options checked to begin
+// with are checked as they arrive.
+func CheckboxesStream(label string, ch <-chan string, opts ...Option) ([]string, error) {
+	return CheckboxesStreamContext(context.Background(), label, ch, opts...)
+}
+
+// CheckboxesStreamContext is like CheckboxesStream but gives up when ctx is
+// done, returning ctx.Err().
+func CheckboxesStreamContext(ctx context.Context, label string, ch <-chan string, opts ...Option) ([]string, error) {
+	c := newConfig(label, opts)
+	m := newCheckboxesModel(c, label, nil)
+	m.list.filter.sorted = c.stream.Sort
+	m.list.streamFrom(ch)
+	if err := run(ctx, c, m); err != nil {
+		return nil, err
+	}
+	return m.picked(), nil
+}
+
+// loaded is the outcome of an OptionsLoader.
+type loaded struct {
+	options []string
+	err     error
+}
+
+// loadingModel shows a spinner until its options are loaded
+// and then hands over to the model built from them.
+type loadingModel struct {
+	label  string
+	theme  *Theme
+	frames []string
+	start  time.Time
+	frame  int
+	loaded chan loaded
+	build  func(options []string) (model, error)
+	// m is the model built from the options once they are loaded.
+	m model
+	// err is why the options could not be loaded.
+	err error
+	// width and height are the size of the terminal, for m.
+	width, height int
+}
+
+func newLoadingModel(ctx context.Context, c *config, label string, load OptionsLoader, build func([]string) (model, error)) *loadingModel {
+	m := &loadingModel{
+		label:  label,
+		theme:  &c.theme,
+		frames: SpinnerDots,
+		start:  time.Now(),
+		loaded: make(chan loaded, 1),
+		build:  build,
+	}
+	if c.theme.ascii {
+		m.frames = SpinnerLine
+	}
+	go func() {
+		options, err := load(ctx)
+		m.loaded <- loaded{options: options, err: err}
+	}()
+	return m
+}
+
+func (m *loadingModel) finish(l loaded) {
+	if l.err == nil {
+		m.m, l.err = m.build(l.options)
+	}
+	if rm, ok := m.m.(resizer); ok {
+		rm.resize(m.width, m.height)
+	}
+	m.err = l.err
+}
+
+// wait waits for the options to be loaded
+// and returns why they were not, if they were not.
+func (m *loadingModel) wait() error {
+	if m.m == nil && m.err == nil {
+		m.finish(<-m.loaded)
+	}
+	return m.err
+}
+
+func (m *loadingModel) tick() (bool, error) {
+	if m.m != nil || m.err != nil {
+		return false, nil
+	}
+	select {
+	case l := <-m.loaded:
+		m.finish(l)
+		return true, m.err
+	default:
+	}
+	frame := spinnerFrame(m.start, len(m.frames))
+	changed := frame != m.frame
+	m.frame = frame
+	return changed, nil
+}
+
+// spinnerFrame returns which of n frames a spinner started at start shows,
+// a tenth of a second each.
+func spinnerFrame(start time.Time, n int) int {
+	return int(time.Since(start)/(100*time.Millisecond)) % n
+}
+
+func (m *loadingModel) resize(width, height int) {
+	m.width, m.height = width, height
+	if rm, ok := m.m.(resizer); ok {
+		rm.resize(width, height)
+	}
+}
+
+func (m *loadingModel) click(line, col int) (bool, error) {
+	if cm, ok := m.m.(clicker); ok {
+		return cm.click(line, col)
+	}
+	return false, nil
+}
+
+func (m *loadingModel) update(k key) (bool, error) {
+	if m.m == nil {
+		return false, nil
+	}
+	return m.m.update(k)
+}
+
+func (m *loadingModel) view() frame {
+	switch {
+	case m.m != nil:
+		return m.m.view()
+	case m.err != nil:
+		return frame{lines: []string{m.theme.label(m.label), m.theme.errorLine(m.err)}}
+	}
+	spinner := m.theme.render(m.theme.Highlight, m.frames[m.frame])
+	return frame{lines: []string{m.theme.label(m.label) + " " + spinner + " " + m.theme.render(m.theme.Hint, m.theme.text("Loading options..."))}}
+}
+
+func (m *loadingModel) prompt() string {
+	if m.wait() != nil {
+		return m.theme.label(m.label) + " "
+	}
+	return m.m.(lineModel).prompt()
+}
+
+func (m *loadingModel) answer(s string) (bool, error) {
+	if err := m.wait(); err != nil {
+		return false, err
+	}
+	return m.m.(lineModel).answer(s)
+}
+
+func (m *loadingModel) eof() (bool, error) {
+	if err := m.wait(); err != nil {
+		return false, err
+	}
+	if em, ok := m.m.(eofModel); ok {
+		return em.eof()
+	}
+	return false, io.EOF
+}
+
+func (m *loadingModel) answerList(list []string) error {
+	if err := m.wait(); err != nil {
+		return err
+	}
+	if lm, ok := m.m.(listModel); ok {
+		return lm.answerList(list)
+	}
+	_, err := m.answer(strings.Join(list, ","))
+	return err
+}
+
+func (m *loadingModel) result() interface{} {
+	return m.m.(resultModel).result()
+}
+
+func (m *loadingModel) rejected() error {
+	r, ok := m.m.(rejecter)
+	if !ok {
+		return nil
+	}
+	return r.rejected()
+}
+
+func (m *loadingModel) captures(k key) bool {
+	c, ok := m.m.(keyCapturer)
+	return ok && c.captures(k)
+}
+
+func (m *loadingModel) keyHelp() ([]keyAction, bool) {
+	h, ok := m.m.(keyHelper)
+	if !ok {
+		return nil, false
+	}
+	return h.keyHelp()
+}
+
+func (m *loadingModel) optionHelp() []string {
+	h, ok := m.m.(optionHelper)
+	if !ok {
+		return nil
+	}
+	return h.optionHelp()
+}
@@ -0,0 +1,121 @@
+package prompts
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	rules "github.com/tidalmigrations/interactive-cli-prompts/prompts/validate"
+)
+
+// A Catalog translates the text prompts show of their own, such as "Yes",
+// "No matching options" or "passwords do not match", from English. Its keys
+// are the English texts; those filled in with values are written as their
+// format strings, e.g. "must be %d digits". Texts missing from it are shown
+// in English.
+//
+// The messages of the validators of the validate package are translated
+// the same way, and so are those of other validators returning
+// a *validate.Error.
+type Catalog map[string]string
+
+var (
+	localeMu sync.Mutex
+	locale   = environmentLocale()
+	catalogs = map[string]Catalog{}
+)
+
+// RegisterCatalog makes c the catalog of the locale, such as "de" for
+// German or "pt-BR" for Brazilian Portuguese.
+func RegisterCatalog(locale string, c Catalog) {
+	localeMu.Lock()
+	defer localeMu.Unlock()
+	catalogs[normalizeLocale(locale)] = c
+}
+
+// SetLocale makes the prompts not given WithLocale show their text in
+// locale, with the catalog registered for it or, failing that, for its
+// language, e.g. "de" for "de-AT". It defaults to the locale set by the
+// first of LC_ALL, LC_MESSAGES and LANG that is set, such as "de_AT.UTF-8".
+func SetLocale(l string) {
+	localeMu.Lock()
+	defer localeMu.Unlock()
+	locale = l
+}
+
+// WithLocale makes a prompt show its text in locale, as SetLocale does
+// for all prompts.
+func WithLocale(locale string) Option {
+	return optionFunc(func(c *config) { c.locale = &locale })
+}
+
+func currentLocale() string {
+	localeMu.Lock()
+	defer localeMu.Unlock()
+	return locale
+}
+
+// catalogFor returns the catalog registered for the locale l or its
+// language, if any.
+func catalogFor(l string) Catalog {
+	localeMu.Lock()
+	defer localeMu.Unlock()
+	l = normalizeLocale(l)
+	if c, ok := catalogs[l]; ok {
+		return c
+	}
+	if i := strings.IndexByte(l, '-'); i >= 0 {
+		return catalogs[l[:i]]
+	}
+	return nil
+}
+
+// environmentLocale returns the locale of messages set by the environment.
+func environmentLocale() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// normalizeLocale turns POSIX locales such as "pt_BR.UTF-8@euro" into the
+// form catalogs are registered under, "pt-BR".
+func normalizeLocale(l string) string {
+	if i := strings.IndexAny(l, ".@"); i >= 0 {
+		l = l[:i]
+	}
+	return strings.ReplaceAll(l, "_", "-")
+}
+
+// text returns the translation of s.
+func (t *Theme) text(s string) string {
+	if tr, ok := t.catalog[s]; ok {
+		return tr
+	}
+	return s
+}
+
+// textf returns the translation of format filled in with args.
+func (t *Theme) textf(format string, args ...interface{}) string {
+	return fmt.Sprintf(t.text(format), args...)
+}
+
+// errorf returns an error whose message is shown translated.
+func errorf(format string, args ...interface{}) error {
+	return &rules.Error{Format: format, Args: args}
+}
+
+// errorText returns the message of err, translated if it is one of ours
+// or a *validate.Error not wrapped with more text.
+func (t *Theme) errorText(err error) string {
+	s := err.Error()
+	var e *rules.Error
+	if errors.As(err, &e) && e.Error() == s {
+		return t.textf(e.Format, e.Args...)
+	}
+	return t.text(s)
+}
@@ -0,0 +1,122 @@
+package prompts
+
+import "unicode"
+
+// A Matcher decides which options match the text typed to filter them,
+// and in what order they are listed. Select, Checkboxes and SelectTable
+// filter their options with it, Tags its suggestions and Autocomplete the
+// characters of its suggestions shown matching.
+type Matcher interface {
+	// Match reports whether option matches text, which is not empty,
+	// and returns the indexes of the runes of option shown matched, if
+	// any, and a score: options scoring higher are listed first, and
+	// those scoring the same in the order they are given. Options not
+	// matching a text are taken not to match the longer texts typed
+	// after it either, so that only those that did are matched again.
+	Match(text, option string) (positions []int, score int, ok bool)
+}
+
+// MatcherFunc is a function used as a Matcher.
+type MatcherFunc func(text, option string) (positions []int, score int, ok bool)
+
+// Match returns f(text, option).
+func (f MatcherFunc) Match(text, option string) ([]int, int, bool) {
+	return f(text, option)
+}
+
+var (
+	// FuzzyMatcher, the Matcher of prompts not given WithMatcher, matches
+	// options containing the characters of the text in order, ignoring
+	// case, so that "gf" matches "Grape Fruit". The more of them are
+	// consecutive or start words, the higher the score.
+	FuzzyMatcher Matcher = MatcherFunc(func(text, option string) ([]int, int, bool) {
+		return fuzzyMatch([]rune(text), option)
+	})
+	// PrefixMatcher matches options starting with the text, ignoring case,
+	// and keeps them in order.
+	PrefixMatcher Matcher = MatcherFunc(prefixMatch)
+	// SubstringMatcher matches options containing the text, ignoring case,
+	// those containing it closer to their start first.
+	SubstringMatcher Matcher = MatcherFunc(substringMatch)
+)
+
+// WithMatcher makes a prompt match options with m instead of FuzzyMatcher,
+// e.g. to match them on keywords or IDs that are not shown as well as on
+// their text:
+//
+//	prompts.WithMatcher(prompts.MatcherFunc(func(text, option string) ([]int, int, bool) {
+//		if strings.HasPrefix(ids[option], text) {
+//			return nil, 100, true
+//		}
+//		return prompts.FuzzyMatcher.Match(text, option)
+//	}))
+//
+// Autocomplete given a Matcher also leaves out the suggestions not
+// matching the text typed, and lists the others best first.
+func WithMatcher(m Matcher) Option {
+	return optionFunc(func(c *config) { c.matcher = m })
+}
+
+func prefixMatch(text, option string) ([]int, int, bool) {
+	t := []rune(text)
+	if i := indexFold([]rune(option), t); i != 0 {
+		return nil, 0, false
+	}
+	return span(0, len(t)), 0, true
+}
+
+func substringMatch(text, option string) ([]int, int, bool) {
+	t := []rune(text)
+	i := indexFold([]rune(option), t)
+	if i < 0 {
+		return nil, 0, false
+	}
+	return span(i, len(t)), -i, true
+}
+
+// indexFold returns the index of the first runes of s equal to sub,
+// ignoring case, or -1 if there are none.
+func indexFold(s, sub []rune) int {
+	for i := 0; i+len(sub) <= len(s); i++ {
+		j := 0
+		for j < len(sub) && unicode.ToLower(s[i+j]) == unicode.ToLower(sub[j]) {
+			j++
+		}
+		if j == len(sub) {
+			return i
+		}
+	}
+	return -1
+}
+
+// span returns the n indexes from i on.
+func span(i, n int) []int {
+	positions := make([]int, n)
+	for k := range positions {
+		positions[k] = i + k
+	}
+	return positions
+}
+
+// match reports whether option matches text with the matcher m,
+// or FuzzyMatcher if m is nil. All options match an empty text.
+func match(m Matcher, text []rune, option string) ([]int, int, bool) {
+	if len(text) == 0 {
+		return nil, 0, true
+	}
+	if m == nil {
+		m = FuzzyMatcher
+	}
+	return m.Match(string(text), option)
+}
+
+// filterMatching returns the options matching text with m, best first.
+func filterMatching(m Matcher, text []rune, options []string) []string {
+	f := optionFilter{text: text, matcher: m}
+	f.apply(options)
+	matching := make([]string, len(f.matches))
+	for i, match := range f.matches {
+		matching[i] = options[match.index]
+	}
+	return matching
+}
@@ -0,0 +1,78 @@
+package prompts
+
+// WithoutMouse keeps a prompt from turning on mouse reporting, for
+// terminals that do not support it or to keep selecting text with the
+// mouse. Otherwise the options of Select and Checkboxes can be clicked,
+// and the wheel moves through them.
+func WithoutMouse() Option {
+	return optionFunc(func(c *config) { c.noMouse = true })
+}
+
+// Escape sequences turning xterm mouse reporting of presses and the wheel
+// on and off, with positions in the SGR encoding.
+const (
+	mouseOn  = "\x1b[?1000h\x1b[?1006h"
+	mouseOff = "\x1b[?1006l\x1b[?1000l"
+)
+
+// A clicker is a model whose lines can be clicked.
+type clicker interface {
+	// click handles a click on the line of the last frame with index line,
+	// at the column col counted from zero, and reports whether the prompt
+	// is done.
+	click(line, col int) (done bool, err error)
+}
+
+// mouseModel passes the mouse events read for a clicker on as it takes
+// them: clicks on the lines of the frames drawn by screen, and the wheel
+// as the first keys of km bound to Up and Down.
+type mouseModel struct {
+	model
+	clicker clicker
+	screen  *screen
+	keys    *Keymap
+}
+
+func (m *mouseModel) update(k key) (done bool, err error) {
+	defer func() {
+		if done || err != nil {
+			// The prompt ends with the next frame, and so must the
+			// replies to where it is.
+			m.screen.locate = false
+		}
+	}()
+	switch k.name {
+	case "click":
+		if line := m.screen.lineAt(k.y); line >= 0 {
+			return m.clicker.click(line, k.x-1)
+		}
+		return false, nil
+	case "wheelup":
+		return m.press(m.keys.Up)
+	case "wheeldown":
+		return m.press(m.keys.Down)
+	case "position":
+		m.screen.located(k.y)
+		return false, nil
+	case "mouse":
+		return false, nil
+	}
+	return m.model.update(k)
+}
+
+// press presses the first of keys, if any.
+func (m *mouseModel) press(keys []string) (bool, error) {
+	if len(keys) == 0 {
+		return false, nil
+	}
+	k := key{name: keys[0]}
+	if r := []rune(k.name); len(r) == 1 {
+		k.r = r[0]
+	}
+	return m.model.update(k)
+}
+
+func (m *mouseModel) captures(k key) bool {
+	c, ok := m.model.(keyCapturer)
+	return ok && c.captures(k)
+}
@@ -0,0 +1,271 @@
+package prompts
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+// MultilineOptions configures Multiline.
+type MultilineOptions struct {
+	// Submit lists the keys that accept the text, as named in Keymap.
+	// Nil means Ctrl+D.
+	Submit []string
+	// Placeholder is shown dimmed while nothing is typed, and Hint after
+	// the label while the text is asked.
+	Placeholder, Hint string
+}
+
+func (o MultilineOptions) apply(c *config) { c.multiline = o }
+
+// Multiline asks for text of any number of lines using the label. Enter
+// starts a new line, the arrows move the cursor across lines and Ctrl+D,
+// or the keys given by MultilineOptions, accepts the text. Lines longer
+// than the terminal is wide wrap. The answer is cleaned up by the
+// transforms given with WithTransform and then checked by the validators
+// given with WithValidator.
+//
+// If the standard input is not a terminal, the answer is read from it
+// up to an empty line or the end of the input. An answer preset in an
+// environment variable or an answers file is taken as the whole text,
+// including its empty lines.
+//
+// It returns ErrInterrupted if the user presses Ctrl+C.
+func Multiline(label string, opts ...Option) (string, error) {
+	return MultilineContext(context.Background(), label, opts...)
+}
+
+// MultilineContext is like Multiline but gives up when ctx is done,
+// returning ctx.Err().
+func MultilineContext(ctx context.Context, label string, opts ...Option) (string, error) {
+	c := newConfig(label, opts)
+	m := &multilineModel{
+		label:       label,
+		theme:       &c.theme,
+		keys:        &c.keymap,
+		submit:      c.multiline.Submit,
+		placeholder: c.multiline.Placeholder,
+		hint:        c.multiline.Hint,
+		transforms:  c.transforms,
+		validators:  c.validators,
+		lines:       []line{{}},
+	}
+	if m.submit == nil {
+		m.submit = []string{"ctrl+d"}
+	}
+	if s, ok := c.initial.(string); ok {
+		m.setText(s)
+	}
+	if err := run(ctx, c, m); err != nil {
+		return "", err
+	}
+	return m.value(), nil
+}
+
+type multilineModel struct {
+	label       string
+	theme       *Theme
+	keys        *Keymap
+	submit      []string
+	placeholder string
+	hint        string
+	transforms  []Transform
+	validators  []Validator
+	// lines are the lines of the text and row the one with the cursor.
+	lines []line
+	row   int
+	// read is set once a line has been read
+	// when the standard input is not a terminal.
+	read bool
+	// err is why the last answer was rejected.
+	err  error
+	done bool
+}
+
+// setText replaces the text with s, leaving the cursor at its end.
+func (m *multilineModel) setText(s string) {
+	m.lines = m.lines[:0]
+	for _, l := range strings.Split(s, "\n") {
+		m.lines = append(m.lines, line{buf: []rune(l), pos: len([]rune(l))})
+	}
+	m.row = len(m.lines) - 1
+}
+
+func (m *multilineModel) text() string {
+	s := make([]string, len(m.lines))
+	for i := range m.lines {
+		s[i] = m.lines[i].String()
+	}
+	return strings.Join(s, "\n")
+}
+
+func (m *multilineModel) value() string {
+	return transform(m.transforms, m.text())
+}
+
+func (m *multilineModel) result() interface{} {
+	return m.value()
+}
+
+func (m *multilineModel) prompt() string {
+	if m.read {
+		return ""
+	}
+	return m.theme.label(m.label) + " "
+}
+
+// answer adds s to the lines read, until s is empty.
+func (m *multilineModel) answer(s string) (bool, error) {
+	if s != "" {
+		if m.read {
+			m.lines = append(m.lines, line{})
+		}
+		m.lines[len(m.lines)-1].buf = []rune(s)
+		m.read = true
+		return false, nil
+	}
+	return m.finish()
+}
+
+// answerText takes the lines of s as the text, however many there are.
+func (m *multilineModel) answerText(s string) error {
+	m.setText(strings.ReplaceAll(s, "\r\n", "\n"))
+	_, err := m.finish()
+	return err
+}
+
+// eof accepts the lines read when the input ends, if there are any.
+// As no more can be read, it fails if they do not pass the validators.
+func (m *multilineModel) eof() (bool, error) {
+	if !m.read {
+		return false, io.EOF
+	}
+	done, err := m.finish()
+	return done, errors.Unwrap(err)
+}
+
+// finish accepts the lines read, unless they do not pass the validators.
+func (m *multilineModel) finish() (bool, error) {
+	if err := validate(m.validators, m.value()); err != nil {
+		m.lines, m.read = []line{{}}, false
+		return false, err
+	}
+	m.done = true
+	return true, nil
+}
+
+func (m *multilineModel) rejected() error {
+	return m.err
+}
+
+func (m *multilineModel) update(k key) (bool, error) {
+	l := &m.lines[m.row]
+	m.err = nil
+	switch {
+	case bound(m.submit, k):
+		m.err = validate(m.validators, m.value())
+		m.done = m.err == nil
+	case k.name == "enter":
+		m.breakLine()
+	case k.name == "paste":
+		m.paste(k.paste)
+	case bound(m.keys.Up, k) && m.row > 0:
+		m.moveTo(m.row-1, l.pos)
+	case bound(m.keys.Down, k) && m.row < len(m.lines)-1:
+		m.moveTo(m.row+1, l.pos)
+	case bound(m.keys.Left, k) && l.pos == 0 && m.row > 0:
+		m.moveTo(m.row-1, len(m.lines[m.row-1].buf))
+	case bound(m.keys.Right, k) && l.pos == len(l.buf) && m.row < len(m.lines)-1:
+		m.moveTo(m.row+1, 0)
+	case k.name == "backspace" && l.pos == 0 && m.row > 0:
+		// Join the line to the previous one.
+		prev := &m.lines[m.row-1]
+		pos := len(prev.buf)
+		prev.buf = append(prev.buf, l.buf...)
+		m.lines = append(m.lines[:m.row], m.lines[m.row+1:]...)
+		m.moveTo(m.row-1, pos)
+	case k.name == "delete" && l.pos == len(l.buf) && m.row < len(m.lines)-1:
+		// Join the next line to this one.
+		l.buf = append(l.buf, m.lines[m.row+1].buf...)
+		m.lines = append(m.lines[:m.row+1], m.lines[m.row+2:]...)
+	default:
+		l.edit(k, m.keys)
+	}
+	return m.done, nil
+}
+
+func (m *multilineModel) keyHelp() ([]keyAction, bool) {
+	return append(editActions(m.keys),
+		act("move between the lines", m.keys.Up, m.keys.Down),
+		act("start a new line", []string{"enter"}),
+		act("accept the text", m.submit),
+	), true
+}
+
+// breakLine splits the line at the cursor.
+func (m *multilineModel) breakLine() {
+	l := &m.lines[m.row]
+	rest := append([]rune(nil), l.buf[l.pos:]...)
+	l.buf = l.buf[:l.pos]
+	m.lines = append(m.lines[:m.row+1], append([]line{{buf: rest}}, m.lines[m.row+1:]...)...)
+	m.row++
+}
+
+// paste types text at the cursor as it was pasted, breaking lines
+// where it does.
+func (m *multilineModel) paste(text []rune) {
+	start := 0
+	for i := 0; i <= len(text); i++ {
+		if i < len(text) && text[i] != '\r' && text[i] != '\n' {
+			continue
+		}
+		m.lines[m.row].paste(text[start:i])
+		if i < len(text) {
+			m.breakLine()
+			if text[i] == '\r' && i+1 < len(text) && text[i+1] == '\n' {
+				i++
+			}
+		}
+		start = i + 1
+	}
+}
+
+// moveTo puts the cursor on the row at the column col,
+// or at the end of the row if it is shorter.
+func (m *multilineModel) moveTo(row, col int) {
+	m.row = row
+	l := &m.lines[row]
+	if col > len(l.buf) {
+		col = len(l.buf)
+	}
+	// Keep the cursor off the middle of a character.
+	if col < len(l.buf) {
+		col = l.before(col + 1)
+	}
+	l.pos = col
+}
+
+func (m *multilineModel) view() frame {
+	if m.done {
+		return frame{lines: []string{m.theme.answered(m.label, summary(m.value()))}}
+	}
+	hint := m.theme.textf("[%s to submit]", keyLabel(m.submit))
+	lines := []string{m.theme.hinted(m.label, m.hint) + " " + m.theme.render(m.theme.Hint, hint)}
+	for i := range m.lines {
+		lines = append(lines, m.lines[i].String())
+	}
+	if len(m.lines) == 1 && len(m.lines[0].buf) == 0 && m.placeholder != "" {
+		lines[1] = m.theme.render(m.theme.Hint, m.placeholder)
+	}
+	if m.err != nil {
+		lines = append(lines, m.theme.errorLine(m.err))
+	}
+	l := &m.lines[m.row]
+	return frame{
+		lines:      lines,
+		cursorRow:  m.row + 1,
+		cursorCol:  textWidth(string(l.buf[:l.pos])),
+		showCursor: true,
+	}
+}
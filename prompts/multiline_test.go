@@ -0,0 +1,82 @@
+package prompts_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/prompttest"
+	rules "github.com/tidalmigrations/interactive-cli-prompts/prompts/validate"
+)
+
+func TestMultiline(t *testing.T) {
+	term := prompttest.New(t)
+	res := prompttest.Start(term, func(o prompts.Option) (string, error) {
+		return prompts.Multiline("Notes?", o)
+	})
+	term.WaitFor("Notes?")
+	term.Send("one" + prompttest.Enter + "two" + prompttest.Enter + prompttest.Enter + "three")
+	term.WaitFor("three")
+	if got, want := term.Screen(), "Notes? [Ctrl+D to submit]\none\ntwo\n\nthree"; got != want {
+		t.Errorf("screen shows\n%s\nwant\n%s", got, want)
+	}
+	term.Send(prompttest.CtrlD)
+	got, err := res.Wait()
+	if want := "one\ntwo\n\nthree"; err != nil || got != want {
+		t.Fatalf("Multiline() = %q, %v, want %q, nil", got, err, want)
+	}
+}
+
+func TestMultilineEnvFallback(t *testing.T) {
+	tests := []struct {
+		name, env, want string
+	}{
+		{"one line", "hello", "hello"},
+		{"lines", "one\ntwo", "one\ntwo"},
+		{"empty lines", "one\n\ntwo\n", "one\n\ntwo\n"},
+		{"CRLF", "one\r\ntwo", "one\ntwo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NOTES", tt.env)
+			got, err := prompts.Multiline("Notes?", prompts.WithEnvFallback("NOTES"))
+			if err != nil || got != tt.want {
+				t.Fatalf("Multiline() = %q, %v, want %q, nil", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMultilineEnvFallbackInvalid(t *testing.T) {
+	t.Setenv("NOTES", "  ")
+	_, err := prompts.Multiline("Notes?", prompts.WithEnvFallback("NOTES"), prompts.WithValidator(rules.Required()))
+	if err == nil || !strings.Contains(err.Error(), "invalid answer in $NOTES") {
+		t.Fatalf("Multiline() returned %v, want the answer of $NOTES rejected", err)
+	}
+}
+
+func TestMultilineRecordReplay(t *testing.T) {
+	for _, name := range []string{"answers.yaml", "answers.json"} {
+		t.Run(name, func(t *testing.T) {
+			r := prompts.NewRecorder()
+			term := prompttest.New(t)
+			res := prompttest.Start(term, func(o prompts.Option) (string, error) {
+				return prompts.Multiline("Notes?", prompts.WithRecorder(r), o)
+			})
+			term.WaitFor("Notes?")
+			term.Send("one" + prompttest.Enter + prompttest.Enter + "two" + prompttest.CtrlD)
+			if _, err := res.Wait(); err != nil {
+				t.Fatal(err)
+			}
+			path := filepath.Join(t.TempDir(), name)
+			if err := r.WriteFile(path); err != nil {
+				t.Fatal(err)
+			}
+			got, err := prompts.Multiline("Notes?", prompts.WithAnswersFile(path))
+			if want := "one\n\ntwo"; err != nil || got != want {
+				t.Fatalf("replayed Multiline() = %q, %v, want %q, nil", got, err, want)
+			}
+		})
+	}
+}
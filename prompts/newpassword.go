@@ -0,0 +1,61 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+)
+
+// NewPasswordOptions configures NewPassword.
+type NewPasswordOptions struct {
+	// ConfirmLabel is used to ask for the password the second time.
+	// It defaults to "Confirm password:".
+	ConfirmLabel string
+}
+
+func (o NewPasswordOptions) apply(c *config) { c.newPassword = o }
+
+// errMismatch is shown when the two entries of a new password differ.
+var errMismatch = errorf("passwords do not match")
+
+// NewPassword asks for a new password using the label, and then asks for
+// it again to make sure it was typed as intended. If the entries do not
+// match, it tells so and starts over. Both entries are read as with
+// PasswordPrompt, with the same options.
+//
+// With WithMaxRetries, entries that do not match are rejected answers
+// like any other: it returns ErrTooManyAttempts once as many of them
+// were given.
+func NewPassword(label string, opts ...Option) (string, error) {
+	return NewPasswordContext(context.Background(), label, opts...)
+}
+
+// NewPasswordContext is like NewPassword but gives up when ctx is done,
+// returning ctx.Err().
+func NewPasswordContext(ctx context.Context, label string, opts ...Option) (string, error) {
+	c := newConfig(label, opts)
+	confirmLabel := c.newPassword.ConfirmLabel
+	if confirmLabel == "" {
+		confirmLabel = c.theme.text("Confirm password:")
+	}
+	// There is no point in rating the strength of the confirmation.
+	noStrength := c.password
+	noStrength.ShowStrength = false
+	confirmOpts := append(opts[:len(opts):len(opts)], noStrength)
+	for attempts := 1; ; attempts++ {
+		first, err := PasswordPromptContext(ctx, label, opts...)
+		if err != nil {
+			return "", err
+		}
+		second, err := PasswordPromptContext(ctx, confirmLabel, confirmOpts...)
+		if err != nil {
+			return "", err
+		}
+		if first == second {
+			return first, nil
+		}
+		if max := c.maxRetries; max > 0 && attempts >= max {
+			return "", ErrTooManyAttempts
+		}
+		fmt.Fprintln(c.writer(), c.theme.errorLine(errMismatch))
+	}
+}
@@ -0,0 +1,45 @@
+package prompts_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/prompttest"
+)
+
+func TestNewPassword(t *testing.T) {
+	term := prompttest.New(t)
+	res := prompttest.Start(term, func(o prompts.Option) (string, error) {
+		return prompts.NewPassword("Password:", o)
+	})
+	term.WaitFor("Password:")
+	term.Send("secret" + prompttest.Enter)
+	term.WaitFor("Confirm password:")
+	term.Send("secert" + prompttest.Enter)
+	term.WaitFor("passwords do not match\nPassword:")
+	term.Send("secret" + prompttest.Enter)
+	term.WaitFor("match\nPassword:\nConfirm password:")
+	term.Send("secret" + prompttest.Enter)
+	if got, err := res.Wait(); err != nil || got != "secret" {
+		t.Fatalf("NewPassword() = %q, %v, want \"secret\", nil", got, err)
+	}
+}
+
+func TestNewPasswordMaxRetries(t *testing.T) {
+	term := prompttest.New(t)
+	res := prompttest.Start(term, func(o prompts.Option) (string, error) {
+		return prompts.NewPassword("Password:", prompts.WithMaxRetries(2), o)
+	})
+	term.WaitFor("Password:")
+	term.Send("secret" + prompttest.Enter)
+	term.WaitFor("Confirm password:")
+	term.Send("secert" + prompttest.Enter)
+	term.WaitFor("passwords do not match\nPassword:")
+	term.Send("secret" + prompttest.Enter)
+	term.WaitFor("match\nPassword:\nConfirm password:")
+	term.Send("secert" + prompttest.Enter)
+	if _, err := res.Wait(); !errors.Is(err, prompts.ErrTooManyAttempts) {
+		t.Fatalf("NewPassword() returned %v, want ErrTooManyAttempts", err)
+	}
+}
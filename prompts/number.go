@@ -0,0 +1,121 @@
+package prompts
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Number asks for a number between min and max using the label, as Input
+// does. Up and Down add step to the number typed, or take it away, and
+// answers that are not numbers in range are rejected. Pass math.Inf(-1)
+// or math.Inf(1) as min or max for no bound; step defaults to 1 if it is
+// not positive. The options of Input apply as well.
+//
+// If the standard input is not a terminal, the answer is the next line
+// read from it.
+//
+// It returns ErrInterrupted if the user presses Ctrl+C and io.EOF if the
+// user presses Ctrl+D on an empty line or the input ends.
+func Number(label string, min, max, step float64, opts ...Option) (float64, error) {
+	return NumberContext(context.Background(), label, min, max, step, opts...)
+}
+
+// NumberContext is like Number but gives up when ctx is done,
+// returning ctx.Err().
+func NumberContext(ctx context.Context, label string, min, max, step float64, opts ...Option) (float64, error) {
+	c := newConfig(label, opts)
+	if step <= 0 {
+		step = 1
+	}
+	c.validators = append([]Validator{numberRange(min, max)}, c.validators...)
+	if f, ok := c.initial.(float64); ok {
+		c.initial = formatNumber(f, decimals(f))
+	}
+	m := &numberModel{inputModel: newInputModel(label, c), min: min, max: max, step: step}
+	if err := run(ctx, c, m); err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(strings.TrimSpace(m.value()), 64)
+}
+
+type numberModel struct {
+	*inputModel
+	min, max, step float64
+}
+
+func (m *numberModel) update(k key) (bool, error) {
+	switch {
+	case bound(m.keys.Up, k):
+		m.add(m.step)
+	case bound(m.keys.Down, k):
+		m.add(-m.step)
+	default:
+		return m.inputModel.update(k)
+	}
+	return false, nil
+}
+
+func (m *numberModel) keyHelp() ([]keyAction, bool) {
+	actions, _ := m.inputModel.keyHelp()
+	return append([]keyAction{act("step the number up or down", m.keys.Up, m.keys.Down)}, actions...), true
+}
+
+// add adds d to the number typed, keeping it in range. If what is typed
+// is not a number, the number starts from the bound closest to zero.
+func (m *numberModel) add(d float64) {
+	s := strings.TrimSpace(transform(m.transforms, m.line.String()))
+	if s == "" {
+		s = m.opts.Default
+	}
+	n := decimals(m.step)
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		f = math.Max(0, m.min)
+	} else {
+		if decimals(f) > n {
+			n = decimals(f)
+		}
+		f += d
+	}
+	f = math.Min(math.Max(f, m.min), m.max)
+	s = formatNumber(f, n)
+	m.line = line{buf: []rune(s), pos: len([]rune(s))}
+	m.err = nil
+}
+
+// decimals returns the number of decimals f is written with.
+func decimals(f float64) int {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+	return 0
+}
+
+// formatNumber writes f rounded to n decimals, without trailing zeros.
+func formatNumber(f float64, n int) string {
+	p := math.Pow(10, float64(n))
+	return strconv.FormatFloat(math.Round(f*p)/p, 'f', -1, 64)
+}
+
+// numberRange rejects answers that are not numbers between min and max.
+func numberRange(min, max float64) Validator {
+	return func(s string) error {
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		switch {
+		case err != nil:
+			return errorf("must be a number")
+		case f < min && !math.IsInf(max, 1):
+			return errorf("must be between %v and %v", min, max)
+		case f > max && !math.IsInf(min, -1):
+			return errorf("must be between %v and %v", min, max)
+		case f < min:
+			return errorf("must be at least %v", min)
+		case f > max:
+			return errorf("must be at most %v", max)
+		}
+		return nil
+	}
+}
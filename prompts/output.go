@@ -0,0 +1,36 @@
+package prompts
+
+import "os"
+
+// WithJSONOutput makes a Form or Wizard print its answers to the standard
+// output as a JSON object, keyed by the names of the questions in order,
+// once they are all given. Prompts are shown on the standard error, so a
+// script can run
+//
+//	answers=$(mytool init --json)
+//
+// and read the answers with jq. Questions that were not asked are left
+// out, and so are the answers to password questions, which are never
+// printed.
+func WithJSONOutput() Option {
+	return optionFunc(func(c *config) { c.printJSON = true })
+}
+
+// printAnswers prints the answers of fields as JSON, if opts ask for it.
+func printAnswers(opts []Option, fields []formField, answers Answers) error {
+	if !newConfig("", opts).printJSON {
+		return nil
+	}
+	var keys []string
+	for _, field := range fields {
+		if _, ok := answers[field.name]; ok && !field.q.secret {
+			keys = append(keys, field.name)
+		}
+	}
+	b, err := marshalJSON(keys, answers)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(b)
+	return err
+}
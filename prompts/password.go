@@ -0,0 +1,243 @@
+package prompts
+
+import (
+	"context"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// PasswordOptions configures PasswordPrompt.
+type PasswordOptions struct {
+	// AllowEmpty accepts an empty password instead of asking again.
+	AllowEmpty bool
+	// ShowStrength shows how strong the password is while it is typed.
+	ShowStrength bool
+	// MinStrength refuses passwords weaker than it.
+	MinStrength Strength
+	// Mask, if set, is echoed for every typed character instead of
+	// hiding the input completely. Pressing Ctrl+R then toggles showing
+	// the password in plain text.
+	Mask rune
+	// Placeholder is shown dimmed while nothing is typed, and Hint after
+	// the label while the password is asked.
+	Placeholder, Hint string
+}
+
+func (o PasswordOptions) apply(c *config) { c.password = o }
+
+// PasswordPrompt asks for a string value using the label.
+// The entered value will not be displayed on the screen
+// while typing, unless PasswordOptions.Mask is set, but is edited as with
+// Input. It is cleaned up by the transforms given with
+// WithTransform and then checked by the validators given with
+// WithValidator.
+//
+// If the standard input is not a terminal, the password is the next
+// non-empty line read from it, so it can be piped in by scripts.
+// Use WithTTY to ask on the controlling terminal instead.
+//
+// It returns ErrInterrupted if the user presses Ctrl+C and io.EOF if the
+// user presses Ctrl+D before typing anything or the input ends.
+func PasswordPrompt(label string, opts ...Option) (string, error) {
+	return PasswordPromptContext(context.Background(), label, opts...)
+}
+
+// PasswordPromptContext is like PasswordPrompt but gives up when ctx
+// is done, returning ctx.Err().
+func PasswordPromptContext(ctx context.Context, label string, opts ...Option) (string, error) {
+	c := newConfig(label, opts)
+	m := newPasswordModel(label, c)
+	defer m.line.wipe()
+	if err := run(ctx, c, m); err != nil {
+		return "", err
+	}
+	return m.value(), nil
+}
+
+// PasswordBytes is like PasswordPrompt but returns the password as bytes,
+// which the caller can zero with Wipe once done with it. The prompt zeroes
+// its own copies, except those made to apply transforms and validators,
+// which get the password as a string, and to read it from a pipe.
+func PasswordBytes(label string, opts ...Option) ([]byte, error) {
+	return PasswordBytesContext(context.Background(), label, opts...)
+}
+
+// PasswordBytesContext is like PasswordBytes but gives up when ctx
+// is done, returning ctx.Err().
+func PasswordBytesContext(ctx context.Context, label string, opts ...Option) ([]byte, error) {
+	c := newConfig(label, opts)
+	m := newPasswordModel(label, c)
+	defer m.line.wipe()
+	if err := run(ctx, c, m); err != nil {
+		return nil, err
+	}
+	return m.bytes(), nil
+}
+
+// Wipe zeroes b, e.g. a password returned by PasswordBytes.
+func Wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// errPasswordRequired rejects empty passwords.
+var errPasswordRequired = errorf("a password is required")
+
+func newPasswordModel(label string, c *config) *passwordModel {
+	m := &passwordModel{
+		label:        label,
+		theme:        &c.theme,
+		keys:         &c.keymap,
+		allowEmpty:   c.password.AllowEmpty,
+		mask:         c.password.Mask,
+		showStrength: c.password.ShowStrength,
+		placeholder:  c.password.Placeholder,
+		hint:         c.password.Hint,
+		transforms:   c.transforms,
+		validators:   c.validators,
+		line:         line{secret: true},
+	}
+	if c.theme.ascii && m.mask >= utf8.RuneSelf {
+		m.mask = '*'
+	}
+	if c.password.MinStrength > VeryWeak {
+		m.validators = append([]Validator{minStrength(c.password.MinStrength)}, m.validators...)
+	}
+	return m
+}
+
+type passwordModel struct {
+	label        string
+	theme        *Theme
+	keys         *Keymap
+	allowEmpty   bool
+	mask         rune
+	revealed     bool
+	showStrength bool
+	placeholder  string
+	hint         string
+	transforms   []Transform
+	validators   []Validator
+	line         line
+	// err is why the last answer was rejected.
+	err  error
+	done bool
+}
+
+func (m *passwordModel) value() string {
+	return transform(m.transforms, m.line.String())
+}
+
+// bytes returns the password UTF-8 encoded. Without transforms,
+// it is encoded right from the line, without an intermediate string.
+func (m *passwordModel) bytes() []byte {
+	if len(m.transforms) > 0 {
+		return []byte(m.value())
+	}
+	n := 0
+	for _, r := range m.line.buf {
+		n += utf8.RuneLen(r)
+	}
+	b := make([]byte, n)
+	n = 0
+	for _, r := range m.line.buf {
+		n += utf8.EncodeRune(b[n:], r)
+	}
+	return b
+}
+
+// check validates the password. Without transforms and validators,
+// it does so without converting the password to a string.
+func (m *passwordModel) check() error {
+	if len(m.transforms) == 0 && len(m.validators) == 0 {
+		if len(m.line.buf) == 0 && !m.allowEmpty {
+			return &invalidAnswer{err: errPasswordRequired}
+		}
+		return nil
+	}
+	s := m.value()
+	if s == "" && !m.allowEmpty {
+		return &invalidAnswer{err: errPasswordRequired}
+	}
+	return validate(m.validators, s)
+}
+
+func (m *passwordModel) secret() bool { return true }
+
+func (m *passwordModel) prompt() string {
+	return m.theme.hinted(m.label, m.hint) + " "
+}
+
+func (m *passwordModel) answer(s string) (bool, error) {
+	m.line.wipe()
+	m.line = line{buf: []rune(s), secret: true}
+	if err := m.check(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (m *passwordModel) rejected() error {
+	return m.err
+}
+
+func (m *passwordModel) update(k key) (bool, error) {
+	switch {
+	case bound(m.keys.Submit, k):
+		m.err = m.check()
+		m.done = m.err == nil
+		return m.done, nil
+	case k.name == "ctrl+d":
+		if len(m.line.buf) == 0 {
+			return false, io.EOF
+		}
+	case k.name == "ctrl+r":
+		m.revealed = m.mask != 0 && !m.revealed
+		return false, nil
+	}
+	if m.line.edit(k, m.keys) {
+		m.err = nil
+	}
+	return false, nil
+}
+
+func (m *passwordModel) keyHelp() ([]keyAction, bool) {
+	actions := editActions(m.keys)
+	if m.mask != 0 {
+		actions = append(actions, act("show or hide the password", []string{"ctrl+r"}))
+	}
+	return append(actions, act("accept the password", m.keys.Submit)), true
+}
+
+func (m *passwordModel) view() frame {
+	prompt := m.prompt()
+	var text, beforeCursor string
+	switch {
+	case m.mask == 0:
+	case m.revealed && !m.done:
+		text, beforeCursor = m.line.String(), string(m.line.buf[:m.line.pos])
+	default:
+		text = strings.Repeat(string(m.mask), len(m.line.buf))
+		beforeCursor = strings.Repeat(string(m.mask), m.line.pos)
+	}
+	if m.done {
+		return frame{lines: []string{m.theme.answered(m.label, text)}}
+	}
+	if len(m.line.buf) == 0 && m.placeholder != "" {
+		text = m.theme.render(m.theme.Hint, m.placeholder)
+	}
+	lines := []string{prompt + text}
+	if m.showStrength && len(m.line.buf) > 0 {
+		lines = append(lines, strengthLine(m.theme, m.value()))
+	}
+	if m.err != nil {
+		lines = append(lines, m.theme.errorLine(m.err))
+	}
+	return frame{
+		lines:      lines,
+		cursorCol:  textWidth(prompt + beforeCursor),
+		showCursor: true,
+	}
+}
@@ -0,0 +1,130 @@
+package prompts
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FilePathOptions configures FilePath.
+type FilePathOptions struct {
+	// DirsOnly only suggests directories.
+	DirsOnly bool
+	// Pattern only suggests the files whose names match it, as with
+	// filepath.Match, e.g. "*.yaml". Directories are suggested anyway.
+	Pattern string
+	// MustExist only accepts paths that exist and, with DirsOnly, are
+	// directories, or, with Pattern, are files matching it or directories.
+	MustExist bool
+}
+
+func (o FilePathOptions) apply(c *config) { c.filePath = o }
+
+// FilePath asks for the path of a file or directory as Autocomplete does,
+// suggesting the entries of the directory typed so far whose names start
+// with what follows it. Names starting with a dot are only suggested once
+// a dot is typed. A leading ~ stands for the home directory, and is
+// expanded in the answer.
+//
+// If the standard input is not a terminal, the answer is the next line
+// read from it.
+//
+// It returns ErrInterrupted if the user presses Ctrl+C and io.EOF if the
+// user presses Ctrl+D on an empty line or the input ends.
+func FilePath(label string, opts ...Option) (string, error) {
+	return FilePathContext(context.Background(), label, opts...)
+}
+
+// FilePathContext is like FilePath but gives up when ctx is done,
+// returning ctx.Err().
+func FilePathContext(ctx context.Context, label string, opts ...Option) (string, error) {
+	c := newConfig(label, opts)
+	o := c.filePath
+	opts = append(opts[:len(opts):len(opts)], WithTransform(expandHome))
+	if o.MustExist {
+		opts = append(opts, WithValidator(o.check))
+	}
+	return AutocompleteContext(ctx, label, o.suggest, opts...)
+}
+
+// suggest returns the paths that complete input.
+func (o FilePathOptions) suggest(input string) []string {
+	if input == "~" {
+		return []string{"~" + string(os.PathSeparator)}
+	}
+	i := strings.LastIndexAny(input, "/"+string(os.PathSeparator))
+	dir, base := input[:i+1], input[i+1:]
+	read := expandHome(dir)
+	if read == "" {
+		read = "."
+	}
+	entries, err := ioutil.ReadDir(read)
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, e := range entries {
+		name := e.Name()
+		if !strings.HasPrefix(name, base) || strings.HasPrefix(name, ".") && !strings.HasPrefix(base, ".") {
+			continue
+		}
+		if isDir(filepath.Join(read, name), e) {
+			paths = append(paths, dir+name+string(os.PathSeparator))
+		} else if o.matches(name) {
+			paths = append(paths, dir+name)
+		}
+	}
+	return paths
+}
+
+// matches reports whether the file name is to be suggested.
+func (o FilePathOptions) matches(name string) bool {
+	if o.DirsOnly {
+		return false
+	}
+	if o.Pattern == "" {
+		return true
+	}
+	ok, _ := filepath.Match(o.Pattern, name)
+	return ok
+}
+
+// check rejects paths that do not exist or are not to be suggested.
+func (o FilePathOptions) check(path string) error {
+	fi, err := os.Stat(path)
+	switch {
+	case err != nil:
+		return errorf("no such file or directory")
+	case fi.IsDir():
+		return nil
+	case o.DirsOnly:
+		return errorf("not a directory")
+	case !o.matches(fi.Name()):
+		return errorf("must match %s", o.Pattern)
+	}
+	return nil
+}
+
+// isDir reports whether the entry e at path is a directory
+// or a symbolic link to one.
+func isDir(path string, e os.FileInfo) bool {
+	if e.Mode()&os.ModeSymlink != 0 {
+		fi, err := os.Stat(path)
+		return err == nil && fi.IsDir()
+	}
+	return e.IsDir()
+}
+
+// expandHome replaces a leading ~ in path with the home directory.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, "~"+string(os.PathSeparator)) {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return home + path[1:]
+}
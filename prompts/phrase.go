@@ -0,0 +1,59 @@
+package prompts
+
+import (
+	"context"
+)
+
+// ConfirmPhrase guards a destructive operation by asking the user to type
+// phrase exactly using the label, such as the name of the resource to
+// delete, which is shown after the label. It returns true once the phrase
+// typed matches and false if Enter is pressed without typing anything.
+// Other text is rejected. The options of Input apply as well.
+//
+// If the standard input is not a terminal, the answer is the next line
+// read from it.
+//
+// It returns ErrInterrupted if the user presses Ctrl+C and io.EOF if the
+// user presses Ctrl+D on an empty line or the input ends.
+func ConfirmPhrase(label, phrase string, opts ...Option) (bool, error) {
+	return ConfirmPhraseContext(context.Background(), label, phrase, opts...)
+}
+
+// ConfirmPhraseContext is like ConfirmPhrase but gives up when ctx
+// is done, returning ctx.Err().
+func ConfirmPhraseContext(ctx context.Context, label, phrase string, opts ...Option) (bool, error) {
+	c := newConfig(label, opts)
+	c.input.Default = ""
+	c.validators = append([]Validator{matchPhrase(phrase)}, c.validators...)
+	m := &phraseModel{inputModel: newInputModel(label, c), phrase: phrase}
+	if err := run(ctx, c, m); err != nil {
+		return false, err
+	}
+	return m.value() == phrase, nil
+}
+
+// matchPhrase rejects answers other than phrase, except an empty one.
+func matchPhrase(phrase string) Validator {
+	return func(s string) error {
+		if s != "" && s != phrase {
+			return errorf("type %q exactly to confirm, or nothing to cancel", phrase)
+		}
+		return nil
+	}
+}
+
+type phraseModel struct {
+	*inputModel
+	phrase string
+}
+
+func (m *phraseModel) prompt() string {
+	return m.theme.label(m.label) + " (" + m.theme.textf("type %q to confirm", m.phrase) + ") "
+}
+
+func (m *phraseModel) view() frame {
+	if m.done {
+		return frame{lines: []string{m.theme.answered(m.label, m.theme.yesNo(m.value() == m.phrase))}}
+	}
+	return m.editing(m.prompt())
+}
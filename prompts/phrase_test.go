@@ -0,0 +1,68 @@
+package prompts_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/prompttest"
+)
+
+func TestConfirmPhrase(t *testing.T) {
+	tests := []struct {
+		name string
+		keys string
+		want bool
+	}{
+		{"phrase", "prod-eu" + prompttest.Enter, true},
+		{"nothing", prompttest.Enter, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term := prompttest.New(t)
+			res := prompttest.Start(term, func(o prompts.Option) (bool, error) {
+				return prompts.ConfirmPhrase("Delete cluster?", "prod-eu", o)
+			})
+			term.WaitFor("Delete cluster?")
+			term.Send(tt.keys)
+			if got, err := res.Wait(); err != nil || got != tt.want {
+				t.Fatalf("ConfirmPhrase() = %v, %v, want %v, nil", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfirmPhraseScreen(t *testing.T) {
+	term := prompttest.New(t)
+	res := prompttest.Start(term, func(o prompts.Option) (bool, error) {
+		return prompts.ConfirmPhrase("Delete cluster?", "prod-eu", o)
+	})
+	term.WaitFor("Delete cluster?")
+	if got, want := term.Screen(), `Delete cluster? (type "prod-eu" to confirm)`; got != want {
+		t.Errorf("screen shows %q, want %q", got, want)
+	}
+	term.Send("prod" + prompttest.Enter)
+	term.WaitFor("or nothing to cancel")
+	if got, want := strings.SplitN(term.Screen(), "\n", 2)[0], `Delete cluster? (type "prod-eu" to confirm) prod`; got != want {
+		t.Errorf("screen shows %q while typing, want %q", got, want)
+	}
+	term.Send("-eu" + prompttest.Enter)
+	if got, err := res.Wait(); err != nil || !got {
+		t.Fatalf("ConfirmPhrase() = %v, %v, want true, nil", got, err)
+	}
+}
+
+func TestConfirmPhraseLines(t *testing.T) {
+	var out strings.Builder
+	got, err := prompts.ConfirmPhrase("Delete cluster?", "prod-eu",
+		prompts.WithInput(strings.NewReader("prod\nprod-eu\n")), prompts.WithOutput(&out))
+	if err != nil || !got {
+		t.Fatalf("ConfirmPhrase() = %v, %v, want true, nil", got, err)
+	}
+	want := `Delete cluster? (type "prod-eu" to confirm) ` + "\n" +
+		`type "prod-eu" exactly to confirm, or nothing to cancel` + "\n" +
+		`Delete cluster? (type "prod-eu" to confirm) ` + "\n"
+	if out.String() != want {
+		t.Errorf("output is %q, want %q", out.String(), want)
+	}
+}
@@ -0,0 +1,151 @@
+package prompts
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+)
+
+// PINOptions configures PIN.
+type PINOptions struct {
+	// Mask, if set, is shown in the boxes instead of the digits typed.
+	Mask rune
+}
+
+func (o PINOptions) apply(c *config) { c.pin = o }
+
+// PIN asks for a code of length digits using the label, such as a device
+// PIN or a one-time code for two-factor authentication, and returns it.
+// Each digit typed fills the next of length boxes and Backspace empties
+// the last one filled; other keys are ignored. The code is submitted as
+// soon as all the boxes are filled, provided it passes the validators
+// given with WithValidator, and the boxes are emptied otherwise.
+//
+// If the standard input is not a terminal, the next line read from it
+// must be a code of length digits.
+//
+// It returns ErrInterrupted if the user presses Ctrl+C.
+func PIN(label string, length int, opts ...Option) (string, error) {
+	return PINContext(context.Background(), label, length, opts...)
+}
+
+// PINContext is like PIN but gives up when ctx is done,
+// returning ctx.Err().
+func PINContext(ctx context.Context, label string, length int, opts ...Option) (string, error) {
+	c := newConfig(label, opts)
+	if length < 1 {
+		length = 1
+	}
+	m := &pinModel{
+		label:      label,
+		theme:      &c.theme,
+		mask:       c.pin.Mask,
+		length:     length,
+		validators: c.validators,
+		line:       line{secret: true},
+	}
+	if c.theme.ascii && m.mask >= utf8.RuneSelf {
+		m.mask = '*'
+	}
+	defer m.line.wipe()
+	if err := run(ctx, c, m); err != nil {
+		return "", err
+	}
+	return m.line.String(), nil
+}
+
+type pinModel struct {
+	label      string
+	theme      *Theme
+	mask       rune
+	length     int
+	validators []Validator
+	line       line
+	// err is why the last code was rejected.
+	err  error
+	done bool
+}
+
+func (m *pinModel) secret() bool { return true }
+
+func (m *pinModel) prompt() string {
+	return m.theme.label(m.label) + " (" + m.theme.textf("%d digits", m.length) + ") "
+}
+
+func (m *pinModel) answer(s string) (bool, error) {
+	s = strings.TrimSpace(s)
+	if err := m.check(s); err != nil {
+		return false, err
+	}
+	m.line.wipe()
+	m.line = line{buf: []rune(s), secret: true}
+	m.done = true
+	return true, nil
+}
+
+// check returns why s is not an acceptable code, if it is not.
+func (m *pinModel) check(s string) error {
+	if utf8.RuneCountInString(s) != m.length || strings.Trim(s, "0123456789") != "" {
+		return &invalidAnswer{err: errorf("must be %d digits", m.length)}
+	}
+	return validate(m.validators, s)
+}
+
+func (m *pinModel) rejected() error {
+	return m.err
+}
+
+func (m *pinModel) update(k key) (bool, error) {
+	switch {
+	case k.r >= '0' && k.r <= '9' && len(m.line.buf) < m.length:
+		m.err = nil
+		m.line.insert(k.r)
+		if len(m.line.buf) == m.length {
+			if m.err = m.check(m.line.String()); m.err != nil {
+				m.line.wipe()
+				return false, nil
+			}
+			m.done = true
+		}
+	case k.name == "backspace":
+		m.line.backspace()
+	}
+	return m.done, nil
+}
+
+func (m *pinModel) keyHelp() ([]keyAction, bool) {
+	return []keyAction{
+		note("type the digits"),
+		act("delete the last digit", []string{"backspace"}),
+	}, false
+}
+
+func (m *pinModel) view() frame {
+	t := m.theme
+	if m.done {
+		return frame{lines: []string{t.answered(m.label, strings.Repeat("*", m.length))}}
+	}
+	prompt := t.label(m.label) + " "
+	boxes := make([]string, m.length)
+	col := 0
+	for i := range boxes {
+		switch {
+		case i < len(m.line.buf) && m.mask != 0:
+			boxes[i] = "[" + string(m.mask) + "]"
+		case i < len(m.line.buf):
+			boxes[i] = "[" + string(m.line.buf[i]) + "]"
+		case i == len(m.line.buf):
+			boxes[i] = t.render(t.Highlight, "[ ]")
+			// Put the cursor inside the box, each box and the space
+			// after it taking four columns.
+			col = textWidth(prompt) + 4*i + 1
+		default:
+			boxes[i] = t.render(t.Hint, "[ ]")
+		}
+	}
+	lines := []string{prompt + strings.Join(boxes, " ")}
+	if m.err != nil {
+		lines = append(lines, t.errorLine(m.err))
+	}
+	return frame{lines: lines, cursorCol: col, showCursor: col > 0}
+}
@@ -0,0 +1,189 @@
+package prompts
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProgressOptions configures a Progress bar.
+type ProgressOptions struct {
+	// Width is the number of columns of the bar, 30 if it is not positive.
+	Width int
+}
+
+func (o ProgressOptions) apply(c *config) { c.progress = o }
+
+// Progress is a progress bar for the work of a loop, showing the label,
+// the share of the work done, how fast it is done and how long it should
+// take to finish. When the standard error is not a terminal, it prints
+// a plain line instead every tenth of the work, or every few seconds
+// when the total is unknown.
+type Progress struct {
+	theme Theme
+	opts  ProgressOptions
+	out   io.Writer
+	tty   bool
+	label string
+	total int64
+
+	mu      sync.Mutex
+	n       int64
+	start   time.Time
+	drawn   time.Time
+	printed int64
+	done    bool
+}
+
+// Redraws are at most this frequent on terminals, and plain lines for
+// unknown totals this frequent otherwise.
+const (
+	progressRedraw = 100 * time.Millisecond
+	progressPrint  = 5 * time.Second
+)
+
+// NewProgress shows a progress bar with the label on the standard error
+// for total units of work, or an unknown number if total is not positive.
+func NewProgress(label string, total int64, opts ...Option) *Progress {
+	c := newConfig(label, opts)
+	p := &Progress{
+		theme: c.theme,
+		opts:  c.progress,
+		out:   c.writer(),
+		tty:   isTerminal(c.writer()) && !c.accessible,
+		label: label,
+		total: total,
+		start: time.Now(),
+	}
+	p.theme.colors = detectColors(c.writer())
+	if p.opts.Width <= 0 {
+		p.opts.Width = 30
+	}
+	if p.tty {
+		io.WriteString(p.out, "\x1b[?25l")
+		p.draw(p.start)
+	}
+	return p
+}
+
+// Add records n more units of work done.
+func (p *Progress) Add(n int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.set(p.n + n)
+}
+
+// Set records that n units of work are done.
+func (p *Progress) Set(n int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.set(n)
+}
+
+// Write records len(b) more units of work done, so that a Progress can
+// count the bytes copied by io.Copy through an io.MultiWriter or
+// io.TeeReader. It never fails.
+func (p *Progress) Write(b []byte) (int, error) {
+	p.Add(int64(len(b)))
+	return len(b), nil
+}
+
+// Done shows the bar as it ends and moves below it.
+func (p *Progress) Done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.done {
+		return
+	}
+	p.done = true
+	if p.tty {
+		p.draw(time.Now())
+		io.WriteString(p.out, "\r\n\x1b[?25h")
+		return
+	}
+	if p.printed != p.n {
+		fmt.Fprintln(p.out, p.line(time.Now()))
+	}
+}
+
+func (p *Progress) set(n int64) {
+	if p.done {
+		return
+	}
+	p.n = n
+	now := time.Now()
+	switch {
+	case p.tty:
+		if now.Sub(p.drawn) >= progressRedraw {
+			p.draw(now)
+		}
+	case p.total > 0:
+		if n*10/p.total > p.printed*10/p.total {
+			p.print(now)
+		}
+	case now.Sub(p.drawn) >= progressPrint:
+		p.print(now)
+	}
+}
+
+func (p *Progress) draw(now time.Time) {
+	io.WriteString(p.out, "\r\x1b[K"+p.line(now))
+	p.drawn = now
+}
+
+func (p *Progress) print(now time.Time) {
+	fmt.Fprintln(p.out, p.line(now))
+	p.drawn, p.printed = now, p.n
+}
+
+// line renders the progress at the time now.
+func (p *Progress) line(now time.Time) string {
+	t := &p.theme
+	elapsed := now.Sub(p.start)
+	var rate float64
+	if elapsed > 0 {
+		rate = float64(p.n) / elapsed.Seconds()
+	}
+	stats := fmt.Sprintf("%d %s/s", p.n, formatRate(rate))
+	if p.total <= 0 {
+		return p.label + " " + t.render(t.Hint, stats)
+	}
+	share := float64(p.n) / float64(p.total)
+	if share > 1 {
+		share = 1
+	}
+	full, empty := "█", "░"
+	if t.ascii {
+		full, empty = "#", "-"
+	}
+	filled := int(share * float64(p.opts.Width))
+	bar := t.render(t.Highlight, strings.Repeat(full, filled)) + strings.Repeat(empty, p.opts.Width-filled)
+	stats = fmt.Sprintf("%d/%d %s/s", p.n, p.total, formatRate(rate))
+	if rate > 0 && p.n < p.total {
+		eta := time.Duration(float64(p.total-p.n) / rate * float64(time.Second))
+		stats += " ETA " + formatETA(eta)
+	}
+	return fmt.Sprintf("%s %s %3.0f%% %s", p.label, bar, share*100, t.render(t.Hint, stats))
+}
+
+// formatRate writes a rate with about three significant digits.
+func formatRate(r float64) string {
+	switch {
+	case r >= 100:
+		return fmt.Sprintf("%.0f", r)
+	case r >= 10:
+		return fmt.Sprintf("%.1f", r)
+	}
+	return fmt.Sprintf("%.2f", r)
+}
+
+// formatETA writes d as M:SS, or H:MM:SS if it is an hour or more.
+func formatETA(d time.Duration) string {
+	s := int(d.Round(time.Second).Seconds())
+	if s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+	}
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
@@ -0,0 +1,115 @@
+// Package prompts implements interactive prompts for command line
+// applications.
+package prompts
+
+import (
+	"io"
+	"os"
+	"time"
+)
+
+// Option configures a prompt. Each prompt has its own options struct,
+// such as InputOptions or PasswordOptions, which implements Option.
+// When the same options struct is passed more than once, the last one wins.
+// Options that apply to all prompts are created by the With functions.
+type Option interface {
+	apply(*config)
+}
+
+// optionFunc adapts a function to the Option interface.
+type optionFunc func(*config)
+
+func (f optionFunc) apply(c *config) { f(c) }
+
+// config collects the options passed to a prompt.
+type config struct {
+	input         InputOptions
+	confirm       ConfirmOptions
+	password      PasswordOptions
+	newPassword   NewPasswordOptions
+	selectOptions SelectOptions
+	checkboxes    CheckboxesOptions
+	autocomplete  AutocompleteOptions
+	filePath      FilePathOptions
+	directoryTree DirectoryTreeOptions
+	editor        EditorOptions
+	multiline     MultilineOptions
+	slider        SliderOptions
+	date          DateOptions
+	spinner       SpinnerOptions
+	progress      ProgressOptions
+	tags          TagsOptions
+	reorder       ReorderOptions
+	transfer      TransferOptions
+	treeSelect    TreeSelectOptions
+	table         TableOptions
+	rating        RatingOptions
+	toggle        ToggleOptions
+	pin           PINOptions
+	keyValues     KeyValuesOptions
+	stream        StreamOptions
+
+	// key identifies the prompt in answers files.
+	key         string
+	envVar      string
+	answersFile string
+	askMissing  bool
+	recorder    *Recorder
+	validators  []Validator
+	transforms  []Transform
+	matcher     Matcher
+	tty         bool
+	in          io.Reader
+	out         io.Writer
+	// terminal is the terminal given with WithTerminal.
+	terminal   *os.File
+	renderer   Renderer
+	driver     *Driver
+	noMouse    bool
+	altScreen  bool
+	accessible bool
+	locale     *string
+	theme      Theme
+	keymap     Keymap
+
+	// initial is the answer a prompt starts from,
+	// e.g. when going back to it in a Wizard.
+	initial interface{}
+	// when is the condition for asking a question of a Form or Wizard.
+	when func(Answers) bool
+	// remembered is set if initial is the answer remembered by WithRemember.
+	remembered bool
+	// history is set to make Input remember its answers.
+	history *HistoryOptions
+	// remember is set to remember the answer for the next run.
+	remember *RememberOptions
+	// review makes a Form or Wizard review its answers.
+	review bool
+	// printJSON makes a Form or Wizard print its answers as JSON.
+	printJSON bool
+	// step is set when the prompt is a step of a Wizard.
+	step *step
+	// timeout is how long the prompt waits for a key
+	// before submitting its answer, if set.
+	timeout time.Duration
+	// maxRetries is how many answers the prompt rejects
+	// before giving up, if set.
+	maxRetries int
+}
+
+func newConfig(label string, opts []Option) *config {
+	c := &config{key: label, theme: currentTheme(), keymap: currentKeymap(), accessible: accessibleByDefault()}
+	for _, o := range opts {
+		o.apply(c)
+	}
+	recall(c)
+	if c.locale != nil {
+		c.theme.catalog = catalogFor(*c.locale)
+	} else {
+		c.theme.catalog = catalogFor(currentLocale())
+	}
+	if c.theme.ascii || !unicodeLocale() {
+		c.theme.toASCII()
+	}
+	return c
+}
@@ -0,0 +1,196 @@
+// Package prompttest runs prompts against a fake terminal in tests,
+// typing keys into them and checking what they show:
+//
+//	func TestName(t *testing.T) {
+//		term := prompttest.New(t)
+//		res := prompttest.Start(term, func(o prompts.Option) (string, error) {
+//			return prompts.Input("What is your name?", o)
+//		})
+//		term.WaitFor("What is your name?")
+//		term.Send("Gopher" + prompttest.Enter)
+//		name, err := res.Wait()
+//		...
+//	}
+//
+// The terminal is a pseudo-terminal, so prompts run as they do for users,
+// and its output is interpreted to keep the text it would show.
+package prompttest
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+)
+
+// Keys that can be sent to a Terminal.
+const (
+	Enter     = "\r"
+	Tab       = "\t"
+	Space     = " "
+	Backspace = "\x7f"
+	Esc       = "\x1b"
+	Up        = "\x1b[A"
+	Down      = "\x1b[B"
+	Right     = "\x1b[C"
+	Left      = "\x1b[D"
+	CtrlC     = "\x03"
+	CtrlD     = "\x04"
+)
+
+// Size of the screen of a Terminal.
+const (
+	Width  = 80
+	Height = 24
+)
+
+// Timeout is how long WaitFor and Result.Wait wait before failing the test.
+var Timeout = 5 * time.Second
+
+// Terminal is a fake terminal for prompts to ask on.
+type Terminal struct {
+	tb       testing.TB
+	pty, tty *os.File
+	done     chan struct{}
+
+	mu     sync.Mutex
+	screen *screen
+	// received counts the bytes of output interpreted so far.
+	received int
+}
+
+// New returns a Terminal, which is closed when the test ends.
+// The test is skipped where there are no pseudo-terminals.
+func New(tb testing.TB) *Terminal {
+	tb.Helper()
+	pty, tty, err := openPTY(Width, Height)
+	if err != nil {
+		tb.Skipf("prompttest: no pseudo-terminal: %v", err)
+	}
+	// Keys sent between prompts are not echoed.
+	if _, err := term.MakeRaw(int(tty.Fd())); err != nil {
+		tb.Fatalf("prompttest: %v", err)
+	}
+	t := &Terminal{tb: tb, pty: pty, tty: tty, done: make(chan struct{}), screen: newScreen(Width, Height)}
+	go t.read()
+	tb.Cleanup(t.close)
+	return t
+}
+
+// Option makes a prompt ask on t.
+func (t *Terminal) Option() prompts.Option {
+	return prompts.WithTerminal(t.tty)
+}
+
+// File returns the terminal prompts ask on, for code that takes it as an
+// input or output of its own, such as a command given it with SetIn.
+func (t *Terminal) File() *os.File {
+	return t.tty
+}
+
+// Send types keys, as if the user pressed them.
+func (t *Terminal) Send(keys string) {
+	t.tb.Helper()
+	if _, err := t.pty.WriteString(keys); err != nil {
+		t.tb.Fatalf("prompttest: sending %q: %v", keys, err)
+	}
+}
+
+// Screen returns the text on the screen, without trailing spaces and
+// empty lines.
+func (t *Terminal) Screen() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.screen.String()
+}
+
+// WaitFor waits until the screen shows text and fails the test if it does
+// not within Timeout.
+func (t *Terminal) WaitFor(text string) {
+	t.tb.Helper()
+	deadline := time.Now().Add(Timeout)
+	for !strings.Contains(t.Screen(), text) {
+		if time.Now().After(deadline) {
+			t.tb.Fatalf("prompttest: screen does not show %q:\n%s", text, t.Screen())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// read interprets the output of the prompts until t is closed.
+func (t *Terminal) read() {
+	defer close(t.done)
+	b := make([]byte, 4096)
+	for {
+		n, err := t.pty.Read(b)
+		t.mu.Lock()
+		t.screen.write(b[:n])
+		t.received += n
+		t.mu.Unlock()
+		if err != nil {
+			return
+		}
+	}
+}
+
+// settle waits until the output of t has stopped for a moment,
+// so that the screen shows all of it.
+func (t *Terminal) settle() {
+	deadline := time.Now().Add(Timeout)
+	for last := -1; time.Now().Before(deadline); {
+		t.mu.Lock()
+		n := t.received
+		t.mu.Unlock()
+		if n == last {
+			return
+		}
+		last = n
+		time.Sleep(settleTime)
+	}
+}
+
+// settleTime is how long the output must stop for to have settled.
+const settleTime = 20 * time.Millisecond
+
+func (t *Terminal) close() {
+	t.tty.Close()
+	<-t.done
+	t.pty.Close()
+}
+
+// Result is the answer of a prompt started with Start.
+type Result[T any] struct {
+	t     *Terminal
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// Start runs ask in the background, passing it the option making prompts
+// ask on t, and returns its Result.
+func Start[T any](t *Terminal, ask func(prompts.Option) (T, error)) *Result[T] {
+	r := &Result[T]{t: t, done: make(chan struct{})}
+	go func() {
+		defer close(r.done)
+		r.value, r.err = ask(t.Option())
+	}()
+	return r
+}
+
+// Wait waits for the prompt to return and fails the test if it does not
+// within Timeout. Once it returns, the screen shows what it left.
+func (r *Result[T]) Wait() (T, error) {
+	r.t.tb.Helper()
+	select {
+	case <-r.done:
+	case <-time.After(Timeout):
+		r.t.tb.Fatalf("prompttest: the prompt is still waiting for an answer:\n%s", r.t.Screen())
+	}
+	r.t.settle()
+	return r.value, r.err
+}
@@ -0,0 +1,78 @@
+package prompttest_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/prompttest"
+)
+
+func TestStartWait(t *testing.T) {
+	term := prompttest.New(t)
+	res := prompttest.Start(term, func(o prompts.Option) (string, error) {
+		return prompts.Input("What is your name?", o)
+	})
+	term.WaitFor("What is your name?")
+	term.Send("Gopher")
+	term.WaitFor("What is your name? Gopher")
+	term.Send(prompttest.Enter)
+	name, err := res.Wait()
+	if err != nil || name != "Gopher" {
+		t.Fatalf("Wait() = %q, %v, want %q, nil", name, err, "Gopher")
+	}
+	if got, want := term.Screen(), "What is your name? Gopher"; got != want {
+		t.Errorf("screen shows %q once answered, want %q", got, want)
+	}
+}
+
+func TestSeveralPrompts(t *testing.T) {
+	term := prompttest.New(t)
+	res := prompttest.Start(term, func(o prompts.Option) ([]string, error) {
+		var answers []string
+		for _, label := range []string{"First?", "Second?"} {
+			s, err := prompts.Input(label, o)
+			if err != nil {
+				return nil, err
+			}
+			answers = append(answers, s)
+		}
+		return answers, nil
+	})
+	term.WaitFor("First?")
+	term.Send("a" + prompttest.Enter)
+	term.WaitFor("Second?")
+	term.Send("b" + prompttest.Enter)
+	answers, err := res.Wait()
+	if err != nil || strings.Join(answers, ",") != "a,b" {
+		t.Fatalf("Wait() = %q, %v, want [a b], nil", answers, err)
+	}
+	if got, want := term.Screen(), "First? a\nSecond? b"; got != want {
+		t.Errorf("screen shows\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestInterrupt(t *testing.T) {
+	term := prompttest.New(t)
+	res := prompttest.Start(term, func(o prompts.Option) (string, error) {
+		return prompts.Input("Name?", o)
+	})
+	term.WaitFor("Name?")
+	term.Send(prompttest.CtrlC)
+	if _, err := res.Wait(); !errors.Is(err, prompts.ErrInterrupted) {
+		t.Fatalf("Wait() returned %v, want ErrInterrupted", err)
+	}
+}
+
+func TestFile(t *testing.T) {
+	term := prompttest.New(t)
+	res := prompttest.Start(term, func(prompts.Option) (string, error) {
+		return prompts.Input("Name?", prompts.WithInput(term.File()), prompts.WithOutput(term.File()))
+	})
+	term.WaitFor("Name?")
+	term.Send("Gopher" + prompttest.Enter)
+	if name, err := res.Wait(); err != nil || name != "Gopher" {
+		t.Fatalf("Wait() = %q, %v, want %q, nil", name, err, "Gopher")
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !linux
+// +build !linux
+
+package prompttest
+
+import (
+	"errors"
+	"os"
+)
+
+// openPTY is only implemented on Linux.
+func openPTY(width, height int) (pty, tty *os.File, err error) {
+	return nil, nil, errors.New("not supported on this system")
+}
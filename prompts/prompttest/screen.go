@@ -0,0 +1,218 @@
+package prompttest
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// screen keeps the text a terminal shows by interpreting its output, as
+// far as prompts need: moving the cursor, erasing and wrapping lines.
+// Colors and other attributes are dropped.
+type screen struct {
+	width    int
+	rows     [][]rune
+	row, col int
+	// wrap is set when a rune was written in the last column,
+	// so that the next one goes on the next row.
+	wrap bool
+	// pending holds the start of a sequence not fully written yet.
+	pending []byte
+	// join is set after a zero width joiner, which joins the next rune
+	// to the character before it.
+	join bool
+}
+
+func newScreen(width, height int) *screen {
+	s := &screen{width: width, rows: make([][]rune, height)}
+	for i := range s.rows {
+		s.rows[i] = s.blank()
+	}
+	return s
+}
+
+func (s *screen) blank() []rune {
+	return []rune(strings.Repeat(" ", s.width))
+}
+
+func (s *screen) write(b []byte) {
+	s.pending = append(s.pending, b...)
+	for len(s.pending) > 0 {
+		n := s.step(s.pending)
+		if n == 0 {
+			return
+		}
+		s.pending = s.pending[n:]
+	}
+}
+
+// step interprets the rune or escape sequence b starts with and returns
+// its length, or zero if b holds only part of it.
+func (s *screen) step(b []byte) int {
+	switch b[0] {
+	case '\x1b':
+		if len(b) < 2 {
+			return 0
+		}
+		if b[1] != '[' {
+			return 2
+		}
+		for i := 2; i < len(b); i++ {
+			if b[i] >= 0x40 && b[i] <= 0x7e {
+				s.csi(string(b[2:i]), b[i])
+				return i + 1
+			}
+		}
+		return 0
+	case '\r':
+		s.col, s.wrap = 0, false
+	case '\n':
+		s.lineFeed()
+	case '\b':
+		if s.col > 0 {
+			s.col--
+		}
+		s.wrap = false
+	case '\t':
+		s.col = (s.col/8 + 1) * 8
+		if s.col >= s.width {
+			s.col = s.width - 1
+		}
+	default:
+		if b[0] < ' ' {
+			return 1
+		}
+		if !utf8.FullRune(b) {
+			return 0
+		}
+		r, n := utf8.DecodeRune(b)
+		s.put(r)
+		return n
+	}
+	return 1
+}
+
+// put writes r at the cursor. Wide characters take two columns, the
+// second one holding a zero, and combining marks and what joins emoji
+// into one are dropped.
+func (s *screen) put(r rune) {
+	join := s.join
+	s.join = r == '\u200d'
+	w := runewidth.RuneWidth(r)
+	if w == 0 || join || r >= 0x1f3fb && r <= 0x1f3ff {
+		return
+	}
+	if s.wrap || s.col+w > s.width {
+		s.col, s.wrap = 0, false
+		s.lineFeed()
+	}
+	s.rows[s.row][s.col] = r
+	if w == 2 {
+		s.col++
+		s.rows[s.row][s.col] = 0
+	}
+	if s.col == s.width-1 {
+		s.wrap = true
+	} else {
+		s.col++
+	}
+}
+
+// lineFeed moves the cursor down a row, scrolling at the bottom.
+func (s *screen) lineFeed() {
+	if s.row < len(s.rows)-1 {
+		s.row++
+		return
+	}
+	copy(s.rows, s.rows[1:])
+	s.rows[len(s.rows)-1] = s.blank()
+}
+
+// csi interprets the control sequence with the parameters params
+// and the final byte final.
+func (s *screen) csi(params string, final byte) {
+	if strings.HasPrefix(params, "?") {
+		// Private modes, such as hiding the cursor, change nothing shown.
+		return
+	}
+	nums := strings.Split(params, ";")
+	n, _ := strconv.Atoi(nums[0])
+	count := n
+	if count < 1 {
+		count = 1
+	}
+	s.wrap = false
+	switch final {
+	case 'A':
+		s.row = max(s.row-count, 0)
+	case 'B':
+		s.row = min(s.row+count, len(s.rows)-1)
+	case 'C':
+		s.col = min(s.col+count, s.width-1)
+	case 'D':
+		s.col = max(s.col-count, 0)
+	case 'G':
+		s.col = min(count, s.width) - 1
+	case 'H':
+		col := 1
+		if len(nums) > 1 {
+			col, _ = strconv.Atoi(nums[1])
+		}
+		s.row, s.col = min(count, len(s.rows))-1, min(max(col, 1), s.width)-1
+	case 'J':
+		switch n {
+		case 0:
+			s.erase(s.row, s.col, s.width)
+			for i := s.row + 1; i < len(s.rows); i++ {
+				s.rows[i] = s.blank()
+			}
+		case 2:
+			for i := range s.rows {
+				s.rows[i] = s.blank()
+			}
+		}
+	case 'K':
+		switch n {
+		case 0:
+			s.erase(s.row, s.col, s.width)
+		case 1:
+			s.erase(s.row, 0, s.col+1)
+		case 2:
+			s.erase(s.row, 0, s.width)
+		}
+	}
+}
+
+// erase blanks the columns from and up to to of the row.
+func (s *screen) erase(row, from, to int) {
+	for i := from; i < to; i++ {
+		s.rows[row][i] = ' '
+	}
+}
+
+func (s *screen) String() string {
+	lines := make([]string, len(s.rows))
+	for i, r := range s.rows {
+		lines[i] = strings.TrimRight(strings.ReplaceAll(string(r), "\x00", ""), " ")
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
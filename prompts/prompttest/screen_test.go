@@ -0,0 +1,58 @@
+package prompttest
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScreen(t *testing.T) {
+	tests := []struct {
+		name string
+		// writes are written one after the other.
+		writes []string
+		want   string
+	}{
+		{"text", []string{"Name? Gopher"}, "Name? Gopher"},
+		{"lines", []string{"one\r\ntwo\r\nthree"}, "one\ntwo\nthree"},
+		{"carriage return", []string{"hello\rj"}, "jello"},
+		{"line feed keeps the column", []string{"ab\ncd"}, "ab\n  cd"},
+		{"backspace", []string{"abc\bX"}, "abX"},
+		{"tab", []string{"a\tb"}, "a       b"},
+		{"cursor up", []string{"one\r\ntwo\x1b[1A\rONE"}, "ONE\ntwo"},
+		{"cursor up without count", []string{"one\r\ntwo\x1b[A\rX"}, "Xne\ntwo"},
+		{"cursor down", []string{"one\x1b[2B\rthree"}, "one\n\nthree"},
+		{"cursor forward and back", []string{"abcdef\r\x1b[2CX\x1b[2DY"}, "aYXdef"},
+		{"cursor to column", []string{"abcdef\x1b[3GX"}, "abXdef"},
+		{"cursor position", []string{"one\r\ntwo\x1b[1;2HX"}, "oXe\ntwo"},
+		{"cursor stops at the edges", []string{"x\x1b[5A\x1b[9DY"}, "Y"},
+		{"erase to end of line", []string{"abcdef\r\x1b[2C\x1b[K"}, "ab"},
+		{"erase to start of line", []string{"abcdef\r\x1b[2C\x1b[1K"}, "   def"},
+		{"erase line", []string{"abcdef\x1b[2K"}, ""},
+		{"erase below", []string{"one\r\ntwo\r\nthree\x1b[1A\r\x1b[J"}, "one"},
+		{"erase screen", []string{"one\r\ntwo\x1b[2J"}, ""},
+		{"private modes", []string{"\x1b[?25lab\x1b[?25h\x1b[?2004h"}, "ab"},
+		{"colors dropped", []string{"\x1b[1;36mcyan\x1b[0m"}, "cyan"},
+		{"other escapes dropped", []string{"a\x1b7b"}, "ab"},
+		{"wrap", []string{strings.Repeat("x", Width) + "y"}, strings.Repeat("x", Width) + "\ny"},
+		{"no wrap before the last column is written", []string{strings.Repeat("x", Width) + "\r"}, strings.Repeat("x", Width)},
+		{"wide runes", []string{"日本語"}, "日本語"},
+		{"wide runes take two columns", []string{"日本\r\x1b[2CX"}, "日X"},
+		{"wide rune wraps whole", []string{strings.Repeat("x", Width-1) + "日"}, strings.Repeat("x", Width-1) + "\n日"},
+		{"combining marks dropped", []string{"é!"}, "e!"},
+		{"joined emoji dropped", []string{"👩‍💻!"}, "👩!"},
+		{"split rune", []string{"\xe6\x97", "\xa5"}, "日"},
+		{"split escape", []string{"one\r\ntwo\x1b[", "1A\rX"}, "Xne\ntwo"},
+		{"scroll", []string{strings.Repeat("line\r\n", Height) + "last"}, strings.Repeat("line\n", Height-1) + "last"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newScreen(Width, Height)
+			for _, w := range tt.writes {
+				s.write([]byte(w))
+			}
+			if got := s.String(); got != tt.want {
+				t.Errorf("screen shows\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package prompts
+
+import "context"
+
+// Question is a prompt to be asked later, e.g. as part of a Form.
+type Question struct {
+	label string
+	// secret answers are not shown when reviewing a Form.
+	secret bool
+	opts   []Option
+	ask    func(ctx context.Context, opts []Option) (interface{}, error)
+}
+
+// InputQuestion returns a Question asked with Input. Its answer is a string.
+func InputQuestion(label string, opts ...Option) *Question {
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return InputContext(ctx, label, opts...)
+	}}
+}
+
+// AutocompleteQuestion returns a Question asked with Autocomplete.
+// Its answer is a string.
+func AutocompleteQuestion(label string, suggest func(input string) []string, opts ...Option) *Question {
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return AutocompleteContext(ctx, label, suggest, opts...)
+	}}
+}
+
+// FilePathQuestion returns a Question asked with FilePath.
+// Its answer is a string.
+func FilePathQuestion(label string, opts ...Option) *Question {
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return FilePathContext(ctx, label, opts...)
+	}}
+}
+
+// DirectoryTreeQuestion returns a Question asked with DirectoryTree.
+// Its answer is a string.
+func DirectoryTreeQuestion(label, root string, opts ...Option) *Question {
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return DirectoryTreeContext(ctx, label, root, opts...)
+	}}
+}
+
+// EditorQuestion returns a Question asked with Editor.
+// Its answer is a string.
+func EditorQuestion(label, initial string, opts ...Option) *Question {
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return EditorContext(ctx, label, initial, opts...)
+	}}
+}
+
+// MultilineQuestion returns a Question asked with Multiline.
+// Its answer is a string.
+func MultilineQuestion(label string, opts ...Option) *Question {
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return MultilineContext(ctx, label, opts...)
+	}}
+}
+
+// NumberQuestion returns a Question asked with Number.
+// Its answer is a float64.
+func NumberQuestion(label string, min, max, step float64, opts ...Option) *Question {
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return NumberContext(ctx, label, min, max, step, opts...)
+	}}
+}
+
+// SliderQuestion returns a Question asked with Slider.
+// Its answer is a float64.
+func SliderQuestion(label string, min, max float64, opts ...Option) *Question {
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return SliderContext(ctx, label, min, max, opts...)
+	}}
+}
+
+// DateQuestion returns a Question asked with Date.
+// Its answer is a time.Time.
+func DateQuestion(label string, opts ...Option) *Question {
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return DateContext(ctx, label, opts...)
+	}}
+}
+
+// DurationQuestion returns a Question asked with Duration.
+// Its answer is a time.Duration.
+func DurationQuestion(label string, opts ...Option) *Question {
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return DurationContext(ctx, label, opts...)
+	}}
+}
+
+// TimeQuestion returns a Question asked with Time.
+// Its answer is a TimeOfDay.
+func TimeQuestion(label string, opts ...Option) *Question {
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return TimeContext(ctx, label, opts...)
+	}}
+}
+
+// ConfirmQuestion returns a Question asked with Confirm. Its answer is a bool.
+func ConfirmQuestion(label string, def bool, opts ...Option) *Question {
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return ConfirmContext(ctx, label, def, opts...)
+	}}
+}
+
+// SelectQuestion returns a Question asked with Select.
+// Its answer is the picked option.
+func SelectQuestion(label string, options []string, opts ...Option) *Question {
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		s, _, err := SelectContext(ctx, label, options, opts...)
+		return s, err
+	}}
+}
+
+// CheckboxesQuestion returns a Question asked with Checkboxes.
+// Its answer is a []string of the picked options.
+func CheckboxesQuestion(label string, options []string, opts ...Option) *Question {
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return CheckboxesContext(ctx, label, options, opts...)
+	}}
+}
+
+// ReorderQuestion returns a Question asked with Reorder.
+// Its answer is a []string of the options in order.
+func ReorderQuestion(label string, options []string, opts ...Option) *Question {
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return ReorderContext(ctx, label, options, opts...)
+	}}
+}
+
+// TransferQuestion returns a Question asked with Transfer.
+// Its answer is a []string of the chosen options in order.
+func TransferQuestion(label string, options []string, opts ...Option) *Question {
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return TransferContext(ctx, label, options, opts...)
+	}}
+}
+
+// TreeSelectQuestion returns a Question asked with TreeSelect.
+// Its answer is a []string of the values of the checked leaves.
+func TreeSelectQuestion(label string, nodes []TreeNode, opts ...Option) *Question {
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return TreeSelectContext(ctx, label, nodes, opts...)
+	}}
+}
+
+// SelectTableQuestion returns a Question asked with SelectTable.
+// Its answer is a string, the Value of the picked row,
+// or its first cell if it has none.
+func SelectTableQuestion(label string, columns []string, rows []TableRow, opts ...Option) *Question {
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		row, err := SelectTableContext(ctx, label, columns, rows, opts...)
+		if err != nil || row.Value != "" || len(row.Cells) == 0 {
+			return row.Value, err
+		}
+		return row.Cells[0], nil
+	}}
+}
+
+// RatingQuestion returns a Question asked with Rating.
+// Its answer is an int.
+func RatingQuestion(label string, max int, opts ...Option) *Question {
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return RatingContext(ctx, label, max, opts...)
+	}}
+}
+
+// ToggleQuestion returns a Question asked with Toggle.
+// Its answer is a bool.
+func ToggleQuestion(label string, def bool, opts ...Option) *Question {
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return ToggleContext(ctx, label, def, opts...)
+	}}
+}
+
+// ConfirmPhraseQuestion returns a Question asked with ConfirmPhrase.
+// Its answer is a bool.
+func ConfirmPhraseQuestion(label, phrase string, opts ...Option) *Question {
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return ConfirmPhraseContext(ctx, label, phrase, opts...)
+	}}
+}
+
+// KeyValuesQuestion returns a Question asked with KeyValues.
+// Its answer is a map[string]string of the pairs.
+func KeyValuesQuestion(label string, opts ...Option) *Question {
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return KeyValuesContext(ctx, label, opts...)
+	}}
+}
+
+// TagsQuestion returns a Question asked with Tags.
+// Its answer is a []string of the tags.
+func TagsQuestion(label string, opts ...Option) *Question {
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return TagsContext(ctx, label, opts...)
+	}}
+}
+
+// PINQuestion returns a Question asked with PIN.
+// Its answer is a string.
+func PINQuestion(label string, length int, opts ...Option) *Question {
+	return &Question{label: label, secret: true, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return PINContext(ctx, label, length, opts...)
+	}}
+}
+
+// PasswordQuestion returns a Question asked with PasswordPrompt.
+// Its answer is a string.
+func PasswordQuestion(label string, opts ...Option) *Question {
+	return &Question{label: label, secret: true, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return PasswordPromptContext(ctx, label, opts...)
+	}}
+}
+
+// NewPasswordQuestion returns a Question asked with NewPassword.
+// Its answer is a string.
+func NewPasswordQuestion(label string, opts ...Option) *Question {
+	return &Question{label: label, secret: true, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		return NewPasswordContext(ctx, label, opts...)
+	}}
+}
+
+// asks reports whether q is to be asked after the answers,
+// with opts added to its options.
+func (q *Question) asks(answers Answers, opts []Option) bool {
+	c := newConfig("", append(opts[:len(opts):len(opts)], q.opts...))
+	return c.when == nil || c.when(answers)
+}
+
+// Ask asks the question on its own, with opts added to its options.
+func (q *Question) Ask(ctx context.Context, opts ...Option) (interface{}, error) {
+	return q.ask(ctx, append(opts[:len(opts):len(opts)], q.opts...))
+}
@@ -0,0 +1,178 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RatingOptions configures Rating.
+type RatingOptions struct {
+	// Default is the rating to begin with, if it is between 1 and the
+	// most stars. Otherwise the rating starts at 1.
+	Default int
+	// Full and Empty are the symbols of the stars given and not given.
+	// Empty means "★" and "☆", or "*" and "." when only ASCII symbols
+	// can be shown.
+	Full, Empty string
+}
+
+func (o RatingOptions) apply(c *config) { c.rating = o }
+
+// Rating asks for a rating from 1 to max stars using the label and
+// returns it. Left and Right, or Down and Up, take a star away and give
+// one more, Home and End give the fewest and the most, and the digit keys
+// give that many stars. Enter accepts the rating, provided it passes the
+// validators given with WithValidator.
+//
+// If the standard input is not a terminal, the next line read from it
+// must be a number from 1 to max, or empty for the default.
+//
+// It returns ErrInterrupted if the user presses Ctrl+C.
+func Rating(label string, max int, opts ...Option) (int, error) {
+	return RatingContext(context.Background(), label, max, opts...)
+}
+
+// RatingContext is like Rating but gives up when ctx is done,
+// returning ctx.Err().
+func RatingContext(ctx context.Context, label string, max int, opts ...Option) (int, error) {
+	c := newConfig(label, opts)
+	o := c.rating
+	if o.Full == "" || c.theme.ascii && !isASCII(o.Full) {
+		o.Full = "★"
+		if c.theme.ascii {
+			o.Full = "*"
+		}
+	}
+	if o.Empty == "" || c.theme.ascii && !isASCII(o.Empty) {
+		o.Empty = "☆"
+		if c.theme.ascii {
+			o.Empty = "."
+		}
+	}
+	if max < 1 {
+		max = 1
+	}
+	m := &ratingModel{
+		label:      label,
+		theme:      &c.theme,
+		keys:       &c.keymap,
+		opts:       o,
+		max:        max,
+		validators: c.validators,
+		value:      1,
+	}
+	if o.Default >= 1 && o.Default <= max {
+		m.value = o.Default
+	}
+	if n, ok := c.initial.(int); ok {
+		m.set(n)
+	} else if f, ok := c.initial.(float64); ok {
+		// Remembered answers are read back as JSON numbers.
+		m.set(int(f))
+	}
+	if err := run(ctx, c, m); err != nil {
+		return 0, err
+	}
+	return m.value, nil
+}
+
+type ratingModel struct {
+	label      string
+	theme      *Theme
+	keys       *Keymap
+	opts       RatingOptions
+	max        int
+	validators []Validator
+	value      int
+	// err is why the last answer was rejected.
+	err  error
+	done bool
+}
+
+// set makes n the rating, kept in range.
+func (m *ratingModel) set(n int) {
+	if n < 1 {
+		n = 1
+	}
+	if n > m.max {
+		n = m.max
+	}
+	m.value = n
+}
+
+func (m *ratingModel) result() interface{} {
+	return m.value
+}
+
+func (m *ratingModel) prompt() string {
+	return m.theme.label(m.label) + fmt.Sprintf(" (1-%d, %d) ", m.max, m.value)
+}
+
+func (m *ratingModel) answer(s string) (bool, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		s = strconv.Itoa(m.value)
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 || n > m.max {
+		return false, &invalidAnswer{err: errorf("must be a number from 1 to %d", m.max)}
+	}
+	if err := validate(m.validators, s); err != nil {
+		return false, err
+	}
+	m.value, m.done = n, true
+	return true, nil
+}
+
+func (m *ratingModel) rejected() error {
+	return m.err
+}
+
+func (m *ratingModel) update(k key) (bool, error) {
+	m.err = nil
+	switch {
+	case bound(m.keys.Submit, k):
+		m.err = validate(m.validators, strconv.Itoa(m.value))
+		m.done = m.err == nil
+	case bound(m.keys.Left, k) || bound(m.keys.Down, k):
+		m.set(m.value - 1)
+	case bound(m.keys.Right, k) || bound(m.keys.Up, k):
+		m.set(m.value + 1)
+	case bound(m.keys.Home, k):
+		m.set(1)
+	case bound(m.keys.End, k):
+		m.set(m.max)
+	case k.r >= '1' && k.r <= '9':
+		if n := int(k.r - '0'); n <= m.max {
+			m.value = n
+		} else {
+			m.err = errorf("at most %d stars", m.max)
+		}
+	}
+	return m.done, nil
+}
+
+func (m *ratingModel) keyHelp() ([]keyAction, bool) {
+	km := m.keys
+	return []keyAction{
+		act("give one star less or more", km.Left, km.Right),
+		act("give one star or all of them", km.Home, km.End),
+		note("type the number of stars"),
+		act("accept the rating", km.Submit),
+	}, false
+}
+
+func (m *ratingModel) view() frame {
+	t := m.theme
+	if m.done {
+		return frame{lines: []string{t.answered(m.label, strings.Repeat(m.opts.Full, m.value)+fmt.Sprintf(" (%d/%d)", m.value, m.max))}}
+	}
+	stars := t.render(t.Highlight, strings.Repeat(m.opts.Full+" ", m.value)) + strings.Repeat(m.opts.Empty+" ", m.max-m.value)
+	lines := []string{t.label(m.label) + " " + stars + t.render(t.Hint, fmt.Sprintf("%d/%d", m.value, m.max))}
+	if m.err != nil {
+		lines = append(lines, t.errorLine(m.err))
+	}
+	return frame{lines: lines}
+}
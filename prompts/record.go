@@ -0,0 +1,111 @@
+package prompts
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Recorder records the answers given to prompts, so that they can be
+// written to an answers file and replayed later with WithAnswersFile.
+// Passwords are never recorded.
+type Recorder struct {
+	mu      sync.Mutex
+	keys    []string
+	answers map[string]interface{}
+}
+
+// NewRecorder returns an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{answers: map[string]interface{}{}}
+}
+
+// WithRecorder makes a prompt record its answer in r under its key.
+func WithRecorder(r *Recorder) Option {
+	return optionFunc(func(c *config) { c.recorder = r })
+}
+
+// record sets the answer for key, keeping the position of an earlier one.
+func (r *Recorder) record(key string, answer interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.answers[key]; !ok {
+		r.keys = append(r.keys, key)
+	}
+	r.answers[key] = answer
+}
+
+// Answers returns the recorded answers by prompt key.
+func (r *Recorder) Answers() map[string]interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	answers := make(map[string]interface{}, len(r.answers))
+	for k, v := range r.answers {
+		answers[k] = v
+	}
+	return answers
+}
+
+// WriteFile writes the recorded answers to an answers file, in the order
+// they were given. The file is JSON if its name ends with ".json" and YAML
+// otherwise.
+func (r *Recorder) WriteFile(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var b []byte
+	var err error
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		b, err = marshalJSON(r.keys, r.answers)
+	} else {
+		b, err = r.marshalYAML()
+	}
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0600)
+}
+
+// marshalJSON encodes answers as a JSON object with the keys in order.
+func marshalJSON(keys []string, answers map[string]interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(answers[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString("\n  ")
+		buf.Write(key)
+		buf.WriteString(": ")
+		buf.Write(value)
+	}
+	buf.WriteString("\n}\n")
+	return buf.Bytes(), nil
+}
+
+func (r *Recorder) marshalYAML() ([]byte, error) {
+	doc := &yaml.Node{Kind: yaml.MappingNode}
+	for _, k := range r.keys {
+		var key, value yaml.Node
+		if err := key.Encode(k); err != nil {
+			return nil, err
+		}
+		if err := value.Encode(r.answers[k]); err != nil {
+			return nil, err
+		}
+		doc.Content = append(doc.Content, &key, &value)
+	}
+	return yaml.Marshal(doc)
+}
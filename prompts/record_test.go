@@ -0,0 +1,75 @@
+package prompts_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+)
+
+// recordAnswers answers a few prompts from lines, recording them in r.
+func recordAnswers(t *testing.T, r *prompts.Recorder) {
+	t.Helper()
+	in := prompts.WithInput(strings.NewReader("Gopher\ny\nGo,Rust\nhunter2\n"))
+	opts := []prompts.Option{in, prompts.WithOutput(&strings.Builder{}), prompts.WithRecorder(r)}
+	if _, err := prompts.Input("Name?", append(opts, prompts.WithKey("name"))...); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := prompts.Confirm("Continue?", false, append(opts, prompts.WithKey("continue"))...); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := prompts.Checkboxes("Languages?", []string{"Go", "Python", "Rust"}, append(opts, prompts.WithKey("languages"))...); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := prompts.PasswordPrompt("Password:", append(opts, prompts.WithKey("password"))...); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	r := prompts.NewRecorder()
+	recordAnswers(t, r)
+	want := map[string]interface{}{"name": "Gopher", "continue": true, "languages": []string{"Go", "Rust"}}
+	if got := r.Answers(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Answers() = %v, want %v", got, want)
+	}
+}
+
+func TestRecorderWriteFile(t *testing.T) {
+	tests := []struct {
+		name, want string
+	}{
+		{"answers.yaml", "name: Gopher\ncontinue: true\nlanguages:\n    - Go\n    - Rust\n"},
+		{"answers.json", "{\n  \"name\": \"Gopher\",\n  \"continue\": true,\n  \"languages\": [\"Go\",\"Rust\"]\n}\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := prompts.NewRecorder()
+			recordAnswers(t, r)
+			path := filepath.Join(t.TempDir(), tt.name)
+			if err := r.WriteFile(path); err != nil {
+				t.Fatal(err)
+			}
+			b, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.want {
+				t.Errorf("file holds %q, want %q", b, tt.want)
+			}
+			// The answers replay without reading any input.
+			file, in := prompts.WithAnswersFile(path), prompts.WithInput(strings.NewReader(""))
+			name, err := prompts.Input("Name?", prompts.WithKey("name"), file, in)
+			if err != nil || name != "Gopher" {
+				t.Errorf("replayed Input() = %q, %v, want \"Gopher\", nil", name, err)
+			}
+			langs, err := prompts.Checkboxes("Languages?", []string{"Go", "Python", "Rust"}, prompts.WithKey("languages"), file, in)
+			if err != nil || strings.Join(langs, ",") != "Go,Rust" {
+				t.Errorf("replayed Checkboxes() = %q, %v, want [Go Rust], nil", langs, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package prompts
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// RememberOptions configures WithRemember.
+type RememberOptions struct {
+	// Ignore keeps the answer remembered from being offered, as for
+	// a --no-cache flag, while the new answer is still remembered.
+	Ignore bool
+}
+
+// WithRemember makes a prompt remember its answer under its key, as set
+// by WithKey, and offer it the next time the program asks: as the default
+// of Input, shown next to its label, and as the answer other prompts
+// start from, such as the option under the cursor of Select. The answers
+// are kept in a file under $XDG_STATE_HOME/<program>/answers.json, or
+// ~/.local/state/<program>/answers.json if XDG_STATE_HOME is not set.
+// Passwords are never remembered.
+func WithRemember(o RememberOptions) Option {
+	return optionFunc(func(c *config) { c.remember = &o })
+}
+
+// rememberedMu serializes reading and writing the remembered answers.
+var rememberedMu sync.Mutex
+
+// recall makes the answer remembered for the prompt of c, if any, its
+// initial answer, unless it has one already.
+func recall(c *config) {
+	if c.remember == nil || c.remember.Ignore || c.initial != nil {
+		return
+	}
+	rememberedMu.Lock()
+	defer rememberedMu.Unlock()
+	if v, ok := loadRemembered()[c.key]; ok {
+		c.initial, c.remembered = rememberedValue(v), true
+	}
+}
+
+// remember saves answer as the one remembered for key. Errors saving it
+// are ignored, as remembered answers are only a convenience.
+func remember(key string, answer interface{}) {
+	rememberedMu.Lock()
+	defer rememberedMu.Unlock()
+	answers := loadRemembered()
+	answers[key] = answer
+	b, err := json.MarshalIndent(answers, "", "  ")
+	if err != nil {
+		return
+	}
+	name := stateFile("answers.json")
+	if err := os.MkdirAll(filepath.Dir(name), 0700); err != nil {
+		return
+	}
+	ioutil.WriteFile(name, append(b, '\n'), 0600)
+}
+
+// loadRemembered returns the remembered answers by key,
+// none if they cannot be read.
+func loadRemembered() map[string]interface{} {
+	answers := map[string]interface{}{}
+	if b, err := ioutil.ReadFile(stateFile("answers.json")); err == nil {
+		json.Unmarshal(b, &answers)
+	}
+	return answers
+}
+
+// rememberedValue converts an answer read back from JSON to the type
+// prompts take as their initial answer.
+func rememberedValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	case map[string]interface{}:
+		m := make(map[string]string, len(v))
+		for k, e := range v {
+			if s, ok := e.(string); ok {
+				m[k] = s
+			}
+		}
+		return m
+	}
+	return v
+}
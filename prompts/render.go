@@ -0,0 +1,77 @@
+package prompts
+
+import "io"
+
+// Frame is what an interactive prompt shows at a given moment.
+type Frame struct {
+	// Lines are the lines shown, which hold the escape sequences
+	// of the styles of the theme.
+	Lines []string
+	// CursorRow and CursorCol position the cursor within Lines.
+	// The cursor is hidden unless ShowCursor is set.
+	CursorRow, CursorCol int
+	ShowCursor           bool
+}
+
+// Renderer shows the frames of interactive prompts, such as on a terminal
+// or in the window of another UI. The state of the prompts is kept apart
+// from it, so any Renderer can show any prompt.
+type Renderer interface {
+	// Render shows f in place of the frame rendered last, if any.
+	Render(f Frame)
+	// Clear erases the frame rendered last, as when going back
+	// to the previous step of a Wizard.
+	Clear()
+	// Done is called when the prompt ends, leaving the frame rendered
+	// last shown for the next prompt to show below it.
+	Done()
+}
+
+// WithRenderer makes a prompt show its frames with r rather than draw them
+// on its output, from which it still reads keys. Answers read line by
+// line, when the input is not a terminal, are not rendered.
+func WithRenderer(r Renderer) Option {
+	return optionFunc(func(c *config) { c.renderer = r })
+}
+
+// NewScreenRenderer returns the Renderer that prompts use unless told
+// otherwise. It draws frames on the terminal w, which is width columns
+// wide, or of unknown width if width is zero, each frame replacing the
+// rows of the previous one.
+func NewScreenRenderer(w io.Writer, width int) Renderer {
+	return screenRenderer{newScreen(w, width)}
+}
+
+// renderer shows frames, as a screen or a Renderer does.
+type renderer interface {
+	draw(f frame)
+	clear()
+	done()
+	// resize is called when the terminal is resized to width columns.
+	resize(width int)
+}
+
+// customRenderer adapts a Renderer to the renderer interface.
+type customRenderer struct {
+	Renderer
+}
+
+func (r customRenderer) draw(f frame) {
+	r.Render(Frame{Lines: f.lines, CursorRow: f.cursorRow, CursorCol: f.cursorCol, ShowCursor: f.showCursor})
+}
+
+func (r customRenderer) clear()     { r.Clear() }
+func (r customRenderer) done()      { r.Done() }
+func (r customRenderer) resize(int) {}
+
+// screenRenderer adapts a screen to the Renderer interface.
+type screenRenderer struct {
+	s *screen
+}
+
+func (r screenRenderer) Render(f Frame) {
+	r.s.draw(frame{lines: f.Lines, cursorRow: f.CursorRow, cursorCol: f.CursorCol, showCursor: f.ShowCursor})
+}
+
+func (r screenRenderer) Clear() { r.s.clear() }
+func (r screenRenderer) Done()  { r.s.done() }
@@ -0,0 +1,207 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ReorderOptions configures Reorder.
+type ReorderOptions struct {
+	// PageSize is the number of options shown at once.
+	// Zero means the default page size.
+	PageSize int
+}
+
+func (o ReorderOptions) apply(c *config) { c.reorder = o }
+
+// Reorder asks to put the options in order using the label, e.g. to set
+// the priority of plugins, and returns them in that order. The cursor is
+// moved with the up and down arrows and Ctrl+Up and Ctrl+Down move the
+// option under it. Space picks the option up, so that the arrows move it,
+// and puts it down again. Enter accepts the order, provided it passes the
+// validators given with WithValidator, which are given the options joined
+// by commas.
+//
+// If the standard input is not a terminal, the next line read from it
+// must list options separated by commas, which are put first in that
+// order, before the others. An empty line keeps the order.
+//
+// It returns ErrNoOptions if options is empty and ErrInterrupted if the
+// user presses Ctrl+C.
+func Reorder(label string, options []string, opts ...Option) ([]string, error) {
+	return ReorderContext(context.Background(), label, options, opts...)
+}
+
+// ReorderContext is like Reorder but gives up when ctx is done,
+// returning ctx.Err().
+func ReorderContext(ctx context.Context, label string, options []string, opts ...Option) ([]string, error) {
+	c := newConfig(label, opts)
+	if len(options) == 0 {
+		return nil, ErrNoOptions
+	}
+	m := &reorderModel{
+		label:      label,
+		theme:      &c.theme,
+		keys:       &c.keymap,
+		validators: c.validators,
+		options:    append([]string{}, options...),
+		pageSize:   c.reorder.PageSize,
+	}
+	if m.pageSize <= 0 {
+		m.pageSize = defaultPageSize
+	}
+	m.maxPage = m.pageSize
+	if initial, ok := c.initial.([]string); ok {
+		if err := m.answerList(initial); err != nil {
+			return nil, err
+		}
+		m.done = false
+	}
+	if err := run(ctx, c, m); err != nil {
+		return nil, err
+	}
+	return m.options, nil
+}
+
+type reorderModel struct {
+	label      string
+	theme      *Theme
+	keys       *Keymap
+	validators []Validator
+	options    []string
+	cursor     int
+	top        int
+	pageSize   int
+	// maxPage is the page size configured, as for optionList.
+	maxPage int
+	// grabbed is set while the option under the cursor is picked up.
+	grabbed bool
+	// err is why the last answer was rejected.
+	err  error
+	done bool
+}
+
+// move moves the option under the cursor by dir places, if it can.
+func (m *reorderModel) move(dir int) {
+	i := m.cursor + dir
+	if i < 0 || i >= len(m.options) {
+		return
+	}
+	m.options[m.cursor], m.options[i] = m.options[i], m.options[m.cursor]
+	m.cursor = i
+}
+
+func (m *reorderModel) result() interface{} {
+	return m.options
+}
+
+func (m *reorderModel) prompt() string {
+	return m.theme.label(m.label) + " (" + strings.Join(m.options, ", ") + ") "
+}
+
+func (m *reorderModel) answer(s string) (bool, error) {
+	if strings.TrimSpace(s) == "" {
+		return true, m.answerList(nil)
+	}
+	var list []string
+	for _, o := range strings.Split(s, ",") {
+		if o = strings.TrimSpace(o); o != "" {
+			list = append(list, o)
+		}
+	}
+	return true, m.answerList(list)
+}
+
+func (m *reorderModel) answerList(list []string) error {
+	first := make([]bool, len(m.options))
+	var order []string
+	for _, o := range list {
+		i := findOption(m.options, o)
+		if i < 0 {
+			return notAnOption(o)
+		}
+		if !first[i] {
+			first[i] = true
+			order = append(order, m.options[i])
+		}
+	}
+	for i, o := range m.options {
+		if !first[i] {
+			order = append(order, o)
+		}
+	}
+	if err := validate(m.validators, strings.Join(order, ",")); err != nil {
+		return err
+	}
+	m.options, m.done = order, true
+	return nil
+}
+
+func (m *reorderModel) rejected() error {
+	return m.err
+}
+
+func (m *reorderModel) update(k key) (bool, error) {
+	n := len(m.options)
+	switch {
+	case bound(m.keys.Submit, k):
+		m.grabbed = false
+		m.err = validate(m.validators, strings.Join(m.options, ","))
+		m.done = m.err == nil
+		return m.done, nil
+	case bound(m.keys.Toggle, k):
+		m.grabbed = !m.grabbed
+	case bound(m.keys.MoveUp, k) || m.grabbed && bound(m.keys.Up, k):
+		m.move(-1)
+	case bound(m.keys.MoveDown, k) || m.grabbed && bound(m.keys.Down, k):
+		m.move(1)
+	case bound(m.keys.Up, k):
+		m.cursor = (m.cursor - 1 + n) % n
+	case bound(m.keys.Down, k):
+		m.cursor = (m.cursor + 1) % n
+	case bound(m.keys.PageUp, k) || bound(m.keys.PageDown, k):
+		m.cursor = pageMove(m.keys, k, m.cursor, m.pageSize, n)
+	default:
+		return false, nil
+	}
+	m.err = nil
+	m.top = scrollTop(m.top, m.cursor, m.pageSize)
+	return false, nil
+}
+
+func (m *reorderModel) keyHelp() ([]keyAction, bool) {
+	km := m.keys
+	return []keyAction{
+		act("move the cursor", km.Up, km.Down),
+		act("move by a page", km.PageUp, km.PageDown),
+		act("move the option up or down", km.MoveUp, km.MoveDown),
+		act("grab or drop the option, to move it with the cursor", km.Toggle),
+		act("accept the order", km.Submit),
+	}, false
+}
+
+func (m *reorderModel) resize(width, height int) {
+	m.pageSize = fitPage(m.maxPage, height, 1)
+	m.top = scrollTop(m.top, m.cursor, m.pageSize)
+}
+
+func (m *reorderModel) view() frame {
+	t := m.theme
+	if m.done {
+		return frame{lines: []string{t.answered(m.label, strings.Join(m.options, ", "))}}
+	}
+	width := len(fmt.Sprint(len(m.options)))
+	lines := []string{t.label(m.label)}
+	lines = append(lines, t.page(m.top, m.pageSize, len(m.options), func(i int) string {
+		s := t.option(m.options[i], fmt.Sprintf("%*d.", width, i+1), nil, i == m.cursor)
+		if i == m.cursor && m.grabbed {
+			s += "  " + t.render(t.Hint, t.text("(moving)"))
+		}
+		return s
+	})...)
+	if m.err != nil {
+		lines = append(lines, t.errorLine(m.err))
+	}
+	return frame{lines: lines}
+}
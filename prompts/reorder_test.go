@@ -0,0 +1,45 @@
+package prompts_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/prompttest"
+)
+
+func TestReorder(t *testing.T) {
+	tests := []struct {
+		name, keys, want string
+	}{
+		{"kept", prompttest.Enter, "lint,test,build"},
+		{"picked up and moved", prompttest.Space + prompttest.Down + prompttest.Space + prompttest.Enter, "test,lint,build"},
+		{"moved past the end", prompttest.Space + prompttest.Down + prompttest.Down + prompttest.Space + prompttest.Enter, "test,build,lint"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term := prompttest.New(t)
+			res := prompttest.Start(term, func(o prompts.Option) ([]string, error) {
+				return prompts.Reorder("Order?", []string{"lint", "test", "build"}, o)
+			})
+			term.WaitFor("build")
+			term.Send(tt.keys)
+			got, err := res.Wait()
+			if err != nil || strings.Join(got, ",") != tt.want {
+				t.Fatalf("Reorder() = %q, %v, want [%s], nil", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReorderLines(t *testing.T) {
+	var out strings.Builder
+	got, err := prompts.Reorder("Order?", []string{"lint", "test", "build"},
+		prompts.WithInput(strings.NewReader("deploy\nbuild, Test\n")), prompts.WithOutput(&out))
+	if err != nil || strings.Join(got, ",") != "build,test,lint" {
+		t.Fatalf("Reorder() = %q, %v, want [build test lint], nil", got, err)
+	}
+	if !strings.Contains(out.String(), "\"deploy\" is not one of the options\n") {
+		t.Errorf("output is %q, want it to say deploy is not an option", out.String())
+	}
+}
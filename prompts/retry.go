@@ -0,0 +1,44 @@
+package prompts
+
+// WithMaxRetries makes a prompt give up and return ErrTooManyAttempts once
+// n of the answers given to it have been rejected, e.g. by its validators,
+// instead of asking again and again.
+func WithMaxRetries(n int) Option {
+	return optionFunc(func(c *config) { c.maxRetries = n })
+}
+
+// rejecter is a model that can tell why its last answer was rejected.
+type rejecter interface {
+	rejected() error
+}
+
+// retryModel counts the answers a model rejects
+// and gives up once there are max of them.
+type retryModel struct {
+	model
+	rejecter rejecter
+	keys     *Keymap
+	max      int
+	attempts int
+}
+
+func (m *retryModel) update(k key) (bool, error) {
+	before := m.rejecter.rejected()
+	done, err := m.model.update(k)
+	if done || err != nil {
+		return done, err
+	}
+	// An answer is rejected when a rejection shows up, or submitting
+	// the answer again is rejected.
+	if after := m.rejecter.rejected(); after != nil && (before == nil || bound(m.keys.Submit, k)) {
+		if m.attempts++; m.attempts >= m.max {
+			return false, ErrTooManyAttempts
+		}
+	}
+	return false, nil
+}
+
+func (m *retryModel) captures(k key) bool {
+	c, ok := m.model.(keyCapturer)
+	return ok && c.captures(k)
+}
@@ -0,0 +1,86 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// WithReview makes a Form or Wizard show all the answers once they are
+// given and ask to submit them or to pick one to change first. Answers to
+// password questions are masked. There is no review when the standard
+// input is not a terminal.
+func WithReview() Option {
+	return optionFunc(func(c *config) { c.review = true })
+}
+
+// reviewSubmit is the option of the review that accepts the answers.
+const reviewSubmit = "Submit"
+
+// review lets the user change the answers of fields until submitting them,
+// if opts ask for it.
+func review(ctx context.Context, opts []Option, fields []formField, answers Answers) error {
+	c := newConfig("", opts)
+	if _, ok := terminalFile(c.reader()); !c.review || !c.tty && !ok && c.driver == nil {
+		return nil
+	}
+	submit := c.theme.text(reviewSubmit)
+	selectOpts := []Option{withInitial(submit)}
+	if c.locale != nil {
+		selectOpts = append(selectOpts, WithLocale(*c.locale))
+	}
+	if c.tty {
+		selectOpts = append(selectOpts, WithTTY())
+	}
+	if c.in != nil {
+		selectOpts = append(selectOpts, WithInput(c.in))
+	}
+	if c.out != nil {
+		selectOpts = append(selectOpts, WithOutput(c.out))
+	}
+	if c.driver != nil {
+		selectOpts = append(selectOpts, WithDriver(c.driver))
+	}
+	for {
+		var options []string
+		var answered []formField
+		for _, field := range fields {
+			if answer, ok := answers[field.name]; ok {
+				options = append(options, field.q.label+" "+formatAnswer(&c.theme, field.q, answer))
+				answered = append(answered, field)
+			}
+		}
+		options = append(options, submit)
+		_, i, err := SelectContext(ctx, c.theme.text("Review your answers:"), options, selectOpts...)
+		if err != nil {
+			return err
+		}
+		if i == len(answered) {
+			return nil
+		}
+		field := answered[i]
+		answer, err := field.q.Ask(ctx, append(fieldOptions(opts, field.name), withInitial(answers[field.name]))...)
+		if err != nil {
+			return err
+		}
+		answers[field.name] = answer
+		// The change may call for other questions to be asked or dropped.
+		if err := fill(ctx, opts, fields, answers); err != nil {
+			return err
+		}
+	}
+}
+
+// formatAnswer renders the answer to q for reviewing it.
+func formatAnswer(t *Theme, q *Question, answer interface{}) string {
+	if q.secret {
+		return "********"
+	}
+	switch a := answer.(type) {
+	case bool:
+		return t.yesNo(a)
+	case []string:
+		return strings.Join(a, ", ")
+	}
+	return fmt.Sprint(answer)
+}
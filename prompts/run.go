@@ -0,0 +1,465 @@
+package prompts
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// model is the state of an interactive prompt driven by key presses.
+type model interface {
+	// update handles a key press and reports whether the prompt is done.
+	update(k key) (done bool, err error)
+	// view renders the current state of the prompt.
+	view() frame
+}
+
+// lineModel is a model that can also take its answer from a line of text,
+// which is how prompts are answered when the standard input is not
+// a terminal, e.g. when answers are piped in by a script.
+type lineModel interface {
+	model
+	// prompt returns the text shown before reading a line.
+	prompt() string
+	// answer takes the answer from s. It reports false
+	// when another line should be read instead.
+	answer(s string) (done bool, err error)
+}
+
+// suspender is a model that hands the terminal over to something else,
+// such as an editor, after some key presses.
+type suspender interface {
+	// suspended returns what to run with the terminal out of raw mode
+	// before the next frame is drawn, or nil if there is nothing to run.
+	suspended() suspendFunc
+}
+
+// suspendFunc runs something on the terminal whose input and output are
+// in and out and reports whether the prompt is done.
+type suspendFunc func(in, out *os.File) (done bool, err error)
+
+// ticker is a model whose state changes while no key is pressed,
+// such as one waiting for its options to load.
+type ticker interface {
+	// tick is called about every pollInterval while no key is pressed and
+	// reports whether the view changed. An error ends the prompt.
+	tick() (changed bool, err error)
+}
+
+// A resizer is a model whose layout depends on the size of the terminal,
+// such as a list showing as many options as fit.
+type resizer interface {
+	// resize is called before the first frame is drawn and whenever
+	// the terminal is resized, with its size, or zeros if unknown.
+	resize(width, height int)
+}
+
+// eofModel is a lineModel that can take its answer
+// when the standard input ends.
+type eofModel interface {
+	lineModel
+	// eof reports whether the prompt is done with the lines read so far.
+	eof() (done bool, err error)
+}
+
+// run asks for the answer of m as configured by c
+// and records it if c has a Recorder.
+func run(ctx context.Context, c *config, m model) error {
+	err := ask(ctx, c, m)
+	if rm, ok := m.(resultModel); ok && err == nil {
+		if c.recorder != nil {
+			c.recorder.record(c.key, rm.result())
+		}
+		if c.remember != nil {
+			remember(c.key, rm.result())
+		}
+	}
+	return err
+}
+
+// ask puts the terminal in raw mode and drives m until it is done
+// or ctx is done. The terminal is restored before run returns, including
+// when the process receives SIGINT or SIGTERM, which makes ask return
+// ErrInterrupted, and when m panics.
+//
+// If the standard input, or the input given with WithInput, is not
+// a terminal, ask reads the answer from it line by line instead, or returns
+// ErrNotATerminal if m is not a lineModel. With WithTTY, it asks on the
+// controlling terminal instead, if there is one, and with WithDriver, it is
+// driven by the Driver without a terminal. Prompts on the standard input,
+// or on a terminal given with WithInput, go by Interactivity, asking a line
+// at a time on the terminals of sessions that are not interactive and on
+// the controlling terminal of forced ones.
+// Answers preset by c, e.g. in an environment variable, are taken without
+// asking at all, and none are asked for in the NoInput and DefaultInput
+// modes.
+func ask(ctx context.Context, c *config, m model) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if lm, ok := m.(lineModel); ok {
+		if done, err := presetAnswer(c, lm); done || err != nil {
+			return err
+		}
+	}
+	switch mode, _ := currentInputMode(); mode {
+	case NoInput:
+		return fmt.Errorf("%w for %q", ErrNoInput, c.key)
+	case DefaultInput:
+		return takeDefault(c, m)
+	}
+	if c.driver != nil {
+		return driveAsk(ctx, c, m)
+	}
+	in, isTerminal := terminalFile(c.reader())
+	if in == os.Stdin || isTerminal && in != c.terminal {
+		switch InteractivityOf(c.reader()).Mode {
+		case NonInteractive:
+			if !isTerminal {
+				break
+			}
+			// Dumb terminals and those of CI are asked a line at a time.
+			if _, ok := m.(lineModel); !ok {
+				return ErrNotATerminal
+			}
+			c.accessible = true
+		case ForcedInteractive:
+			c.tty = true
+		}
+	}
+	out := c.writer()
+	if !isTerminal {
+		err := ErrNotATerminal
+		var ttyIn, ttyOut *os.File
+		if c.tty {
+			ttyIn, ttyOut, err = openTTY()
+		}
+		if err != nil {
+			if lm, ok := m.(lineModel); ok {
+				c.theme.colors = detectColors(out)
+				if c.step != nil {
+					fmt.Fprintln(out, c.step.header(&c.theme))
+				}
+				return runLines(ctx, c, lm)
+			}
+			return ErrNotATerminal
+		}
+		defer ttyIn.Close()
+		if ttyOut != ttyIn {
+			defer ttyOut.Close()
+		}
+		in = ttyIn
+		if c.out == nil {
+			out = ttyOut
+		}
+	}
+	if lm, ok := m.(lineModel); ok && c.accessible {
+		c.theme.colors = detectColors(out)
+		if c.step != nil {
+			fmt.Fprintln(out, c.step.header(&c.theme))
+		}
+		return runAccessible(ctx, c, lm, in, out)
+	}
+	// Editors and the like run on the terminal of the output, or on that
+	// of the input if the output is not a file.
+	outFile, ok := out.(*os.File)
+	if !ok {
+		outFile = in
+	}
+	c.theme.colors = detectColors(out)
+	fd := int(in.Fd())
+	ctx, interrupted, stop := withInterrupt(ctx)
+	defer stop()
+
+	// Deferred calls run while panicking too, so the terminal
+	// is restored whichever way the prompt ends.
+	defer enableVirtualTerminal(in, outFile)()
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(fd, state)
+
+	size := func() (width, height int) {
+		width, height, _ = term.GetSize(int(outFile.Fd()))
+		return width, height
+	}
+	width, _ := size()
+	s := newScreen(out, width)
+	var erase int
+	if c.step != nil {
+		// Replace the output of the step the user went back to.
+		erase, c.step.erase = c.step.erase, 0
+	}
+	var r renderer = s
+	if c.renderer != nil {
+		r = customRenderer{c.renderer}
+	}
+	alt := c.altScreen && c.renderer == nil
+	if !alt {
+		s.cursorRow = erase
+	}
+	defer func() {
+		if err == errBack {
+			r.clear()
+			return
+		}
+		if c.step != nil {
+			c.step.rows += s.rows
+		}
+		r.done()
+	}()
+	if alt {
+		io.WriteString(out, altScreenOn)
+		defer func() {
+			// Draw the last frame again on the screen left, as if it had
+			// been drawn there all along.
+			lines := s.lines
+			io.WriteString(out, altScreenOff)
+			s.rows, s.cursorRow, s.lines, s.top = erase, erase, nil, 0
+			if err != errBack {
+				s.draw(frame{lines: lines})
+			}
+		}()
+	}
+	cr := &contextReader{ctx: ctx, f: in}
+	io.WriteString(out, pasteOn)
+	defer io.WriteString(out, pasteOff)
+	suspend := func(f suspendFunc) (bool, error) {
+		io.WriteString(out, pasteOff)
+		term.Restore(fd, state)
+		defer io.WriteString(out, pasteOn)
+		defer term.MakeRaw(fd)
+		return f(in, outFile)
+	}
+	if _, ok := m.(clicker); ok && !c.noMouse && c.renderer == nil {
+		io.WriteString(out, mouseOn)
+		defer io.WriteString(out, mouseOff)
+		s.locate = true
+	}
+	err = interact(c, m, r, cr, &cr.idle, suspend, size)
+	if err != nil && interrupted() {
+		return ErrInterrupted
+	}
+	return err
+}
+
+// interact drives m, wrapped as c asks, with the keys read from in until
+// it is done, drawing it with r. If m changes while no key is pressed, idle
+// is set to update it, for in to call. If size is not nil, it returns the
+// size of the terminal, and m is laid out again when that changes.
+func interact(c *config, m model, r renderer, in io.Reader, idle *func() error, suspend func(suspendFunc) (bool, error), size func() (width, height int)) error {
+	inner := m
+	var width, height int
+	rm, _ := inner.(resizer)
+	if size != nil {
+		width, height = size()
+		if rm != nil {
+			rm.resize(width, height)
+		}
+	}
+	tm, ticks := m.(ticker)
+	if s, ok := r.(*screen); ok && s.locate {
+		m = &mouseModel{model: m, clicker: inner.(clicker), screen: s, keys: &c.keymap}
+	}
+	if c.step != nil {
+		m = &stepModel{model: m, theme: &c.theme, keys: &c.keymap, step: c.step}
+	}
+	helper, _ := inner.(keyHelper)
+	m = &helpModel{model: m, helper: helper, theme: &c.theme, keys: &c.keymap, back: c.step != nil && c.step.back}
+	if rej, ok := inner.(rejecter); ok && c.maxRetries > 0 {
+		m = &retryModel{model: m, rejecter: rej, keys: &c.keymap, max: c.maxRetries}
+	}
+	if c.timeout > 0 {
+		t := newTimeoutModel(m, inner, &c.theme, c.timeout)
+		m, tm, ticks = t, t, true
+	}
+	if ticks || size != nil {
+		*idle = func() error {
+			var changed bool
+			if size != nil {
+				if w, h := size(); w != width || h != height {
+					width, height, changed = w, h, true
+					r.resize(w)
+					if rm != nil {
+						rm.resize(w, h)
+					}
+				}
+			}
+			var err error
+			if ticks {
+				var ticked bool
+				ticked, err = tm.tick()
+				changed = changed || ticked
+			}
+			if changed || err != nil {
+				r.draw(m.view())
+			}
+			return err
+		}
+	}
+	return loop(newKeyReader(in), r, m, &c.keymap, suspend)
+}
+
+// loop draws m and feeds it key presses until it is done
+// or a Cancel key of km is pressed. What a suspender model needs to run
+// is run by suspend. When a timeout expires, the first Submit key of km
+// is pressed instead of the user.
+func loop(kr *keyReader, r renderer, m model, km *Keymap, suspend func(suspendFunc) (bool, error)) error {
+	for {
+		r.draw(m.view())
+		k, err := kr.readKey()
+		if err == errTimedOut && len(km.Submit) > 0 {
+			k, err = key{name: km.Submit[0]}, nil
+		}
+		if err != nil {
+			return err
+		}
+		if bound(km.Cancel, k) {
+			return ErrInterrupted
+		}
+		done, err := m.update(k)
+		if err != nil {
+			// Show what led to the error.
+			r.draw(m.view())
+			return err
+		}
+		if sm, ok := m.(suspender); ok && !done {
+			if f := sm.suspended(); f != nil {
+				r.draw(m.view())
+				if done, err = suspend(f); err != nil {
+					return err
+				}
+			}
+		}
+		if done {
+			r.draw(m.view())
+			return nil
+		}
+	}
+}
+
+// takeDefault answers m as pressing Submit at once would.
+func takeDefault(c *config, m model) error {
+	if len(c.keymap.Submit) > 0 {
+		done, err := m.update(key{name: c.keymap.Submit[0]})
+		if err != nil || done {
+			return err
+		}
+	}
+	return fmt.Errorf("%w: no default answer for %q", ErrNoInput, c.key)
+}
+
+// runLines feeds m lines of the input of c until it is done, showing its
+// prompts on the output of c. With WithMaxRetries, it gives up with
+// ErrTooManyAttempts once m has rejected as many answers as allowed.
+func runLines(ctx context.Context, c *config, m lineModel) error {
+	lines, out, max := linesOf(c.reader()), c.writer(), c.maxRetries
+	for attempts := 0; ; {
+		fmt.Fprint(out, m.prompt())
+		s, err := lines.readLine(ctx)
+		fmt.Fprintln(out)
+		var done bool
+		if em, ok := m.(eofModel); ok && err == io.EOF {
+			done, err = em.eof()
+		} else if err != nil {
+			return err
+		} else {
+			done, err = m.answer(s)
+		}
+		var invalid *invalidAnswer
+		if errors.As(err, &invalid) {
+			fmt.Fprintln(out, invalid)
+			if attempts++; max > 0 && attempts >= max {
+				return ErrTooManyAttempts
+			}
+			continue
+		}
+		if err != nil || done {
+			return err
+		}
+	}
+}
+
+// stdinLines reads piped answers. It is shared by all prompts,
+// so that input buffered by one prompt is seen by the next one.
+var stdinLines lineReader
+
+// lineReader reads lines of an input.
+type lineReader struct {
+	cr contextReader
+	r  io.ByteReader
+}
+
+// linesOf returns a lineReader for r. Only the standard input is
+// buffered, by stdinLines; other inputs are read a byte at a time, unless
+// they are io.ByteReaders, so that prompts read no further than their
+// answers.
+func linesOf(r io.Reader) *lineReader {
+	if f, ok := r.(*os.File); ok && f == os.Stdin {
+		if stdinLines.r == nil {
+			stdinLines.cr.f = os.Stdin
+			stdinLines.r = bufio.NewReader(&stdinLines.cr)
+		}
+		return &stdinLines
+	}
+	lr := &lineReader{}
+	switch r := r.(type) {
+	case io.ByteReader:
+		lr.r = r
+	case *os.File:
+		lr.cr.f = r
+		lr.r = byteReader{&lr.cr}
+	default:
+		lr.r = byteReader{r}
+	}
+	return lr
+}
+
+// readLine returns the next line without its line ending. The last line
+// does not need one, but io.EOF is returned if there is no more input.
+func (lr *lineReader) readLine(ctx context.Context) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	lr.cr.ctx = ctx
+	var b []byte
+	for {
+		c, err := lr.r.ReadByte()
+		if err == io.EOF && len(b) > 0 {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if c == '\n' {
+			break
+		}
+		b = append(b, c)
+	}
+	return strings.TrimRight(string(b), "\r"), nil
+}
+
+// byteReader reads a byte at a time from an io.Reader.
+type byteReader struct {
+	r io.Reader
+}
+
+func (br byteReader) ReadByte() (byte, error) {
+	var b [1]byte
+	for {
+		n, err := br.r.Read(b[:])
+		if n == 1 {
+			return b[0], nil
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
@@ -0,0 +1,378 @@
+package prompts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	rules "github.com/tidalmigrations/interactive-cli-prompts/prompts/validate"
+)
+
+// AskSchema asks for a JSON document as described by a JSON Schema,
+// asking a question for every property of the object it describes, in
+// order, and returns the answers as a map, which encodes to the document
+// with encoding/json. The options apply to all the questions.
+//
+// The properties are labelled with their title, or their name, and show
+// their description as a placeholder when they are typed. Their default is
+// the default answer. Strings are asked with Input, or Select if they have
+// an enum, PasswordPrompt if their format is "password" or they are
+// writeOnly, and Date if their format is "date". Numbers and integers are
+// asked with Number, within their minimum and maximum, and booleans with
+// Confirm. Arrays of strings are asked with Checkboxes, picking at least
+// minItems and at most maxItems, if their items have an enum and with Tags
+// otherwise.
+// Objects are asked property by property, and nested in the answers.
+// Required properties must not be empty, minLength, maxLength, pattern
+// and the formats "email" and "uri" apply to strings, and optional strings
+// and numbers left empty are left out.
+//
+// With WithJSONOutput, the document is printed as well, without the
+// answers to password questions.
+//
+// The questions are keyed in answers files by the names of the properties
+// on the path to them, joined by dots, e.g. "server.port".
+func AskSchema(schema []byte, opts ...Option) (map[string]interface{}, error) {
+	return AskSchemaContext(context.Background(), schema, opts...)
+}
+
+// AskSchemaContext is like AskSchema but gives up when ctx is done,
+// returning ctx.Err().
+func AskSchemaContext(ctx context.Context, schema []byte, opts ...Option) (map[string]interface{}, error) {
+	var s jsonSchema
+	if err := json.Unmarshal(schema, &s); err != nil {
+		return nil, fmt.Errorf("prompts: reading JSON Schema: %w", err)
+	}
+	if t := s.typeName(); t != "object" {
+		return nil, fmt.Errorf("prompts: JSON Schema describes a %s, not an object", t)
+	}
+	// The form would print the answers flat, so they are printed here
+	// once nested instead.
+	printJSON := newConfig("", opts).printJSON
+	f := NewForm(append(opts, optionFunc(func(c *config) { c.printJSON = false }))...)
+	var fields []schemaField
+	if err := schemaQuestions(f, &s, nil, &fields); err != nil {
+		return nil, err
+	}
+	answers := map[string]interface{}{}
+	if err := f.RunContext(ctx, &answers); err != nil {
+		return nil, err
+	}
+	if printJSON {
+		b, err := json.MarshalIndent(nest(fields, answers, false), "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if _, err := fmt.Fprintf(os.Stdout, "%s\n", b); err != nil {
+			return nil, err
+		}
+	}
+	return nest(fields, answers, true), nil
+}
+
+// nest returns the document holding the answers to fields, with or
+// without the secret ones.
+func nest(fields []schemaField, answers map[string]interface{}, secrets bool) map[string]interface{} {
+	doc := map[string]interface{}{}
+	for _, field := range fields {
+		answer, ok := answers[strings.Join(field.path, ".")]
+		if !ok || !field.required && answer == "" || field.secret && !secrets {
+			continue
+		}
+		obj := doc
+		for _, name := range field.path[:len(field.path)-1] {
+			inner, ok := obj[name].(map[string]interface{})
+			if !ok {
+				inner = map[string]interface{}{}
+				obj[name] = inner
+			}
+			obj = inner
+		}
+		obj[field.path[len(field.path)-1]] = answer
+	}
+	return doc
+}
+
+// jsonSchema is the part of a JSON Schema that AskSchema understands.
+type jsonSchema struct {
+	Type        json.RawMessage  `json:"type"`
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	Format      string           `json:"format"`
+	Pattern     string           `json:"pattern"`
+	Enum        []interface{}    `json:"enum"`
+	Default     interface{}      `json:"default"`
+	Required    []string         `json:"required"`
+	Properties  []schemaProperty `json:"-"`
+	Items       *jsonSchema      `json:"items"`
+	Minimum     *float64         `json:"minimum"`
+	Maximum     *float64         `json:"maximum"`
+	MinLength   int              `json:"minLength"`
+	MaxLength   int              `json:"maxLength"`
+	MinItems    int              `json:"minItems"`
+	MaxItems    int              `json:"maxItems"`
+	WriteOnly   bool             `json:"writeOnly"`
+	RawProps    json.RawMessage  `json:"properties"`
+}
+
+type schemaProperty struct {
+	name   string
+	schema *jsonSchema
+}
+
+// schemaField is a property asked by a question of AskSchema.
+type schemaField struct {
+	path             []string
+	required, secret bool
+}
+
+func (s *jsonSchema) UnmarshalJSON(b []byte) error {
+	type plain jsonSchema
+	if err := json.Unmarshal(b, (*plain)(s)); err != nil {
+		return err
+	}
+	if len(s.RawProps) == 0 {
+		return nil
+	}
+	// Decode the properties one by one to keep their order.
+	d := json.NewDecoder(bytes.NewReader(s.RawProps))
+	if t, err := d.Token(); err != nil || t != json.Delim('{') {
+		return errors.New("properties must be an object")
+	}
+	for d.More() {
+		t, err := d.Token()
+		if err != nil {
+			return err
+		}
+		p := schemaProperty{name: t.(string), schema: &jsonSchema{}}
+		if err := d.Decode(p.schema); err != nil {
+			return err
+		}
+		s.Properties = append(s.Properties, p)
+	}
+	return nil
+}
+
+// typeName returns the type of the schema, the first one other than
+// "null" if it lists several, or "string" if it has none.
+func (s *jsonSchema) typeName() string {
+	var name string
+	if json.Unmarshal(s.Type, &name) == nil && name != "" {
+		return name
+	}
+	var names []string
+	json.Unmarshal(s.Type, &names)
+	for _, n := range names {
+		if n != "null" {
+			return n
+		}
+	}
+	if s.Properties != nil {
+		return "object"
+	}
+	return "string"
+}
+
+// schemaQuestions adds the questions for the properties of the object
+// schema s at path to f, and the fields they answer to fields.
+func schemaQuestions(f *Form, s *jsonSchema, path []string, fields *[]schemaField) error {
+	for _, p := range s.Properties {
+		path := append(path[:len(path):len(path)], p.name)
+		required := false
+		for _, r := range s.Required {
+			required = required || r == p.name
+		}
+		if p.schema.typeName() == "object" {
+			if err := schemaQuestions(f, p.schema, path, fields); err != nil {
+				return err
+			}
+			continue
+		}
+		q, err := schemaQuestion(p.schema, p.name, required)
+		if err != nil {
+			return fmt.Errorf("prompts: property %s: %w", strings.Join(path, "."), err)
+		}
+		f.Add(strings.Join(path, "."), q)
+		*fields = append(*fields, schemaField{path: path, required: required, secret: q.secret})
+	}
+	return nil
+}
+
+// schemaQuestion returns the question for the property name
+// described by s.
+func schemaQuestion(s *jsonSchema, name string, required bool) (*Question, error) {
+	label := s.Title
+	if label == "" {
+		label = name
+	}
+	var opts []Option
+	if required {
+		opts = append(opts, WithValidator(rules.Required()))
+	}
+	if s.Enum != nil {
+		return enumQuestion(label, s.Enum, s.Default, opts), nil
+	}
+	def := ""
+	if s.Default != nil {
+		def = fmt.Sprint(s.Default)
+	}
+	input := InputOptions{Default: def, Placeholder: s.Description}
+	switch t := s.typeName(); t {
+	case "string":
+		// Optional strings can be left empty whatever they must be
+		// when given.
+		check := func(v Validator) {
+			if !required {
+				v = allowEmpty(v)
+			}
+			opts = append(opts, WithValidator(v))
+		}
+		if s.MinLength > 0 {
+			check(rules.MinLength(s.MinLength))
+		}
+		if s.MaxLength > 0 {
+			check(rules.MaxLength(s.MaxLength))
+		}
+		switch s.Format {
+		case "email":
+			check(rules.Email())
+		case "uri", "url":
+			check(rules.URL())
+		}
+		if s.Pattern != "" {
+			re, err := regexp.Compile(s.Pattern)
+			if err != nil {
+				return nil, err
+			}
+			check(rules.Regexp(re, ""))
+		}
+		switch {
+		case s.Format == "password" || s.WriteOnly:
+			return PasswordQuestion(label, append(opts, PasswordOptions{AllowEmpty: !required})...), nil
+		case s.Format == "date":
+			if def != "" {
+				d, err := parseDate(def)
+				if err != nil {
+					return nil, err
+				}
+				opts = append(opts, DateOptions{Default: d})
+			}
+			return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+				d, err := DateContext(ctx, label, opts...)
+				return d.Format(dateLayout), err
+			}}, nil
+		}
+		return InputQuestion(label, append(opts, input)...), nil
+	case "number", "integer":
+		min, max := math.Inf(-1), math.Inf(1)
+		if s.Minimum != nil {
+			min = *s.Minimum
+		}
+		if s.Maximum != nil {
+			max = *s.Maximum
+		}
+		opts = append(opts, input)
+		if t == "integer" {
+			opts = append(opts, WithValidator(wholeNumber))
+		}
+		// Number takes no empty answer, so optional numbers
+		// without a default are typed with Input.
+		optional := !required && def == ""
+		if optional {
+			opts = append(opts, WithValidator(optionalNumber(min, max)))
+		}
+		return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+			var f float64
+			if optional {
+				s, err := InputContext(ctx, label, opts...)
+				if err != nil || strings.TrimSpace(s) == "" {
+					return "", err
+				}
+				f, _ = strconv.ParseFloat(strings.TrimSpace(s), 64)
+			} else {
+				var err error
+				if f, err = NumberContext(ctx, label, min, max, 1, opts...); err != nil {
+					return nil, err
+				}
+			}
+			if t == "integer" {
+				return int64(f), nil
+			}
+			return f, nil
+		}}, nil
+	case "boolean":
+		b, _ := s.Default.(bool)
+		return ConfirmQuestion(label, b, opts...), nil
+	case "array":
+		if s.Items == nil || s.Items.typeName() != "string" {
+			return nil, errors.New("only arrays of strings can be asked for")
+		}
+		var def []string
+		if list, ok := s.Default.([]interface{}); ok {
+			for _, v := range list {
+				def = append(def, fmt.Sprint(v))
+			}
+		}
+		if s.Items.Enum != nil {
+			options := make([]string, len(s.Items.Enum))
+			for i, v := range s.Items.Enum {
+				options[i] = fmt.Sprint(v)
+			}
+			opts = append(opts, CheckboxesOptions{Default: def, MinSelected: s.MinItems, MaxSelected: s.MaxItems})
+			return CheckboxesQuestion(label, options, opts...), nil
+		}
+		return TagsQuestion(label, append(opts, TagsOptions{Default: def})...), nil
+	default:
+		return nil, fmt.Errorf("cannot ask for a %s", t)
+	}
+}
+
+// enumQuestion returns a question picking one of the values of enum,
+// which it answers with the value picked.
+func enumQuestion(label string, enum []interface{}, def interface{}, opts []Option) *Question {
+	options := make([]string, len(enum))
+	for i, v := range enum {
+		options[i] = fmt.Sprint(v)
+	}
+	if def != nil {
+		opts = append(opts, withInitial(fmt.Sprint(def)))
+	}
+	return &Question{label: label, opts: opts, ask: func(ctx context.Context, opts []Option) (interface{}, error) {
+		_, i, err := SelectContext(ctx, label, options, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return enum[i], nil
+	}}
+}
+
+// optionalNumber rejects answers that are neither empty
+// nor numbers between min and max.
+func optionalNumber(min, max float64) Validator {
+	return allowEmpty(numberRange(min, max))
+}
+
+// allowEmpty returns v letting empty answers through.
+func allowEmpty(v Validator) Validator {
+	return func(s string) error {
+		if strings.TrimSpace(s) == "" {
+			return nil
+		}
+		return v(s)
+	}
+}
+
+// wholeNumber rejects numbers with decimals.
+func wholeNumber(s string) error {
+	if strings.ContainsAny(strings.TrimSpace(s), ".eE") {
+		return errorf("must be a whole number")
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package prompts_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/prompttest"
+)
+
+const serviceSchema = `{
+	"type": "object",
+	"required": ["name"],
+	"properties": {
+		"name": {"type": "string", "title": "Service name", "maxLength": 10},
+		"owner": {"type": "string"},
+		"server": {
+			"type": "object",
+			"properties": {
+				"port": {"type": "integer", "minimum": 1, "maximum": 65535, "default": 8080},
+				"tls": {"type": "boolean"}
+			}
+		},
+		"languages": {"type": "array", "items": {"type": "string", "enum": ["Go", "Python", "Rust"]}}
+	}
+}`
+
+func TestAskSchema(t *testing.T) {
+	path := writeAnswers(t, "answers.yaml", "name: billing\nowner: \"\"\nserver.port: 9090\nserver.tls: yes\nlanguages: [Go, Rust]\n")
+	got, err := prompts.AskSchema([]byte(serviceSchema), prompts.WithAnswersFile(path), prompts.WithInput(strings.NewReader("")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The optional owner left empty is left out.
+	if want := `{"languages":["Go","Rust"],"name":"billing","server":{"port":9090,"tls":true}}`; string(b) != want {
+		t.Errorf("AskSchema() encodes to %s, want %s", b, want)
+	}
+}
+
+func TestAskSchemaTerminal(t *testing.T) {
+	term := prompttest.New(t)
+	res := prompttest.Start(term, func(o prompts.Option) (map[string]interface{}, error) {
+		return prompts.AskSchema([]byte(`{
+			"type": "object",
+			"required": ["name"],
+			"properties": {
+				"name": {"type": "string", "title": "Service name"},
+				"public": {"type": "boolean", "title": "Public?"}
+			}
+		}`), o)
+	})
+	term.WaitFor("Service name")
+	term.Send(prompttest.Enter)
+	term.WaitFor("a value is required")
+	term.Send("billing" + prompttest.Enter)
+	term.WaitFor("Public?")
+	term.Send("y" + prompttest.Enter)
+	got, err := res.Wait()
+	if err != nil || got["name"] != "billing" || got["public"] != true {
+		t.Fatalf("AskSchema() = %v, %v, want map[name:billing public:true], nil", got, err)
+	}
+}
+
+func TestAskSchemaInvalid(t *testing.T) {
+	path := writeAnswers(t, "answers.yaml", "name: accounts-receivable\n")
+	_, err := prompts.AskSchema([]byte(serviceSchema), prompts.WithAnswersFile(path), prompts.WithInput(strings.NewReader("")))
+	if err == nil || !strings.Contains(err.Error(), "invalid answer in "+path) {
+		t.Fatalf("AskSchema() returned %v, want an invalid answer", err)
+	}
+}
+
+func TestAskSchemaNotAnObject(t *testing.T) {
+	_, err := prompts.AskSchema([]byte(`{"type": "string"}`))
+	if want := "prompts: JSON Schema describes a string, not an object"; err == nil || err.Error() != want {
+		t.Fatalf("AskSchema() returned %v, want %s", err, want)
+	}
+}
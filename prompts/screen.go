@@ -0,0 +1,267 @@
+package prompts
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+// frame is what a prompt shows on the screen at a given moment.
+type frame struct {
+	lines []string
+	// cursorRow and cursorCol position the cursor within lines.
+	// The cursor is hidden unless showCursor is set.
+	cursorRow, cursorCol int
+	showCursor           bool
+}
+
+// screen draws frames on a terminal, each one replacing the previous one.
+// Only the lines that changed since the previous frame are redrawn, and
+// each frame is written at once, so that slow connections do not flicker.
+type screen struct {
+	w io.Writer
+	// width is the terminal width in columns, or zero if unknown.
+	width int
+	// rows is the number of terminal rows the last frame occupies and
+	// cursorRow the row of the cursor within them.
+	rows, cursorRow int
+	// col is the column of the cursor within the line of the last frame
+	// it is on, before wrapping, as passed to advance.
+	col int
+	// lines are the lines of the last frame and starts the rows they
+	// start at, or nil if the frame on the screen is unknown.
+	lines  []string
+	starts []int
+	// locate is set to ask the terminal where each frame is, so that
+	// mouse clicks can be placed on it. queries holds the rows the cursor
+	// was on when asked, for the replies still to come, and top is the
+	// screen row, counted from 1, of the first row of the frame, or zero
+	// if unknown.
+	locate  bool
+	queries []int
+	top     int
+}
+
+func newScreen(w io.Writer, width int) *screen {
+	return &screen{w: w, width: width}
+}
+
+// draw replaces the previously drawn frame with f.
+func (s *screen) draw(f frame) {
+	var b strings.Builder
+	b.WriteString("\x1b[?25l")
+	moved := s.lines == nil
+	if s.lines == nil {
+		// Start from a blank area, erasing what the rows hold.
+		s.rewind(&b)
+		b.WriteString("\x1b[J")
+		s.rows, s.cursorRow = 0, 0
+	}
+	starts := make([]int, len(f.lines))
+	rows := 0
+	for i, l := range f.lines {
+		starts[i] = rows
+		row, _ := advance(l, textWidth(l), s.width)
+		rows += row + 1
+	}
+	for i, l := range f.lines {
+		if i < len(s.lines) && s.lines[i] == l && s.starts[i] == starts[i] {
+			continue
+		}
+		moved = true
+		s.moveTo(&b, starts[i])
+		b.WriteString(l)
+		// Erase what is left of the old line, unless the new one fills
+		// its last row, where the cursor waits to wrap.
+		row, col := advance(l, textWidth(l), s.width)
+		if s.width <= 0 || col != s.width {
+			b.WriteString("\x1b[K")
+		}
+		s.cursorRow = starts[i] + row
+		s.col = textWidth(l)
+		if s.cursorRow >= s.rows {
+			s.rows = s.cursorRow + 1
+		}
+	}
+	if rows < s.rows {
+		moved = true
+		s.moveTo(&b, rows)
+		b.WriteString("\x1b[J")
+		if rows > 0 {
+			s.moveTo(&b, rows-1)
+		}
+	}
+	s.rows = rows
+	s.lines, s.starts = append(s.lines[:0], f.lines...), starts
+	if f.showCursor {
+		row, col := advance(f.lines[f.cursorRow], f.cursorCol, s.width)
+		if col == s.width {
+			row, col = row+1, 0
+		}
+		s.moveTo(&b, starts[f.cursorRow]+row)
+		if col > 0 {
+			fmt.Fprintf(&b, "\x1b[%dC", col)
+		}
+		s.col = f.cursorCol
+		b.WriteString("\x1b[?25h")
+	}
+	if s.locate && moved {
+		// Writing may have scrolled the screen, moving the frame.
+		b.WriteString("\x1b[6n")
+		s.queries = append(s.queries, s.cursorRow)
+	}
+	io.WriteString(s.w, b.String())
+}
+
+// located takes the reply of the terminal to the oldest query of where
+// the cursor is: it was on the screen row y, counted from 1.
+func (s *screen) located(y int) {
+	if len(s.queries) == 0 {
+		return
+	}
+	s.top = y - s.queries[0]
+	s.queries = s.queries[1:]
+}
+
+// lineAt returns the index of the line of the last frame shown on the
+// screen row y, counted from 1, or -1 if there is none or it is unknown.
+func (s *screen) lineAt(y int) int {
+	if s.top <= 0 || s.lines == nil {
+		return -1
+	}
+	row := y - s.top
+	if row < 0 || row >= s.rows {
+		return -1
+	}
+	line := -1
+	for i, start := range s.starts {
+		if start <= row {
+			line = i
+		}
+	}
+	return line
+}
+
+// moveTo moves the cursor to the start of the row of the frame,
+// adding rows below the last one if needed.
+func (s *screen) moveTo(b *strings.Builder, row int) {
+	if row < s.cursorRow {
+		fmt.Fprintf(b, "\x1b[%dA", s.cursorRow-row)
+	}
+	if last := s.rows - 1; row > s.cursorRow && s.cursorRow < last {
+		to := row
+		if to > last {
+			to = last
+		}
+		fmt.Fprintf(b, "\x1b[%dB", to-s.cursorRow)
+		s.cursorRow = to
+	}
+	for ; s.cursorRow < row; s.cursorRow++ {
+		b.WriteString("\r\n")
+	}
+	b.WriteString("\r")
+	s.cursorRow, s.col = row, 0
+}
+
+// resize makes s draw for a terminal width columns wide. Terminals rewrap
+// the lines on the screen when their width changes, so the last frame is
+// taken to be rewrapped, and erased from where it then starts when the
+// next frame is drawn.
+func (s *screen) resize(width int) {
+	if s.lines != nil {
+		line := 0
+		for i, start := range s.starts {
+			if start <= s.cursorRow {
+				line = i
+			}
+		}
+		row := 0
+		for _, l := range s.lines[:line] {
+			r, _ := advance(l, textWidth(l), width)
+			row += r + 1
+		}
+		r, _ := advance(s.lines[line], s.col, width)
+		s.cursorRow = row + r
+		s.lines = nil
+	}
+	s.width = width
+}
+
+// done moves the cursor below the last frame, leaving it on the screen.
+func (s *screen) done() {
+	var b strings.Builder
+	if down := s.rows - 1 - s.cursorRow; down > 0 {
+		fmt.Fprintf(&b, "\x1b[%dB", down)
+	}
+	b.WriteString("\r\n\x1b[?25h")
+	io.WriteString(s.w, b.String())
+	s.rows, s.cursorRow, s.lines = 0, 0, nil
+}
+
+// clear erases the last frame, leaving the cursor where it started.
+func (s *screen) clear() {
+	var b strings.Builder
+	s.rewind(&b)
+	b.WriteString("\x1b[J\x1b[?25h")
+	io.WriteString(s.w, b.String())
+	s.rows, s.cursorRow, s.lines = 0, 0, nil
+}
+
+// rewind moves the cursor to the first row of the last frame.
+func (s *screen) rewind(b *strings.Builder) {
+	if s.cursorRow > 0 {
+		fmt.Fprintf(b, "\x1b[%dA", s.cursorRow)
+	}
+	b.WriteString("\r")
+}
+
+// advance returns the row, below the first, and the column the cursor
+// ends up on after writing the first col columns of the line s, followed
+// by spaces if it is shorter, on a terminal width columns wide. Terminals
+// move wide characters that do not fit at the end of a row to the next
+// one, and keep the cursor past the last column of a full row until the
+// next character is written, where the column returned is width.
+// Nothing wraps if width is unknown.
+func advance(s string, col, width int) (row, c int) {
+	if width <= 0 {
+		return 0, col
+	}
+	x := 0
+	put := func(w int) {
+		if c+w > width {
+			row, c = row+1, 0
+		}
+		c += w
+		x += w
+	}
+	g := uniseg.NewGraphemes(ansiEscape.ReplaceAllString(s, ""))
+	for g.Next() {
+		w := runewidth.StringWidth(g.Str())
+		if x+w > col {
+			break
+		}
+		put(w)
+	}
+	for x < col {
+		put(1)
+	}
+	return row, c
+}
+
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;?]*[A-Za-z]")
+
+// textWidth returns the number of columns s takes on the screen, where
+// East Asian wide characters take two and combining marks none.
+func textWidth(s string) int {
+	// Most text is printable ASCII, one column a byte.
+	for i := 0; i < len(s); i++ {
+		if s[i] < ' ' || s[i] > '~' {
+			return runewidth.StringWidth(ansiEscape.ReplaceAllString(s, ""))
+		}
+	}
+	return len(s)
+}
@@ -0,0 +1,265 @@
+package prompts
+
+import (
+	"context"
+	"strings"
+)
+
+// SelectOptions configures Select.
+type SelectOptions struct {
+	// PageSize is the number of options shown at once.
+	// Zero means the default page size.
+	PageSize int
+	// Default is the option the cursor starts on, such as the previous
+	// answer, rather than the first one. It is ignored if it is not one of
+	// the options, and an answer remembered with WithRemember wins over it.
+	Default string
+	// Descriptions maps options to short descriptions shown next to them
+	// and Help to longer help shown for the option under the cursor
+	// when the Help key is pressed.
+	Descriptions, Help map[string]string
+	// Disabled maps the options that are shown but cannot be picked to
+	// why not, which is shown next to them if it is not empty.
+	Disabled map[string]string
+	// Groups maps options to the name of the group they are listed under,
+	// after the options in no group. The header of a group is collapsed
+	// and expanded with Left and Right or Enter.
+	Groups map[string]string
+	// Preview, if set, returns a preview of the option under the cursor,
+	// such as the contents of a file, which is shown below the list in at
+	// most PreviewHeight lines, or 10 if it is zero. It is called once for
+	// each option previewed.
+	Preview       func(option string) string
+	PreviewHeight int
+	// Columns is the most columns options are laid out in, across and then
+	// down, when there are more than fit on a page and they are short
+	// enough for several to fit side by side on the terminal. Left and
+	// Right move between the columns. Zero means as many as fit and one
+	// keeps the options in a column, as they are with Groups, Descriptions
+	// or a Preview.
+	Columns int
+}
+
+func (o SelectOptions) apply(c *config) { c.selectOptions = o }
+
+// Select asks to pick one of the options using the label and returns
+// the picked option with its index. The cursor starts on the first option,
+// or on the Default of SelectOptions, and is moved with the up and
+// down arrows, wrapping around at either end, and by a page with PageUp
+// and PageDown, and Enter picks the option under it, provided it passes
+// the validators given with WithValidator.
+// Typing filters the options down to those containing the typed characters
+// in order, best matches first, with the matched characters highlighted.
+// Backspace deletes the last typed character and Esc or Ctrl+U clears them.
+// ? or F1 shows the keys, with the help SelectOptions gives the option
+// under the cursor, if any.
+//
+// If the standard input is not a terminal, the next line read from it
+// must name one of the options.
+//
+// It returns ErrNoOptions if options is empty and ErrInterrupted if the
+// user presses Ctrl+C.
+func Select(label string, options []string, opts ...Option) (string, int, error) {
+	return SelectContext(context.Background(), label, options, opts...)
+}
+
+// SelectContext is like Select but gives up when ctx is done,
+// returning ctx.Err().
+func SelectContext(ctx context.Context, label string, options []string, opts ...Option) (string, int, error) {
+	c := newConfig(label, opts)
+	if len(options) == 0 {
+		return "", -1, ErrNoOptions
+	}
+	m := newSelectModel(c, label, options)
+	if err := run(ctx, c, m); err != nil {
+		return "", -1, err
+	}
+	return options[m.picked], m.picked, nil
+}
+
+func newSelectModel(c *config, label string, options []string) *selectModel {
+	m := &selectModel{
+		label:      label,
+		theme:      &c.theme,
+		keys:       &c.keymap,
+		list:       newOptionList(&c.theme, &c.keymap, options, c.selectOptions.PageSize),
+		validators: c.validators,
+		numbered:   c.accessible,
+	}
+	m.list.descriptions, m.list.help = c.selectOptions.Descriptions, c.selectOptions.Help
+	m.list.disabled = c.selectOptions.Disabled
+	m.list.preview, m.list.previewHeight = c.selectOptions.Preview, c.selectOptions.PreviewHeight
+	m.list.maxColumns = c.selectOptions.Columns
+	m.list.filter.matcher = c.matcher
+	m.list.init(c.selectOptions.Groups)
+	if s := c.initialOption(); s != "" {
+		if i := findOption(options, s); i >= 0 {
+			m.list.moveTo(i)
+		}
+	}
+	return m
+}
+
+// initialOption returns the option Select starts on, if not the first.
+func (c *config) initialOption() string {
+	if s, ok := c.initial.(string); ok {
+		return s
+	}
+	return c.selectOptions.Default
+}
+
+type selectModel struct {
+	label      string
+	theme      *Theme
+	keys       *Keymap
+	list       optionList
+	validators []Validator
+	// numbered is set to list the options numbered before asking
+	// for an answer as a line, as accessible prompts do.
+	numbered bool
+	// initial is the option to put the cursor on once it is streamed,
+	// unless a key is pressed first.
+	initial string
+	// picked is the index of the picked option.
+	picked int
+	// err is why the last answer was rejected.
+	err  error
+	done bool
+}
+
+func (m *selectModel) result() interface{} {
+	return m.list.options[m.picked]
+}
+
+func (m *selectModel) resize(width, height int) {
+	m.list.resize(width, height, 1)
+}
+
+func (m *selectModel) defaultAnswer() string {
+	if i := m.list.current(); i >= 0 {
+		return m.list.options[i]
+	}
+	return ""
+}
+
+func (m *selectModel) prompt() string {
+	m.list.drain()
+	if m.numbered {
+		n := len(m.list.options)
+		return m.theme.label(m.label) + "\n" + numberedOptions(m.theme, m.list.options, m.list.disabled) +
+			m.theme.render(m.theme.Hint, m.theme.textf("Type a number from 1 to %d:", n)) + " "
+	}
+	return m.theme.label(m.label) + " "
+}
+
+func (m *selectModel) answer(s string) (bool, error) {
+	if m.list.drain(); len(m.list.options) == 0 {
+		return false, ErrNoOptions
+	}
+	i := findOption(m.list.options, s)
+	if i < 0 && m.numbered {
+		i = optionNumber(s, len(m.list.options))
+	}
+	if i < 0 {
+		return false, notAnOption(s)
+	}
+	if err := m.list.disabledReason(m.list.options[i]); err != nil {
+		return false, err
+	}
+	if err := validate(m.validators, m.list.options[i]); err != nil {
+		return false, err
+	}
+	m.picked, m.done = i, true
+	return true, nil
+}
+
+func (m *selectModel) captures(k key) bool {
+	return m.list.captures(k)
+}
+
+func (m *selectModel) rejected() error {
+	return m.err
+}
+
+func (m *selectModel) update(k key) (bool, error) {
+	m.initial = ""
+	if bound(m.keys.Submit, k) && !m.list.toggleGroup() {
+		if i := m.list.current(); i >= 0 {
+			m.picked = i
+			m.err = validate(m.validators, m.list.options[i])
+			m.done = m.err == nil
+		}
+		return m.done, nil
+	}
+	if m.list.update(k) {
+		m.err = nil
+	}
+	return false, nil
+}
+
+// tick adds the options streamed since it was last called, failing with
+// ErrNoOptions if none are by the end.
+func (m *selectModel) tick() (bool, error) {
+	from := len(m.list.options)
+	changed := m.list.tick()
+	if m.initial != "" {
+		if i := findOption(m.list.options[from:], m.initial); i >= 0 {
+			m.list.moveTo(from + i)
+			m.initial = ""
+		}
+	}
+	if m.list.stream == nil && len(m.list.options) == 0 {
+		return true, ErrNoOptions
+	}
+	return changed, nil
+}
+
+func (m *selectModel) optionHelp() []string {
+	return m.list.optionHelp()
+}
+
+func (m *selectModel) keyHelp() ([]keyAction, bool) {
+	return m.list.keyActions(act("pick the option", m.keys.Submit)), false
+}
+
+func (m *selectModel) click(line, col int) (bool, error) {
+	m.initial = ""
+	if m.list.click(line, col) {
+		m.err = nil
+	}
+	return false, nil
+}
+
+func (m *selectModel) view() frame {
+	if m.done {
+		return frame{lines: []string{m.theme.answered(m.label, m.list.options[m.picked])}}
+	}
+	lines := m.list.lines(m.label, func(int) string { return "" })
+	if m.err != nil {
+		lines = append(lines, m.theme.errorLine(m.err))
+	}
+	return frame{lines: lines}
+}
+
+// findOption returns the index of the option s, preferring an exact match
+// over a case-insensitive one, or -1 if there is none.
+func findOption(options []string, s string) int {
+	s = strings.TrimSpace(s)
+	for i, o := range options {
+		if o == s {
+			return i
+		}
+	}
+	for i, o := range options {
+		if strings.EqualFold(o, s) {
+			return i
+		}
+	}
+	return -1
+}
+
+// notAnOption is the error of an answer naming none of the options,
+// which is asked again.
+func notAnOption(s string) error {
+	return &invalidAnswer{err: errorf("%q is not one of the options", strings.TrimSpace(s))}
+}
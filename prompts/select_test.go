@@ -0,0 +1,170 @@
+package prompts_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/prompttest"
+)
+
+func TestSelect(t *testing.T) {
+	colors := []string{"red", "green", "blue"}
+	tests := []struct {
+		name  string
+		opts  []prompts.Option
+		keys  string
+		want  string
+		index int
+	}{
+		{"first", nil, prompttest.Enter, "red", 0},
+		{"down", nil, prompttest.Down + prompttest.Enter, "green", 1},
+		{"up wraps", nil, prompttest.Up + prompttest.Enter, "blue", 2},
+		{"filter", nil, "bl" + prompttest.Enter, "blue", 2},
+		{"default", []prompts.Option{prompts.SelectOptions{Default: "green"}}, prompttest.Enter, "green", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term := prompttest.New(t)
+			res := prompttest.Start(term, func(o prompts.Option) ([2]any, error) {
+				s, i, err := prompts.Select("Color?", colors, append(tt.opts, o)...)
+				return [2]any{s, i}, err
+			})
+			term.WaitFor("blue")
+			term.Send(tt.keys)
+			got, err := res.Wait()
+			if err != nil || got != [2]any{tt.want, tt.index} {
+				t.Fatalf("Select() = %v, %v, want %v, nil", got, err, [2]any{tt.want, tt.index})
+			}
+			if screen, want := term.Screen(), "Color? "+tt.want; screen != want {
+				t.Errorf("screen shows %q once answered, want %q", screen, want)
+			}
+		})
+	}
+}
+
+func TestSelectScreen(t *testing.T) {
+	term := prompttest.New(t)
+	res := prompttest.Start(term, func(o prompts.Option) (string, error) {
+		s, _, err := prompts.Select("Color?", []string{"red", "green", "blue"}, o)
+		return s, err
+	})
+	term.WaitFor("blue")
+	if got, want := term.Screen(), "Color?\n> red\n  green\n  blue"; got != want {
+		t.Errorf("screen shows\n%s\nwant\n%s", got, want)
+	}
+	term.Send(prompttest.Down)
+	term.WaitFor("> green")
+	term.Send(prompttest.Enter)
+	if _, err := res.Wait(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSelectDefault(t *testing.T) {
+	colors := []string{"red", "green", "blue"}
+	tests := []struct {
+		name string
+		def  string
+		keys string
+		want string
+	}{
+		{"starts on it", "blue", prompttest.Enter, "blue"},
+		{"moves from it", "green", prompttest.Down + prompttest.Enter, "blue"},
+		{"wraps from it", "blue", prompttest.Down + prompttest.Enter, "red"},
+		{"not an option", "purple", prompttest.Enter, "red"},
+		{"case differs", "GREEN", prompttest.Enter, "green"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term := prompttest.New(t)
+			res := prompttest.Start(term, func(o prompts.Option) (string, error) {
+				s, _, err := prompts.Select("Color?", colors, prompts.SelectOptions{Default: tt.def}, o)
+				return s, err
+			})
+			term.WaitFor("blue")
+			term.Send(tt.keys)
+			if got, err := res.Wait(); err != nil || got != tt.want {
+				t.Fatalf("Select() = %q, %v, want %q, nil", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectDefaultScreen(t *testing.T) {
+	term := prompttest.New(t)
+	res := prompttest.Start(term, func(o prompts.Option) (string, error) {
+		s, _, err := prompts.Select("Color?", []string{"red", "green", "blue"}, prompts.SelectOptions{Default: "green"}, o)
+		return s, err
+	})
+	term.WaitFor("blue")
+	if got, want := term.Screen(), "Color?\n  red\n> green\n  blue"; got != want {
+		t.Errorf("screen shows\n%s\nwant\n%s", got, want)
+	}
+	term.Send(prompttest.Enter)
+	if _, err := res.Wait(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSelectDefaultRemembered(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	colors := []string{"red", "green", "blue"}
+	for _, want := range []string{"red", "blue"} {
+		term := prompttest.New(t)
+		res := prompttest.Start(term, func(o prompts.Option) (string, error) {
+			s, _, err := prompts.Select("Color?", colors, prompts.SelectOptions{Default: "green"},
+				prompts.WithRemember(prompts.RememberOptions{}), o)
+			return s, err
+		})
+		term.WaitFor("blue")
+		if want == "red" {
+			// Nothing is remembered yet: move up from the default.
+			term.Send(prompttest.Up)
+			term.WaitFor("> red")
+		} else {
+			// The answer remembered wins over the default.
+			if !strings.Contains(term.Screen(), "> red") {
+				t.Errorf("screen shows\n%s\nwant the cursor on the remembered answer", term.Screen())
+			}
+			term.Send(prompttest.Up)
+			term.WaitFor("> blue")
+		}
+		term.Send(prompttest.Enter)
+		if got, err := res.Wait(); err != nil || got != want {
+			t.Fatalf("Select() = %q, %v, want %q, nil", got, err, want)
+		}
+	}
+}
+
+func TestSelectStreamDefault(t *testing.T) {
+	term := prompttest.New(t)
+	ch := make(chan string)
+	res := prompttest.Start(term, func(o prompts.Option) (string, error) {
+		s, _, err := prompts.SelectStream("Color?", ch, prompts.SelectOptions{Default: "blue"}, o)
+		return s, err
+	})
+	ch <- "red"
+	term.WaitFor("> red")
+	ch <- "green"
+	ch <- "blue"
+	close(ch)
+	// The cursor moves to the default when it arrives.
+	term.WaitFor("> blue")
+	term.Send(prompttest.Enter)
+	if got, err := res.Wait(); err != nil || got != "blue" {
+		t.Fatalf("SelectStream() = %q, %v, want %q, nil", got, err, "blue")
+	}
+}
+
+func TestSelectLines(t *testing.T) {
+	var out strings.Builder
+	got, i, err := prompts.Select("Color?", []string{"red", "green", "blue"},
+		prompts.WithInput(strings.NewReader("purple\nGreen\n")), prompts.WithOutput(&out))
+	if err != nil || got != "green" || i != 1 {
+		t.Fatalf("Select() = %q, %d, %v, want %q, 1, nil", got, i, err, "green")
+	}
+	if want := "Color? \n\"purple\" is not one of the options\nColor? \n"; out.String() != want {
+		t.Errorf("output is %q, want %q", out.String(), want)
+	}
+}
@@ -0,0 +1,34 @@
+package prompts
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+)
+
+// withInterrupt returns a copy of ctx that is cancelled when the process
+// receives SIGINT or SIGTERM, so that a prompt gets the chance to restore
+// the terminal instead of the process dying in raw mode. interrupted
+// reports whether that happened and stop releases the signal handler.
+func withInterrupt(ctx context.Context) (_ context.Context, interrupted func() bool, stop func()) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	ctx, cancel := context.WithCancel(ctx)
+	var got int32
+	go func() {
+		select {
+		case <-sigs:
+			atomic.StoreInt32(&got, 1)
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	interrupted = func() bool { return atomic.LoadInt32(&got) == 1 }
+	stop = func() {
+		signal.Stop(sigs)
+		cancel()
+	}
+	return ctx, interrupted, stop
+}
@@ -0,0 +1,182 @@
+package prompts
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SliderOptions configures Slider.
+type SliderOptions struct {
+	// Step is how much Left and Right change the value, 1 if it is not
+	// positive, and BigStep how much Shift+Left and Shift+Right, PageUp
+	// and PageDown do, a tenth of the range if it is not positive.
+	Step, BigStep float64
+	// Default is the value the slider starts at, if it is in range.
+	// Otherwise the slider starts at its minimum.
+	Default float64
+	// Width is the number of columns of the slider, 30 if it is not
+	// positive.
+	Width int
+}
+
+func (o SliderOptions) apply(c *config) { c.slider = o }
+
+// Slider asks for a number between min and max using the label, shown as
+// a bar that Left and Right slide by a step, Shift+Left and Shift+Right
+// by a bigger step and Home and End to either end. Enter accepts the
+// value, provided it passes the validators given with WithValidator.
+//
+// If the standard input is not a terminal, the next line read from it
+// must be a number between min and max, or empty for the default.
+//
+// It returns ErrInterrupted if the user presses Ctrl+C.
+func Slider(label string, min, max float64, opts ...Option) (float64, error) {
+	return SliderContext(context.Background(), label, min, max, opts...)
+}
+
+// SliderContext is like Slider but gives up when ctx is done,
+// returning ctx.Err().
+func SliderContext(ctx context.Context, label string, min, max float64, opts ...Option) (float64, error) {
+	c := newConfig(label, opts)
+	o := c.slider
+	if o.Step <= 0 {
+		o.Step = 1
+	}
+	if o.BigStep <= 0 {
+		o.BigStep = math.Max(o.Step, (max-min)/10)
+	}
+	if o.Width <= 0 {
+		o.Width = 30
+	}
+	m := &sliderModel{
+		label:      label,
+		theme:      &c.theme,
+		keys:       &c.keymap,
+		opts:       o,
+		min:        min,
+		max:        max,
+		validators: append([]Validator{numberRange(min, max)}, c.validators...),
+		value:      min,
+	}
+	if o.Default >= min && o.Default <= max {
+		m.value = o.Default
+	}
+	if f, ok := c.initial.(float64); ok {
+		m.set(f)
+	}
+	if err := run(ctx, c, m); err != nil {
+		return 0, err
+	}
+	return m.value, nil
+}
+
+type sliderModel struct {
+	label      string
+	theme      *Theme
+	keys       *Keymap
+	opts       SliderOptions
+	min, max   float64
+	validators []Validator
+	value      float64
+	// err is why the last answer was rejected.
+	err  error
+	done bool
+}
+
+func (m *sliderModel) result() interface{} {
+	return m.value
+}
+
+func (m *sliderModel) prompt() string {
+	return m.theme.label(m.label) + " (" + m.format(m.value) + ") "
+}
+
+func (m *sliderModel) answer(s string) (bool, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		s = m.format(m.value)
+	}
+	if err := validate(m.validators, s); err != nil {
+		return false, err
+	}
+	m.value, _ = strconv.ParseFloat(s, 64)
+	m.done = true
+	return true, nil
+}
+
+func (m *sliderModel) rejected() error {
+	return m.err
+}
+
+func (m *sliderModel) update(k key) (bool, error) {
+	m.err = nil
+	switch {
+	case bound(m.keys.Submit, k):
+		m.err = validate(m.validators, m.format(m.value))
+		m.done = m.err == nil
+	case k.name == "shift+left" || bound(m.keys.PageDown, k):
+		m.set(m.value - m.opts.BigStep)
+	case k.name == "shift+right" || bound(m.keys.PageUp, k):
+		m.set(m.value + m.opts.BigStep)
+	case bound(m.keys.Left, k) || bound(m.keys.Down, k):
+		m.set(m.value - m.opts.Step)
+	case bound(m.keys.Right, k) || bound(m.keys.Up, k):
+		m.set(m.value + m.opts.Step)
+	case bound(m.keys.Home, k):
+		m.set(m.min)
+	case bound(m.keys.End, k):
+		m.set(m.max)
+	}
+	return m.done, nil
+}
+
+func (m *sliderModel) keyHelp() ([]keyAction, bool) {
+	km := m.keys
+	return []keyAction{
+		act("move by a step", km.Left, km.Right),
+		act("move by a big step", []string{"shift+left", "shift+right"}, km.PageUp, km.PageDown),
+		act("move to the start or the end", km.Home, km.End),
+		act("accept the value", km.Submit),
+	}, false
+}
+
+// set makes f the value, rounded to the step and kept in range.
+func (m *sliderModel) set(f float64) {
+	f, _ = strconv.ParseFloat(m.format(f), 64)
+	m.value = math.Min(math.Max(f, m.min), m.max)
+}
+
+// format writes f with as many decimals as the steps have.
+func (m *sliderModel) format(f float64) string {
+	n := decimals(m.opts.Step)
+	if d := decimals(m.opts.BigStep); d > n {
+		n = d
+	}
+	return formatNumber(f, n)
+}
+
+func (m *sliderModel) view() frame {
+	if m.done {
+		return frame{lines: []string{m.theme.answered(m.label, m.format(m.value))}}
+	}
+	full, empty := "█", "░"
+	if m.theme.ascii {
+		full, empty = "#", "-"
+	}
+	filled := m.opts.Width
+	if m.max > m.min {
+		filled = int(math.Round((m.value - m.min) / (m.max - m.min) * float64(m.opts.Width)))
+	}
+	bar := m.theme.render(m.theme.Highlight, strings.Repeat(full, filled)) + strings.Repeat(empty, m.opts.Width-filled)
+	lines := []string{
+		m.theme.label(m.label),
+		m.theme.render(m.theme.Hint, m.format(m.min)) + " " + bar + " " + m.theme.render(m.theme.Hint, m.format(m.max)) +
+			"  " + m.format(m.value),
+	}
+	if m.err != nil {
+		lines = append(lines, m.theme.errorLine(m.err))
+	}
+	return frame{lines: lines}
+}
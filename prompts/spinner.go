@@ -0,0 +1,161 @@
+package prompts
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// SpinnerOptions configures a Spinner.
+type SpinnerOptions struct {
+	// Frames are shown one after the other, every Interval, before the
+	// message. Nil means SpinnerDots, or SpinnerLine when only ASCII
+	// symbols can be shown, and zero 100ms.
+	Frames   []string
+	Interval time.Duration
+	// SuccessMark and FailureMark are shown before the final message by
+	// Succeed and Fail. Empty means a check mark and a cross.
+	SuccessMark, FailureMark string
+}
+
+func (o SpinnerOptions) apply(c *config) { c.spinner = o }
+
+// Animations for SpinnerOptions.Frames.
+var (
+	SpinnerDots = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+	SpinnerLine = []string{"|", "/", "-", `\`}
+	SpinnerArc  = []string{"◜", "◠", "◝", "◞", "◡", "◟"}
+)
+
+// Spinner shows that work is going on between prompts, with an animation
+// before a message, until it is stopped. When the standard error is not
+// a terminal, it prints the message and the final message as plain lines
+// instead.
+type Spinner struct {
+	theme Theme
+	opts  SpinnerOptions
+	out   io.Writer
+	tty   bool
+
+	mu      sync.Mutex
+	message string
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// Start shows a spinner with the message on the standard error
+// until one of its Stop, Succeed and Fail methods is called.
+func Start(message string, opts ...Option) *Spinner {
+	c := newConfig(message, opts)
+	s := &Spinner{
+		theme:   c.theme,
+		opts:    c.spinner,
+		out:     c.writer(),
+		tty:     isTerminal(c.writer()) && !c.accessible,
+		message: message,
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	s.theme.colors = detectColors(c.writer())
+	if s.opts.Frames == nil {
+		s.opts.Frames = SpinnerDots
+		if s.theme.ascii {
+			s.opts.Frames = SpinnerLine
+		}
+	}
+	if s.opts.Interval <= 0 {
+		s.opts.Interval = 100 * time.Millisecond
+	}
+	if s.opts.SuccessMark == "" {
+		s.opts.SuccessMark = "✓"
+		if s.theme.ascii {
+			s.opts.SuccessMark = "+"
+		}
+	}
+	if s.opts.FailureMark == "" {
+		s.opts.FailureMark = "✗"
+		if s.theme.ascii {
+			s.opts.FailureMark = "x"
+		}
+	}
+	if !s.tty {
+		fmt.Fprintln(s.out, message)
+		close(s.stopped)
+		return s
+	}
+	go s.spin()
+	return s
+}
+
+func (s *Spinner) spin() {
+	defer close(s.stopped)
+	t := time.NewTicker(s.opts.Interval)
+	defer t.Stop()
+	io.WriteString(s.out, "\x1b[?25l")
+	for i := 0; ; i++ {
+		s.mu.Lock()
+		frame := s.theme.render(s.theme.Highlight, s.opts.Frames[i%len(s.opts.Frames)])
+		fmt.Fprintf(s.out, "\r\x1b[K%s %s", frame, s.message)
+		s.mu.Unlock()
+		select {
+		case <-s.stop:
+			io.WriteString(s.out, "\r\x1b[K\x1b[?25h")
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// Update replaces the message of the spinner.
+func (s *Spinner) Update(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.message = message
+	if !s.tty {
+		fmt.Fprintln(s.out, message)
+	}
+}
+
+// Stop removes the spinner. Stopping a spinner more than once
+// does nothing.
+func (s *Spinner) Stop() {
+	s.halt()
+}
+
+// halt stops the spinner and reports whether it was still running.
+func (s *Spinner) halt() bool {
+	s.mu.Lock()
+	running := true
+	select {
+	case <-s.stop:
+		running = false
+	default:
+		close(s.stop)
+	}
+	s.mu.Unlock()
+	<-s.stopped
+	return running
+}
+
+// Succeed replaces the spinner with the success mark and the message,
+// or the message of the spinner if it is empty.
+func (s *Spinner) Succeed(message string) {
+	s.finish(Style{Color: "green"}, s.opts.SuccessMark, message)
+}
+
+// Fail replaces the spinner with the failure mark and the message,
+// or the message of the spinner if it is empty.
+func (s *Spinner) Fail(message string) {
+	s.finish(s.theme.Error, s.opts.FailureMark, message)
+}
+
+func (s *Spinner) finish(style Style, mark, message string) {
+	if !s.halt() {
+		return
+	}
+	if message == "" {
+		message = s.message
+	}
+	fmt.Fprintln(s.out, s.theme.render(style, mark)+" "+message)
+}
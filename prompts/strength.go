@@ -0,0 +1,105 @@
+package prompts
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Strength rates how hard a password is to guess.
+type Strength int
+
+// Password strengths, from the weakest to the strongest.
+const (
+	VeryWeak Strength = iota
+	Weak
+	Fair
+	Strong
+	VeryStrong
+)
+
+var strengthNames = [...]string{"very weak", "weak", "fair", "strong", "very strong"}
+
+func (s Strength) String() string {
+	if s < VeryWeak || s > VeryStrong {
+		return "unknown"
+	}
+	return strengthNames[s]
+}
+
+// PasswordStrength rates a password by its estimated entropy, which depends
+// on its length and on the kinds of characters it mixes. Repeated
+// characters count less than distinct ones.
+func PasswordStrength(password string) Strength {
+	var lower, upper, digit, symbol, other bool
+	seen := map[rune]int{}
+	var length float64
+	for _, r := range password {
+		switch {
+		case r < unicode.MaxASCII && unicode.IsLower(r):
+			lower = true
+		case r < unicode.MaxASCII && unicode.IsUpper(r):
+			upper = true
+		case r < unicode.MaxASCII && unicode.IsDigit(r):
+			digit = true
+		case r < unicode.MaxASCII:
+			symbol = true
+		default:
+			other = true
+		}
+		seen[r]++
+		length += 1 / float64(seen[r])
+	}
+	pool := 0
+	for _, c := range []struct {
+		used bool
+		size int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+		if c.used {
+			pool += c.size
+		}
+	}
+	if pool == 0 {
+		return VeryWeak
+	}
+	bits := length * math.Log2(float64(pool))
+	switch {
+	case bits < 28:
+		return VeryWeak
+	case bits < 36:
+		return Weak
+	case bits < 60:
+		return Fair
+	case bits < 128:
+		return Strong
+	}
+	return VeryStrong
+}
+
+// minStrength returns a Validator rejecting passwords weaker than min.
+func minStrength(min Strength) Validator {
+	return func(s string) error {
+		if PasswordStrength(s) < min {
+			return errorf("the password is too weak")
+		}
+		return nil
+	}
+}
+
+// strengthLine renders a colored meter of the strength of password.
+func strengthLine(t *Theme, password string) string {
+	s := PasswordStrength(password)
+	style := Style{Color: "red"}
+	switch {
+	case s >= Strong:
+		style.Color = "green"
+	case s == Fair:
+		style.Color = "yellow"
+	}
+	full, empty := "■", "□"
+	if t.ascii {
+		full, empty = "#", "-"
+	}
+	bar := strings.Repeat(full, int(s)+1) + strings.Repeat(empty, int(VeryStrong-s))
+	return t.render(style, bar+" "+t.text(s.String()))
+}
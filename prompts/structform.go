@@ -0,0 +1,180 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	rules "github.com/tidalmigrations/interactive-cli-prompts/prompts/validate"
+)
+
+// AskStruct asks a question for every field of the struct v points to that
+// has a prompt tag and stores the answers in the fields. See FormFor for
+// how fields are turned into questions. The options apply to all of them.
+func AskStruct(v interface{}, opts ...Option) error {
+	return AskStructContext(context.Background(), v, opts...)
+}
+
+// AskStructContext is like AskStruct but gives up when ctx is done,
+// returning ctx.Err().
+func AskStructContext(ctx context.Context, v interface{}, opts ...Option) error {
+	f, err := FormFor(v, opts...)
+	if err != nil {
+		return err
+	}
+	return f.RunContext(ctx, v)
+}
+
+// FormFor returns a Form asking a question for every field of the struct
+// v points to that has a prompt tag, which holds the label:
+//
+//	type Config struct {
+//		Name  string   `prompt:"Your name" validate:"required"`
+//		Langs []string `prompt:"Languages" options:"Go,Rust,Python"`
+//	}
+//
+// String fields are asked with Input, or with Select if they have an
+// options tag listing comma-separated choices. []string fields are asked
+// with Checkboxes and need an options tag. Bool fields are asked with
+// Confirm and number fields with Input, which only accepts numbers.
+// The current values of the fields are the defaults.
+//
+// A validate tag adds the validator described by it, as parsed by
+// validate.Parse, or for []string fields the bounds on the number of
+// options checked, as parsed by validate.ParseCount. Bool fields take no
+// validate tag. The question is named after the field, or after its form
+// tag if it has one.
+func FormFor(v interface{}, opts ...Option) (*Form, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("prompts: FormFor needs a pointer to a struct, not %T", v)
+	}
+	rv = rv.Elem()
+	f := NewForm(opts...)
+	for i := 0; i < rv.NumField(); i++ {
+		sf := rv.Type().Field(i)
+		label, ok := sf.Tag.Lookup("prompt")
+		if !ok || label == "-" || sf.PkgPath != "" {
+			continue
+		}
+		q, err := fieldQuestion(sf, rv.Field(i), label)
+		if err != nil {
+			return nil, fmt.Errorf("prompts: field %s: %w", sf.Name, err)
+		}
+		name := sf.Name
+		if tag, ok := sf.Tag.Lookup("form"); ok {
+			name = tag
+		}
+		f.Add(name, q)
+	}
+	return f, nil
+}
+
+// fieldQuestion returns the question for the struct field sf,
+// whose current value is fv.
+func fieldQuestion(sf reflect.StructField, fv reflect.Value, label string) (*Question, error) {
+	var opts []Option
+	spec, validated := sf.Tag.Lookup("validate")
+	if validated && sf.Type.Kind() != reflect.Slice {
+		if sf.Type.Kind() == reflect.Bool {
+			return nil, fmt.Errorf("validate does not apply to %s fields", sf.Type)
+		}
+		v, err := rules.Parse(spec)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithValidator(v))
+	}
+	var options []string
+	if tag, ok := sf.Tag.Lookup("options"); ok {
+		for _, o := range strings.Split(tag, ",") {
+			options = append(options, strings.TrimSpace(o))
+		}
+	}
+	switch t := sf.Type; t.Kind() {
+	case reflect.String:
+		if options != nil {
+			if fv.String() != "" {
+				opts = append(opts, withInitial(fv.String()))
+			}
+			return SelectQuestion(label, options, opts...), nil
+		}
+		return InputQuestion(label, append(opts, InputOptions{Default: fv.String()})...), nil
+	case reflect.Bool:
+		return ConfirmQuestion(label, fv.Bool(), opts...), nil
+	case reflect.Slice:
+		if t.Elem().Kind() != reflect.String {
+			break
+		}
+		if options == nil {
+			return nil, fmt.Errorf("%s needs an options tag", t)
+		}
+		def := make([]string, fv.Len())
+		for i := range def {
+			def[i] = fv.Index(i).String()
+		}
+		o := CheckboxesOptions{Default: def}
+		if validated {
+			var err error
+			if o.MinSelected, o.MaxSelected, err = rules.ParseCount(spec); err != nil {
+				return nil, err
+			}
+		}
+		opts = append([]Option{o}, opts...)
+		return CheckboxesQuestion(label, options, opts...), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		var def string
+		if !fv.IsZero() {
+			def = fmt.Sprint(fv.Interface())
+		}
+		opts = append([]Option{WithValidator(numberValidator(t)), InputOptions{Default: def}}, opts...)
+		return InputQuestion(label, opts...), nil
+	}
+	return nil, fmt.Errorf("cannot ask for a %s", sf.Type)
+}
+
+// numberValidator rejects answers that are not numbers of type t.
+func numberValidator(t reflect.Type) Validator {
+	return func(s string) error {
+		if err := setNumber(reflect.New(t).Elem(), s); err != nil {
+			if t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64 {
+				return errorf("must be a number")
+			}
+			return errorf("must be a whole number")
+		}
+		return nil
+	}
+}
+
+// setNumber parses s into the number fv.
+func setNumber(fv reflect.Value, s string) error {
+	s = strings.TrimSpace(s)
+	bits := fv.Type().Bits()
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, bits)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, bits)
+		if err != nil {
+			return err
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, bits)
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(n)
+	default:
+		return fmt.Errorf("not a number")
+	}
+	return nil
+}
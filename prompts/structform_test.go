@@ -0,0 +1,51 @@
+package prompts_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+)
+
+type serviceConfig struct {
+	Name     string   `prompt:"Service name" validate:"required"`
+	Tier     string   `prompt:"Tier" options:"free,pro"`
+	Replicas int      `prompt:"Replicas"`
+	Public   bool     `prompt:"Public?"`
+	Langs    []string `prompt:"Languages" options:"Go,Python,Rust" validate:"min=1"`
+	Internal string
+}
+
+func TestAskStruct(t *testing.T) {
+	cfg := serviceConfig{Replicas: 2, Internal: "kept"}
+	var out strings.Builder
+	// The empty name and the letters for replicas are asked again.
+	in := prompts.WithInput(strings.NewReader("\nbilling\npro\nthree\n\ny\nGo,Rust\n"))
+	if err := prompts.AskStruct(&cfg, in, prompts.WithOutput(&out)); err != nil {
+		t.Fatal(err)
+	}
+	want := serviceConfig{Name: "billing", Tier: "pro", Replicas: 2, Public: true, Langs: []string{"Go", "Rust"}, Internal: "kept"}
+	if cfg.Name != want.Name || cfg.Tier != want.Tier || cfg.Replicas != want.Replicas || cfg.Public != want.Public ||
+		strings.Join(cfg.Langs, ",") != "Go,Rust" || cfg.Internal != want.Internal {
+		t.Errorf("AskStruct() stored %+v, want %+v", cfg, want)
+	}
+	if strings.Count(out.String(), "Service name") != 2 || strings.Count(out.String(), "Replicas") != 2 {
+		t.Errorf("output is %q, want the name and the replicas asked twice", out.String())
+	}
+}
+
+func TestFormForNotAStruct(t *testing.T) {
+	var name string
+	if _, err := prompts.FormFor(&name); err == nil {
+		t.Fatal("FormFor() returned nil, want an error")
+	}
+}
+
+func TestFormForBadTag(t *testing.T) {
+	var cfg struct {
+		Langs []string `prompt:"Languages"`
+	}
+	if _, err := prompts.FormFor(&cfg); err == nil {
+		t.Fatal("FormFor() returned nil for []string without options, want an error")
+	}
+}
@@ -0,0 +1,328 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TableRow is a row of SelectTable. The prompt shows the Cells under
+// the columns and returns the whole row, so that the Value can identify
+// it whatever the cells say.
+type TableRow struct {
+	Value string
+	Cells []string
+}
+
+// TableOptions configures SelectTable.
+type TableOptions struct {
+	// PageSize is the number of rows shown at once.
+	// Zero means the default page size.
+	PageSize int
+}
+
+func (o TableOptions) apply(c *config) { c.table = o }
+
+// SelectTable is like Select but picks one of rows, whose cells are lined
+// up under a header naming the columns, and returns it. Ctrl+S sorts the
+// rows by the first column, then the same column in reverse, then the next
+// column and so on, until it restores the order of rows. Numbers are
+// sorted by value. Rows matching the filter are listed best matches first,
+// whatever the order.
+// The validators given with WithValidator are given the Value of the row,
+// or its first cell if it has none.
+//
+// If the standard input is not a terminal, the next line read from it
+// must be the Value or the first cell of one of the rows.
+//
+// It returns ErrNoOptions if rows is empty and ErrInterrupted if the user
+// presses Ctrl+C.
+func SelectTable(label string, columns []string, rows []TableRow, opts ...Option) (TableRow, error) {
+	return SelectTableContext(context.Background(), label, columns, rows, opts...)
+}
+
+// SelectTableContext is like SelectTable but gives up when ctx is done,
+// returning ctx.Err().
+func SelectTableContext(ctx context.Context, label string, columns []string, rows []TableRow, opts ...Option) (TableRow, error) {
+	c := newConfig(label, opts)
+	if len(rows) == 0 {
+		return TableRow{}, ErrNoOptions
+	}
+	m := &tableModel{
+		label:      label,
+		theme:      &c.theme,
+		keys:       &c.keymap,
+		pageSize:   c.table.PageSize,
+		validators: c.validators,
+		columns:    columns,
+		rows:       rows,
+		sortColumn: -1,
+		matcher:    c.matcher,
+	}
+	m.layout()
+	if s, ok := c.initial.(string); ok {
+		if i := m.find(s); i >= 0 {
+			m.list.moveTo(m.position(i))
+		}
+	}
+	if err := run(ctx, c, m); err != nil {
+		return TableRow{}, err
+	}
+	return rows[m.picked], nil
+}
+
+type tableModel struct {
+	label    string
+	theme    *Theme
+	keys     *Keymap
+	pageSize int
+	// width and height are the size of the terminal, or zero if unknown.
+	width      int
+	height     int
+	validators []Validator
+	columns    []string
+	rows       []TableRow
+	// widths are the widths of the columns and texts the rows with their
+	// cells padded to them.
+	widths []int
+	texts  []string
+	// order lists the rows in the order shown, sorted by sortColumn
+	// in reverse if descending, unless sortColumn is -1.
+	order      []int
+	sortColumn int
+	descending bool
+	matcher    Matcher
+	list       optionList
+	// picked is the index of the picked row.
+	picked int
+	// err is why the last answer was rejected.
+	err  error
+	done bool
+}
+
+func (m *tableModel) resize(width, height int) {
+	m.width, m.height = width, height
+	m.list.resize(width, height, 2)
+}
+
+// cell returns the cell of row i in column j, which may be missing.
+func (m *tableModel) cell(i, j int) string {
+	if cells := m.rows[i].Cells; j < len(cells) {
+		return cells[j]
+	}
+	return ""
+}
+
+// layout lines the cells up under the columns
+// and lists the rows in order.
+func (m *tableModel) layout() {
+	m.widths = make([]int, len(m.columns))
+	for j, c := range m.columns {
+		m.widths[j] = textWidth(c) + textWidth(m.theme.Above) + 1
+	}
+	for i := range m.rows {
+		for j := range m.columns {
+			if w := textWidth(m.cell(i, j)); w > m.widths[j] {
+				m.widths[j] = w
+			}
+		}
+	}
+	m.texts = make([]string, len(m.rows))
+	for i := range m.rows {
+		cells := make([]string, len(m.columns))
+		for j := range m.columns {
+			cells[j] = m.pad(m.cell(i, j), j)
+		}
+		m.texts[i] = strings.TrimRight(strings.Join(cells, "  "), " ")
+	}
+	m.order = make([]int, len(m.rows))
+	for i := range m.order {
+		m.order[i] = i
+	}
+	m.relist()
+}
+
+func (m *tableModel) pad(s string, column int) string {
+	return s + strings.Repeat(" ", m.widths[column]-textWidth(s))
+}
+
+// relist lists the rows in order, keeping the filter
+// and the cursor on the same row.
+func (m *tableModel) relist() {
+	current := -1
+	if i := m.list.current(); i >= 0 {
+		current = m.order[i]
+	}
+	var text []rune
+	if m.list.options != nil {
+		text = m.list.filter.text
+	}
+	if m.sortColumn >= 0 {
+		j := m.sortColumn
+		sort.SliceStable(m.order, func(a, b int) bool {
+			x, y := m.cell(m.order[a], j), m.cell(m.order[b], j)
+			if m.descending {
+				x, y = y, x
+			}
+			return lessCell(x, y)
+		})
+	} else {
+		sort.Ints(m.order)
+	}
+	options := make([]string, len(m.order))
+	for p, i := range m.order {
+		options[p] = m.texts[i]
+	}
+	m.list = newOptionList(m.theme, m.keys, options, m.pageSize)
+	m.list.filter.text, m.list.filter.matcher = text, m.matcher
+	m.list.init(nil)
+	m.list.resize(m.width, m.height, 2)
+	if current >= 0 {
+		m.list.moveTo(m.position(current))
+	}
+}
+
+// lessCell compares cells as numbers if both are numbers
+// and as text otherwise.
+func lessCell(x, y string) bool {
+	a, errA := strconv.ParseFloat(strings.TrimSpace(x), 64)
+	b, errB := strconv.ParseFloat(strings.TrimSpace(y), 64)
+	if errA == nil && errB == nil {
+		return a < b
+	}
+	return strings.ToLower(x) < strings.ToLower(y)
+}
+
+// position returns where row i is listed.
+func (m *tableModel) position(i int) int {
+	for p, r := range m.order {
+		if r == i {
+			return p
+		}
+	}
+	return -1
+}
+
+// find returns the index of the row whose Value or first cell is s,
+// preferring an exact match over a case-insensitive one, or -1.
+func (m *tableModel) find(s string) int {
+	s = strings.TrimSpace(s)
+	for _, fold := range []bool{false, true} {
+		for i, r := range m.rows {
+			for _, v := range []string{r.Value, m.cell(i, 0)} {
+				if v != "" && (v == s || fold && strings.EqualFold(v, s)) {
+					return i
+				}
+			}
+		}
+	}
+	return -1
+}
+
+// nextSort sorts by the next column or order.
+func (m *tableModel) nextSort() {
+	switch {
+	case m.sortColumn >= 0 && !m.descending:
+		m.descending = true
+	case m.sortColumn+1 < len(m.columns):
+		m.sortColumn, m.descending = m.sortColumn+1, false
+	default:
+		m.sortColumn, m.descending = -1, false
+	}
+	m.relist()
+}
+
+// value returns the Value of row i, or its first cell if it has none.
+func (m *tableModel) value(i int) string {
+	if v := m.rows[i].Value; v != "" {
+		return v
+	}
+	return m.cell(i, 0)
+}
+
+func (m *tableModel) result() interface{} {
+	return m.value(m.picked)
+}
+
+func (m *tableModel) prompt() string {
+	return m.theme.label(m.label) + " "
+}
+
+func (m *tableModel) answer(s string) (bool, error) {
+	i := m.find(s)
+	if i < 0 {
+		return false, fmt.Errorf("prompts: %q is not one of the rows", s)
+	}
+	if err := validate(m.validators, m.value(i)); err != nil {
+		return false, err
+	}
+	m.picked, m.done = i, true
+	return true, nil
+}
+
+func (m *tableModel) captures(k key) bool {
+	return m.list.captures(k)
+}
+
+func (m *tableModel) rejected() error {
+	return m.err
+}
+
+func (m *tableModel) update(k key) (bool, error) {
+	switch {
+	case bound(m.keys.Submit, k):
+		if i := m.list.current(); i >= 0 {
+			m.picked = m.order[i]
+			m.err = validate(m.validators, m.value(m.picked))
+			m.done = m.err == nil
+		}
+		return m.done, nil
+	case bound(m.keys.Sort, k):
+		m.nextSort()
+	case !m.list.update(k):
+		return false, nil
+	}
+	m.err = nil
+	return false, nil
+}
+
+func (m *tableModel) keyHelp() ([]keyAction, bool) {
+	return m.list.keyActions(
+		act("sort by the next column", m.keys.Sort),
+		act("pick the row", m.keys.Submit),
+	), false
+}
+
+// header renders the names of the columns, marking the one the rows are
+// sorted by, lined up with the rows.
+func (m *tableModel) header() string {
+	t := m.theme
+	names := make([]string, len(m.columns))
+	for j, c := range m.columns {
+		if j == m.sortColumn {
+			mark := t.Above
+			if m.descending {
+				mark = t.Below
+			}
+			c += " " + mark
+		}
+		names[j] = m.pad(c, j)
+	}
+	indent := strings.Repeat(" ", textWidth(t.Pointer)+1)
+	return indent + t.render(Style{Bold: true}, strings.TrimRight(strings.Join(names, "  "), " "))
+}
+
+func (m *tableModel) view() frame {
+	t := m.theme
+	if m.done {
+		return frame{lines: []string{t.answered(m.label, m.cell(m.picked, 0))}}
+	}
+	lines := m.list.lines(m.label, func(int) string { return "" })
+	lines = append([]string{lines[0], m.header()}, lines[1:]...)
+	if m.err != nil {
+		lines = append(lines, t.errorLine(m.err))
+	}
+	return frame{lines: lines}
+}
@@ -0,0 +1,285 @@
+package prompts
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// TagsOptions configures Tags.
+type TagsOptions struct {
+	// Default lists the tags to begin with.
+	Default []string
+	// Suggestions are known tags. Those matching the text typed, fuzzily
+	// unless the prompt is given WithMatcher, are listed below the prompt,
+	// at most PageSize of them, or 7 if it is not positive.
+	Suggestions []string
+	PageSize    int
+	// Separators are the characters that add the text typed as a tag,
+	// as Enter does. Nil means a comma.
+	Separators []rune
+}
+
+func (o TagsOptions) apply(c *config) { c.tags = o }
+
+// Tags asks for a list of free-form values using the label and returns
+// them. Enter or a comma adds the text typed as a tag, shown as a chip
+// before the text, and Enter with no text accepts the tags. Backspace with
+// no text removes the last tag. Tags are added once, after the transforms
+// given with WithTransform, provided they pass the validators given with
+// WithValidator. Up and Down highlight one of the matching suggestions of
+// TagsOptions, which Enter adds, and Tab takes it, or the first one,
+// as the text typed so far.
+//
+// If the standard input is not a terminal, the next line read from it
+// must list the tags separated by the separators. An empty line keeps
+// the default tags.
+//
+// It returns ErrInterrupted if the user presses Ctrl+C.
+func Tags(label string, opts ...Option) ([]string, error) {
+	return TagsContext(context.Background(), label, opts...)
+}
+
+// TagsContext is like Tags but gives up when ctx is done,
+// returning ctx.Err().
+func TagsContext(ctx context.Context, label string, opts ...Option) ([]string, error) {
+	c := newConfig(label, opts)
+	m := &tagsModel{
+		label:      label,
+		theme:      &c.theme,
+		keys:       &c.keymap,
+		opts:       c.tags,
+		validators: c.validators,
+		transforms: c.transforms,
+		matcher:    c.matcher,
+		tags:       []string{},
+		cursor:     -1,
+	}
+	if m.opts.Separators == nil {
+		m.opts.Separators = []rune{','}
+	}
+	if m.opts.PageSize <= 0 {
+		m.opts.PageSize = defaultPageSize
+	}
+	tags := m.opts.Default
+	if initial, ok := c.initial.([]string); ok {
+		tags = initial
+	}
+	for _, s := range tags {
+		m.add(s)
+	}
+	if err := run(ctx, c, m); err != nil {
+		return nil, err
+	}
+	return m.tags, nil
+}
+
+type tagsModel struct {
+	label      string
+	theme      *Theme
+	keys       *Keymap
+	opts       TagsOptions
+	validators []Validator
+	transforms []Transform
+	matcher    Matcher
+	tags       []string
+	line       line
+	// suggestions are the indexes of the suggestions matching the line
+	// and cursor the highlighted one, or -1.
+	suggestions []int
+	cursor      int
+	// err is why the last tag or answer was rejected.
+	err  error
+	done bool
+}
+
+// add adds s as a tag unless it is empty or already added.
+func (m *tagsModel) add(s string) error {
+	s = strings.TrimSpace(transform(m.transforms, s))
+	if s == "" {
+		return nil
+	}
+	for _, t := range m.tags {
+		if t == s {
+			return nil
+		}
+	}
+	if err := validate(m.validators, s); err != nil {
+		return err
+	}
+	m.tags = append(m.tags, s)
+	return nil
+}
+
+// commit adds the text typed, or the highlighted suggestion,
+// as a tag and clears the line if it is accepted.
+func (m *tagsModel) commit() {
+	s := m.line.String()
+	if m.cursor >= 0 {
+		s = m.opts.Suggestions[m.suggestions[m.cursor]]
+	}
+	if m.err = m.add(s); m.err == nil {
+		m.line = line{}
+		m.suggest()
+	}
+}
+
+// suggest lists the suggestions matching the line, best first,
+// leaving out the tags already added.
+func (m *tagsModel) suggest() {
+	m.suggestions, m.cursor = m.suggestions[:0], -1
+	text := []rune(m.line.String())
+	if len(text) == 0 {
+		return
+	}
+	scores := map[int]int{}
+	for i, s := range m.opts.Suggestions {
+		if m.added(s) {
+			continue
+		}
+		if _, score, ok := match(m.matcher, text, s); ok {
+			m.suggestions = append(m.suggestions, i)
+			scores[i] = score
+		}
+	}
+	sort.SliceStable(m.suggestions, func(i, j int) bool {
+		return scores[m.suggestions[i]] > scores[m.suggestions[j]]
+	})
+	if len(m.suggestions) > m.opts.PageSize {
+		m.suggestions = m.suggestions[:m.opts.PageSize]
+	}
+}
+
+func (m *tagsModel) added(s string) bool {
+	for _, t := range m.tags {
+		if t == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *tagsModel) separator(r rune) bool {
+	for _, sep := range m.opts.Separators {
+		if r == sep {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *tagsModel) result() interface{} {
+	return m.tags
+}
+
+func (m *tagsModel) prompt() string {
+	if len(m.tags) > 0 {
+		return m.theme.label(m.label) + " (" + strings.Join(m.tags, ", ") + ") "
+	}
+	return m.theme.label(m.label) + " "
+}
+
+func (m *tagsModel) answer(s string) (bool, error) {
+	if strings.TrimSpace(s) == "" {
+		m.done = true
+		return true, nil
+	}
+	list := strings.FieldsFunc(s, m.separator)
+	return true, m.answerList(list)
+}
+
+func (m *tagsModel) answerList(list []string) error {
+	tags := m.tags
+	m.tags = []string{}
+	for _, s := range list {
+		if err := m.add(s); err != nil {
+			m.tags = tags
+			return err
+		}
+	}
+	m.done = true
+	return nil
+}
+
+func (m *tagsModel) rejected() error {
+	return m.err
+}
+
+func (m *tagsModel) update(k key) (bool, error) {
+	m.err = nil
+	n := len(m.suggestions)
+	switch {
+	case bound(m.keys.Submit, k):
+		if m.line.String() == "" && m.cursor < 0 {
+			m.done = true
+			return true, nil
+		}
+		m.commit()
+		return false, nil
+	case k.r != 0 && m.separator(k.r):
+		m.commit()
+		return false, nil
+	case k.name == "backspace" && len(m.line.buf) == 0:
+		if len(m.tags) > 0 {
+			m.tags = m.tags[:len(m.tags)-1]
+		}
+		return false, nil
+	case n > 0 && bound(m.keys.Up, k):
+		m.cursor = (m.cursor + n) % (n + 1)
+		if m.cursor == n {
+			m.cursor = -1
+		}
+		return false, nil
+	case n > 0 && bound(m.keys.Down, k):
+		m.cursor = (m.cursor+2)%(n+1) - 1
+		return false, nil
+	case n > 0 && bound(m.keys.Complete, k):
+		i := m.cursor
+		if i < 0 {
+			i = 0
+		}
+		s := m.opts.Suggestions[m.suggestions[i]]
+		m.line = line{buf: []rune(s), pos: len([]rune(s))}
+		m.suggest()
+		return false, nil
+	}
+	if m.line.edit(k, m.keys) {
+		m.suggest()
+	}
+	return false, nil
+}
+
+func (m *tagsModel) keyHelp() ([]keyAction, bool) {
+	return append(editActions(m.keys),
+		act("move through the suggestions", m.keys.Up, m.keys.Down),
+		act("take the suggestion", m.keys.Complete),
+		act("add the tag typed, or accept the tags if nothing is typed", m.keys.Submit),
+		act("remove the last tag when nothing is typed", []string{"backspace"}),
+	), true
+}
+
+func (m *tagsModel) view() frame {
+	t := m.theme
+	if m.done {
+		return frame{lines: []string{t.answered(m.label, strings.Join(m.tags, ", "))}}
+	}
+	prompt := t.label(m.label) + " "
+	for _, s := range m.tags {
+		prompt += t.render(t.Highlight, "["+s+"]") + " "
+	}
+	lines := []string{prompt + m.line.String()}
+	text := []rune(m.line.String())
+	for i, j := range m.suggestions {
+		s := m.opts.Suggestions[j]
+		positions, _, _ := match(m.matcher, text, s)
+		lines = append(lines, t.option(s, "", positions, i == m.cursor))
+	}
+	if m.err != nil {
+		lines = append(lines, t.errorLine(m.err))
+	}
+	return frame{
+		lines:      lines,
+		cursorCol:  textWidth(prompt + string(m.line.buf[:m.line.pos])),
+		showCursor: true,
+	}
+}
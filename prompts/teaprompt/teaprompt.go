@@ -0,0 +1,180 @@
+// Package teaprompt embeds prompts in Bubble Tea programs, which own the
+// terminal, as tea.Models:
+//
+//	name := teaprompt.New(func(o prompts.Option) (string, error) {
+//		return prompts.Input("What is your name?", o)
+//	})
+//
+// The model is started by its Init method, like any other, and passed the
+// messages of the program by its parent. Once the prompt has its answer,
+// or fails, the model sends a DoneMsg and Result returns the outcome.
+package teaprompt
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+)
+
+// Model is a prompt running as a Bubble Tea model.
+type Model[T any] struct {
+	ask    func(prompts.Option) (T, error)
+	driver *prompts.Driver
+	events chan tea.Msg
+	view   string
+
+	done  bool
+	value T
+	err   error
+}
+
+// New returns a Model asking with ask, which must pass the option it is
+// given to its prompts.
+func New[T any](ask func(prompts.Option) (T, error)) *Model[T] {
+	m := &Model[T]{ask: ask, events: make(chan tea.Msg)}
+	m.driver = prompts.NewDriver(renderer{m.events, m})
+	return m
+}
+
+// DoneMsg is sent when the prompt of Model returns.
+type DoneMsg struct {
+	// Model is the model whose prompt returned.
+	Model tea.Model
+}
+
+// frameMsg carries a frame rendered by the prompt of model.
+type frameMsg struct {
+	model interface{}
+	view  string
+}
+
+// resultMsg carries the outcome of the prompt of model.
+type resultMsg[T any] struct {
+	model *Model[T]
+	value T
+	err   error
+}
+
+// Init starts the prompt.
+func (m *Model[T]) Init() tea.Cmd {
+	go func() {
+		v, err := m.ask(prompts.WithDriver(m.driver))
+		m.events <- resultMsg[T]{model: m, value: v, err: err}
+	}()
+	return m.next
+}
+
+// next waits for what the prompt does next.
+func (m *Model[T]) next() tea.Msg {
+	return <-m.events
+}
+
+// Update passes the keys pressed to the prompt, while it runs, and
+// takes what it shows.
+func (m *Model[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if !m.done {
+			if keys := keyBytes(msg); keys != "" {
+				m.driver.Send(keys)
+			}
+		}
+	case frameMsg:
+		if msg.model == m {
+			m.view = msg.view
+			return m, m.next
+		}
+	case resultMsg[T]:
+		if msg.model == m {
+			m.done, m.value, m.err = true, msg.value, msg.err
+			return m, func() tea.Msg { return DoneMsg{Model: m} }
+		}
+	}
+	return m, nil
+}
+
+// View returns what the prompt shows.
+func (m *Model[T]) View() string {
+	return m.view
+}
+
+// Done reports whether the prompt has returned.
+func (m *Model[T]) Done() bool {
+	return m.done
+}
+
+// Result returns what the prompt returned, once it is done.
+func (m *Model[T]) Result() (T, error) {
+	return m.value, m.err
+}
+
+// renderer sends the frames of a prompt to its model.
+type renderer struct {
+	events chan<- tea.Msg
+	model  interface{}
+}
+
+func (r renderer) Render(f prompts.Frame) {
+	r.events <- frameMsg{model: r.model, view: strings.Join(f.Lines, "\n")}
+}
+
+func (r renderer) Clear() {
+	r.events <- frameMsg{model: r.model}
+}
+
+func (r renderer) Done() {}
+
+// keyBytes returns the bytes a terminal sends for k.
+func keyBytes(k tea.KeyMsg) string {
+	var s string
+	switch {
+	case k.Type == tea.KeyRunes:
+		s = string(k.Runes)
+	case k.Type == tea.KeySpace:
+		s = " "
+	case k.Type >= 0:
+		// Control characters are their own codes.
+		s = string(rune(k.Type))
+	default:
+		s = sequences[k.Type]
+	}
+	if k.Alt && s != "" {
+		s = "\x1b" + s
+	}
+	return s
+}
+
+// sequences are the escape sequences of the keys that send one.
+var sequences = map[tea.KeyType]string{
+	tea.KeyUp:            "\x1b[A",
+	tea.KeyDown:          "\x1b[B",
+	tea.KeyRight:         "\x1b[C",
+	tea.KeyLeft:          "\x1b[D",
+	tea.KeyShiftTab:      "\x1b[Z",
+	tea.KeyHome:          "\x1b[H",
+	tea.KeyEnd:           "\x1b[F",
+	tea.KeyPgUp:          "\x1b[5~",
+	tea.KeyPgDown:        "\x1b[6~",
+	tea.KeyDelete:        "\x1b[3~",
+	tea.KeyInsert:        "\x1b[2~",
+	tea.KeyCtrlUp:        "\x1b[1;5A",
+	tea.KeyCtrlDown:      "\x1b[1;5B",
+	tea.KeyCtrlRight:     "\x1b[1;5C",
+	tea.KeyCtrlLeft:      "\x1b[1;5D",
+	tea.KeyCtrlHome:      "\x1b[1;5H",
+	tea.KeyCtrlEnd:       "\x1b[1;5F",
+	tea.KeyShiftUp:       "\x1b[1;2A",
+	tea.KeyShiftDown:     "\x1b[1;2B",
+	tea.KeyShiftRight:    "\x1b[1;2C",
+	tea.KeyShiftLeft:     "\x1b[1;2D",
+	tea.KeyShiftHome:     "\x1b[1;2H",
+	tea.KeyShiftEnd:      "\x1b[1;2F",
+	tea.KeyCtrlShiftUp:   "\x1b[1;6A",
+	tea.KeyCtrlShiftDown: "\x1b[1;6B",
+	tea.KeyF1:            "\x1bOP",
+	tea.KeyF2:            "\x1bOQ",
+	tea.KeyF3:            "\x1bOR",
+	tea.KeyF4:            "\x1bOS",
+}
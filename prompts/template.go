@@ -0,0 +1,104 @@
+package prompts
+
+import (
+	"strings"
+	"text/template"
+)
+
+// Templates render parts of every prompt in place of the layout of the
+// theme, so that products can brand what their prompts show. Each is
+// executed with the data named below; templates parsed with the functions
+// of TemplateFuncs can style their text with the colors the output can
+// show. Parts whose template is not set, or fails, are rendered by the
+// theme as usual.
+type Templates struct {
+	// Question renders the label of a prompt while it is asked,
+	// given a QuestionData.
+	Question *template.Template
+	// Option renders an option of a list, given an OptionData.
+	Option *template.Template
+	// Answer renders the line a prompt leaves once it has its answer,
+	// given an AnswerData.
+	Answer *template.Template
+}
+
+// QuestionData is what the Question template is given.
+type QuestionData struct {
+	// Prefix is the Prefix of the theme and Label the label of the prompt.
+	Prefix, Label string
+}
+
+// AnswerData is what the Answer template is given.
+type AnswerData struct {
+	// Prefix is the Prefix of the theme and Label the label of the prompt.
+	Prefix, Label string
+	// Answer is the answer as the prompt shows it, e.g. the picked options
+	// of Checkboxes separated by commas.
+	Answer string
+}
+
+// OptionData is what the Option template is given.
+type OptionData struct {
+	// Option is the option as given and Text the option with the characters
+	// matching the filter typed so far in the Match style of the theme.
+	Option, Text string
+	// Mark is what is shown before the option, such as the Checked or
+	// Unchecked mark of Checkboxes, or the empty string.
+	Mark string
+	// Pointer is the Pointer of the theme.
+	Pointer string
+	// Highlighted is set for the option under the cursor.
+	Highlighted bool
+}
+
+// TemplateFuncs returns the functions templates can be parsed with:
+//
+//	style "bold cyan" text
+//
+// renders text with the attributes bold, dim and underline and a Color
+// named in the space-separated list, as far as the output allows.
+func TemplateFuncs() template.FuncMap {
+	return (&Theme{}).templateFuncs()
+}
+
+func (t *Theme) templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"style": func(spec, s string) string { return t.render(parseStyle(spec), s) },
+	}
+}
+
+// parseStyle returns the style of a space-separated list of attributes
+// and a color.
+func parseStyle(spec string) Style {
+	var st Style
+	for _, w := range strings.Fields(spec) {
+		switch strings.ToLower(w) {
+		case "bold":
+			st.Bold = true
+		case "dim":
+			st.Dim = true
+		case "underline":
+			st.Underline = true
+		default:
+			st.Color = Color(w)
+		}
+	}
+	return st
+}
+
+// execute renders data with tmpl, whose functions render with the colors
+// of t, and reports whether it did.
+func (t *Theme) execute(tmpl *template.Template, data interface{}) (string, bool) {
+	if tmpl == nil {
+		return "", false
+	}
+	tmpl, err := tmpl.Clone()
+	if err != nil {
+		return "", false
+	}
+	var b strings.Builder
+	if err := tmpl.Funcs(t.templateFuncs()).Execute(&b, data); err != nil {
+		return "", false
+	}
+	return b.String(), true
+}
@@ -0,0 +1,124 @@
+package prompts
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// WithTTY makes a prompt ask on the controlling terminal of the process,
+// e.g. /dev/tty, when the standard input is redirected, rather than read
+// the answer from it. This lets a tool run as "mytool < data.json" still
+// ask for a password. Without a controlling terminal, the answer is read
+// from the standard input as usual.
+func WithTTY() Option {
+	return optionFunc(func(c *config) { c.tty = true })
+}
+
+// WithInput makes a prompt read from r rather than from the standard
+// input. If r is a terminal, the prompt is interactive on it as usual,
+// as Interactivity finds, and otherwise the answer is read from it line by
+// line.
+func WithInput(r io.Reader) Option {
+	return optionFunc(func(c *config) { c.in, c.terminal = r, nil })
+}
+
+// WithOutput makes a prompt show on w rather than on the output set with
+// SetOutput. Unless w is a terminal, it is not sent colors.
+func WithOutput(w io.Writer) Option {
+	return optionFunc(func(c *config) { c.out = w })
+}
+
+var (
+	outputMu sync.Mutex
+	output   io.Writer = os.Stderr
+)
+
+// SetOutput makes w the output of all prompts, spinners and progress bars
+// not given WithOutput. It defaults to the standard error: all they show
+// goes there, and the standard output is left to the program, so that
+// piping it never captures their text. Only WithJSONOutput prints to the
+// standard output, the answers the program is run for.
+func SetOutput(w io.Writer) {
+	outputMu.Lock()
+	defer outputMu.Unlock()
+	output = w
+}
+
+func currentOutput() io.Writer {
+	outputMu.Lock()
+	defer outputMu.Unlock()
+	return output
+}
+
+// WithTerminal makes a prompt ask on the terminal f, such as the
+// pseudo-terminal of a test, rather than on the standard input and error.
+// It is like WithInput(f) and WithOutput(f), except that unless f is the
+// standard input, the prompt asks on it whatever Interactivity finds.
+func WithTerminal(f *os.File) Option {
+	return optionFunc(func(c *config) { c.in, c.out, c.terminal = f, f, f })
+}
+
+// reader returns what the prompt reads from.
+func (c *config) reader() io.Reader {
+	if c.in != nil {
+		return c.in
+	}
+	return os.Stdin
+}
+
+// writer returns what the prompt shows on.
+func (c *config) writer() io.Writer {
+	if c.out != nil {
+		return c.out
+	}
+	return currentOutput()
+}
+
+// terminalFile returns r as a file if it is a terminal.
+func terminalFile(r io.Reader) (*os.File, bool) {
+	f, ok := r.(*os.File)
+	return f, ok && term.IsTerminal(int(f.Fd()))
+}
+
+// isTerminal reports whether w is a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// pollInterval is how often a pending read checks for cancellation.
+const pollInterval = 50 * time.Millisecond
+
+// contextReader reads from a terminal until its context is done.
+type contextReader struct {
+	ctx context.Context
+	f   *os.File
+	// idle, if set, is called whenever no input arrives for pollInterval,
+	// and an error it returns is returned by Read.
+	idle func() error
+}
+
+func (r *contextReader) Read(b []byte) (int, error) {
+	for {
+		if err := r.ctx.Err(); err != nil {
+			return 0, err
+		}
+		ready, err := waitInput(r.f, pollInterval)
+		if err != nil {
+			return 0, err
+		}
+		if ready {
+			return r.f.Read(b)
+		}
+		if r.idle != nil {
+			if err := r.idle(); err != nil {
+				return 0, err
+			}
+		}
+	}
+}
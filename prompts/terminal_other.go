@@ -0,0 +1,27 @@
+//go:build !windows
+// +build !windows
+
+package prompts
+
+import (
+	"os"
+	"strings"
+)
+
+// enableVirtualTerminal is a no-op outside Windows, where terminals
+// interpret escape sequences natively.
+func enableVirtualTerminal(in, out *os.File) (restore func()) {
+	return func() {}
+}
+
+// unicodeLocale reports whether the locale, as set by the first of LC_ALL,
+// LC_CTYPE and LANG that is set, has a UTF-8 character set.
+func unicodeLocale() bool {
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := os.Getenv(name); v != "" {
+			v = strings.ToLower(v)
+			return strings.Contains(v, "utf-8") || strings.Contains(v, "utf8")
+		}
+	}
+	return false
+}
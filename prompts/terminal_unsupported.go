@@ -0,0 +1,21 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris && !zos && !windows
+// +build !aix,!darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris,!zos,!windows
+
+package prompts
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+// waitInput reports f as always readable where it cannot be polled,
+// so reads block until input arrives.
+func waitInput(f *os.File, timeout time.Duration) (bool, error) {
+	return true, nil
+}
+
+// openTTY fails where there is no known way to open the terminal.
+func openTTY() (in, out *os.File, err error) {
+	return nil, nil, errors.New("prompts: cannot open the terminal on this platform")
+}
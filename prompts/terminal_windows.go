@@ -0,0 +1,112 @@
+//go:build windows
+// +build windows
+
+package prompts
+
+import (
+	"os"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// enableVirtualTerminal switches the console to virtual terminal mode,
+// so that cmd.exe and PowerShell interpret ANSI escape sequences on output
+// and report special keys as escape sequences on input.
+// The returned function restores the previous console modes.
+func enableVirtualTerminal(in, out *os.File) (restore func()) {
+	var restores []func()
+	enable := func(f *os.File, flags uint32) {
+		h := windows.Handle(f.Fd())
+		var mode uint32
+		if err := windows.GetConsoleMode(h, &mode); err != nil {
+			return
+		}
+		if err := windows.SetConsoleMode(h, mode|flags); err != nil {
+			return
+		}
+		restores = append(restores, func() { windows.SetConsoleMode(h, mode) })
+	}
+	enable(in, windows.ENABLE_VIRTUAL_TERMINAL_INPUT)
+	enable(out, windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING)
+
+	return func() {
+		for i := len(restores) - 1; i >= 0; i-- {
+			restores[i]()
+		}
+	}
+}
+
+// waitInput waits up to timeout for the console f to have pending input.
+// The console is signaled for focus, mouse and resize events as well,
+// which reading it skips, so those are discarded rather than reported as
+// input that would block the read.
+func waitInput(f *os.File, timeout time.Duration) (bool, error) {
+	h := windows.Handle(f.Fd())
+	ev, err := windows.WaitForSingleObject(h, uint32(timeout/time.Millisecond))
+	if err != nil || ev != windows.WAIT_OBJECT_0 {
+		return false, err
+	}
+	for {
+		var rec inputRecord
+		var n uint32
+		if r, _, err := procPeekConsoleInputW.Call(uintptr(h), uintptr(unsafe.Pointer(&rec)), 1, uintptr(unsafe.Pointer(&n))); r == 0 {
+			return false, err
+		}
+		if n == 0 {
+			return false, nil
+		}
+		if rec.typ == keyEvent && rec.keyDown != 0 && rec.char != 0 {
+			return true, nil
+		}
+		if r, _, err := procReadConsoleInputW.Call(uintptr(h), uintptr(unsafe.Pointer(&rec)), 1, uintptr(unsafe.Pointer(&n))); r == 0 {
+			return false, err
+		}
+	}
+}
+
+var (
+	kernel32              = windows.NewLazySystemDLL("kernel32.dll")
+	procPeekConsoleInputW = kernel32.NewProc("PeekConsoleInputW")
+	procReadConsoleInputW = kernel32.NewProc("ReadConsoleInputW")
+)
+
+// keyEvent is the type of the input records of keys.
+const keyEvent = 0x0001
+
+// inputRecord is an INPUT_RECORD, laid out as the KEY_EVENT_RECORD of
+// its union, the largest of its members. Only key presses producing
+// a character are read from the console: key releases and modifier keys
+// are not.
+type inputRecord struct {
+	typ        uint16
+	_          uint16
+	keyDown    int32
+	repeat     uint16
+	virtualKey uint16
+	scanCode   uint16
+	char       uint16
+	controls   uint32
+}
+
+// openTTY opens the console for input and output,
+// whether or not the standard streams are redirected.
+func openTTY() (in, out *os.File, err error) {
+	in, err = os.OpenFile("CONIN$", os.O_RDWR, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	out, err = os.OpenFile("CONOUT$", os.O_RDWR, 0)
+	if err != nil {
+		in.Close()
+		return nil, nil, err
+	}
+	return in, out, nil
+}
+
+// unicodeLocale reports true on Windows, whose consoles render Unicode
+// whatever the locale.
+func unicodeLocale() bool {
+	return true
+}
@@ -0,0 +1,282 @@
+package prompts
+
+import (
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// Theme controls how prompts look. SetTheme installs one for all prompts
+// and WithTheme for a single prompt.
+type Theme struct {
+	// Prefix is shown before the label of every prompt, e.g. "? ".
+	Prefix string
+	// Pointer marks the highlighted option of Select and Checkboxes.
+	Pointer string
+	// Checked and Unchecked mark the options of Checkboxes and the nodes
+	// of TreeSelect, and Mixed the nodes of TreeSelect only some of whose
+	// children are checked.
+	Checked, Unchecked, Mixed string
+	// Expanded and Collapsed mark the directories of DirectoryTree.
+	Expanded, Collapsed string
+	// Above and Below mark that there are more options than fit on the
+	// page of Select and Checkboxes before and after the ones shown.
+	Above, Below string
+
+	// Question is the style of labels.
+	Question Style
+	// Answer is the style of answers once they are given.
+	Answer Style
+	// Highlight is the style of the highlighted option.
+	Highlight Style
+	// Error is the style of the reasons answers are rejected.
+	Error Style
+	// Hint is the style of placeholders and other hints.
+	Hint Style
+	// Match is the style of the characters of options matching the text
+	// typed to filter them, and of suggestions of Autocomplete matching
+	// the text typed, added to the style of the option.
+	Match Style
+
+	// Templates, if set, render the labels, options and answers of
+	// prompts instead.
+	Templates Templates
+
+	// colors is what the output of the prompt can show.
+	colors colorProfile
+	// ascii restricts the theme to ASCII symbols.
+	ascii bool
+	// catalog translates the text prompts show of their own.
+	catalog Catalog
+}
+
+// DefaultTheme returns the theme prompts use unless told otherwise.
+func DefaultTheme() Theme {
+	return Theme{
+		Pointer:   ">",
+		Checked:   "[x]",
+		Unchecked: "[ ]",
+		Mixed:     "[-]",
+		Expanded:  "-",
+		Collapsed: "+",
+		Above:     "^",
+		Below:     "v",
+		Highlight: Style{Color: "cyan"},
+		Error:     Style{Color: "red"},
+		Hint:      Style{Dim: true},
+		Match:     Style{Color: "yellow", Bold: true},
+	}
+}
+
+var (
+	themeMu sync.Mutex
+	theme   = DefaultTheme()
+)
+
+// SetTheme makes t the theme of all prompts not given WithTheme.
+func SetTheme(t Theme) {
+	themeMu.Lock()
+	defer themeMu.Unlock()
+	theme = t
+}
+
+func currentTheme() Theme {
+	themeMu.Lock()
+	defer themeMu.Unlock()
+	return theme
+}
+
+// WithASCII makes a prompt only show ASCII symbols, for terminals and
+// fonts that cannot render others. Symbols of the theme that are not ASCII
+// are replaced by those of DefaultTheme, and so is the Mask of passwords.
+// This is the default when the locale does not use UTF-8, e.g. when LANG
+// is "C" or unset, except on Windows.
+func WithASCII() Option {
+	return optionFunc(func(c *config) { c.theme.ascii = true })
+}
+
+// toASCII replaces the symbols of t that are not ASCII.
+func (t *Theme) toASCII() {
+	def := DefaultTheme()
+	for _, s := range []struct{ symbol, fallback *string }{
+		{&t.Prefix, &def.Prefix},
+		{&t.Pointer, &def.Pointer},
+		{&t.Checked, &def.Checked},
+		{&t.Unchecked, &def.Unchecked},
+		{&t.Mixed, &def.Mixed},
+		{&t.Expanded, &def.Expanded},
+		{&t.Collapsed, &def.Collapsed},
+		{&t.Above, &def.Above},
+		{&t.Below, &def.Below},
+	} {
+		if !isASCII(*s.symbol) {
+			*s.symbol = *s.fallback
+		}
+	}
+	t.ascii = true
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// WithTheme makes a prompt look as t says instead of the installed theme.
+func WithTheme(t Theme) Option {
+	return optionFunc(func(c *config) {
+		t.ascii = c.theme.ascii
+		c.theme = t
+	})
+}
+
+// label renders the label of a prompt.
+func (t *Theme) label(label string) string {
+	if s, ok := t.execute(t.Templates.Question, QuestionData{Prefix: t.Prefix, Label: label}); ok {
+		return s
+	}
+	return t.Prefix + t.render(t.Question, label)
+}
+
+// hinted renders the label of a prompt followed by hint, dimmed, unless
+// hint is empty.
+func (t *Theme) hinted(label, hint string) string {
+	if hint == "" {
+		return t.label(label)
+	}
+	return t.label(label) + " " + t.render(t.Hint, hint)
+}
+
+// answered renders a prompt once it has its answer.
+func (t *Theme) answered(label, answer string) string {
+	if s, ok := t.execute(t.Templates.Answer, AnswerData{Prefix: t.Prefix, Label: label, Answer: answer}); ok {
+		return s
+	}
+	return t.label(label) + " " + t.render(t.Answer, answer)
+}
+
+// option renders an option of a list, highlighted if it is under the
+// pointer, with the mark shown between the pointer and the option and the
+// runes of the option at the matched positions in the Match style.
+func (t *Theme) option(s, mark string, matched []int, highlighted bool) string {
+	if t.Templates.Option != nil {
+		data := OptionData{
+			Option:      s,
+			Text:        t.matched(s, Style{}, matched),
+			Mark:        mark,
+			Pointer:     t.Pointer,
+			Highlighted: highlighted,
+		}
+		if s, ok := t.execute(t.Templates.Option, data); ok {
+			return s
+		}
+	}
+	style := Style{}
+	prefix := strings.Repeat(" ", textWidth(t.Pointer)+1)
+	if highlighted {
+		style, prefix = t.Highlight, t.Pointer+" "
+	}
+	if mark != "" {
+		prefix += mark + " "
+	}
+	if len(matched) == 0 {
+		return t.render(style, prefix+s)
+	}
+	return t.render(style, prefix) + t.matched(s, style, matched)
+}
+
+// matched renders s in style with the runes at the matched positions
+// in the Match style added.
+func (t *Theme) matched(s string, style Style, matched []int) string {
+	if len(matched) == 0 {
+		return t.render(style, s)
+	}
+	var b strings.Builder
+	match := style.with(t.Match)
+	// Characters made of several runes are matched as a whole, so that
+	// styles do not split them.
+	var run strings.Builder
+	runMatch := false
+	flush := func() {
+		if runMatch {
+			b.WriteString(t.render(match, run.String()))
+		} else {
+			b.WriteString(t.render(style, run.String()))
+		}
+		run.Reset()
+	}
+	g := uniseg.NewGraphemes(s)
+	for i := 0; g.Next(); {
+		n := len(g.Runes())
+		isMatch := false
+		for len(matched) > 0 && matched[0] < i+n {
+			isMatch, matched = true, matched[1:]
+		}
+		if isMatch != runMatch && run.Len() > 0 {
+			flush()
+		}
+		runMatch = isMatch
+		run.WriteString(g.Str())
+		i += n
+	}
+	if run.Len() > 0 {
+		flush()
+	}
+	return b.String()
+}
+
+// errorLine renders the error of a rejected answer below a prompt.
+func (t *Theme) errorLine(err error) string {
+	return t.render(t.Error, t.errorText(err))
+}
+
+// Style is how a piece of text is shown.
+type Style struct {
+	Color                Color
+	Bold, Dim, Underline bool
+}
+
+// with returns st with the colors and attributes set in o added.
+func (st Style) with(o Style) Style {
+	if o.Color != "" {
+		st.Color = o.Color
+	}
+	st.Bold = st.Bold || o.Bold
+	st.Dim = st.Dim || o.Dim
+	st.Underline = st.Underline || o.Underline
+	return st
+}
+
+// render returns s in the style st,
+// as far as the colors of the output allow.
+func (t *Theme) render(st Style, s string) string {
+	colors := t.colors
+	if colors == colorAuto {
+		colors = detectColors(currentOutput())
+	}
+	if colors == colorNone {
+		return s
+	}
+	var params []string
+	if st.Bold {
+		params = append(params, "1")
+	}
+	if st.Dim {
+		params = append(params, "2")
+	}
+	if st.Underline {
+		params = append(params, "4")
+	}
+	if p := st.Color.sgr(colors); p != "" {
+		params = append(params, p)
+	}
+	if len(params) == 0 {
+		return s
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m" + s + "\x1b[0m"
+}
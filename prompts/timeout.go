@@ -0,0 +1,106 @@
+package prompts
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// WithTimeout makes a prompt submit its answer as if Enter were pressed
+// once no key has been pressed for d, so that it takes its default answer
+// unless the user answers first. The seconds left are counted down below
+// the prompt, e.g. "defaulting to Yes in 8s". It only applies to prompts
+// asked on a terminal.
+func WithTimeout(d time.Duration) Option {
+	return optionFunc(func(c *config) { c.timeout = d })
+}
+
+// errTimedOut is returned by the tick of a timeoutModel
+// to make the prompt submit its answer.
+var errTimedOut = errors.New("prompts: timed out")
+
+// defaulter is a model that can say what it answers when Enter is pressed.
+type defaulter interface {
+	defaultAnswer() string
+}
+
+// timeoutModel counts down to submitting the answer of a model
+// while no key is pressed.
+type timeoutModel struct {
+	model
+	theme   *Theme
+	timeout time.Duration
+	// answer says what the model answers, if it can.
+	answer defaulter
+	// inner is the ticker of the model, if it is one.
+	inner    ticker
+	deadline time.Time
+	// left is the number of seconds shown as left.
+	left int
+	// expired is set once the answer has been submitted.
+	expired bool
+}
+
+func newTimeoutModel(m, inner model, t *Theme, timeout time.Duration) *timeoutModel {
+	tm := &timeoutModel{model: m, theme: t, timeout: timeout}
+	tm.answer, _ = inner.(defaulter)
+	tm.inner, _ = inner.(ticker)
+	tm.restart()
+	return tm
+}
+
+func (m *timeoutModel) restart() {
+	m.deadline = time.Now().Add(m.timeout)
+	m.left = m.secondsLeft()
+}
+
+func (m *timeoutModel) secondsLeft() int {
+	return int(math.Ceil(time.Until(m.deadline).Seconds()))
+}
+
+func (m *timeoutModel) update(k key) (bool, error) {
+	if !m.expired {
+		m.restart()
+	}
+	return m.model.update(k)
+}
+
+func (m *timeoutModel) tick() (bool, error) {
+	var changed bool
+	if m.inner != nil {
+		var err error
+		if changed, err = m.inner.tick(); err != nil {
+			return changed, err
+		}
+	}
+	if m.expired {
+		return changed, nil
+	}
+	if left := m.secondsLeft(); left <= 0 {
+		m.expired = true
+		return true, errTimedOut
+	} else if left != m.left {
+		m.left, changed = left, true
+	}
+	return changed, nil
+}
+
+func (m *timeoutModel) captures(k key) bool {
+	c, ok := m.model.(keyCapturer)
+	return ok && c.captures(k)
+}
+
+func (m *timeoutModel) view() frame {
+	f := m.model.view()
+	if m.expired {
+		return f
+	}
+	s := m.theme.textf("answering in %ds", m.left)
+	if m.answer != nil {
+		if a := m.answer.defaultAnswer(); a != "" {
+			s = m.theme.textf("defaulting to %s in %ds", a, m.left)
+		}
+	}
+	f.lines = append(f.lines, m.theme.render(m.theme.Hint, s))
+	return f
+}
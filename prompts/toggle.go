@@ -0,0 +1,133 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ToggleOptions configures Toggle.
+type ToggleOptions struct {
+	// On and Off name the states of the switch.
+	// Empty means "enabled" and "disabled".
+	On, Off string
+}
+
+func (o ToggleOptions) apply(c *config) { c.toggle = o }
+
+// Toggle asks to switch a setting on or off using the label, starting
+// from def, and returns whether it is on. Unlike Confirm, it shows both
+// states as a switch, which Space, Left and Right flip. Enter accepts
+// the state.
+//
+// If the standard input is not a terminal, the next line read from it
+// must name a state, or be yes, no, on or off, or empty for def.
+//
+// It returns ErrInterrupted if the user presses Ctrl+C.
+func Toggle(label string, def bool, opts ...Option) (bool, error) {
+	return ToggleContext(context.Background(), label, def, opts...)
+}
+
+// ToggleContext is like Toggle but gives up when ctx is done,
+// returning ctx.Err().
+func ToggleContext(ctx context.Context, label string, def bool, opts ...Option) (bool, error) {
+	c := newConfig(label, opts)
+	if b, ok := c.initial.(bool); ok {
+		def = b
+	}
+	m := &toggleModel{label: label, theme: &c.theme, keys: &c.keymap, opts: c.toggle, value: def}
+	if m.opts.On == "" {
+		m.opts.On = c.theme.text("enabled")
+	}
+	if m.opts.Off == "" {
+		m.opts.Off = c.theme.text("disabled")
+	}
+	if err := run(ctx, c, m); err != nil {
+		return false, err
+	}
+	return m.value, nil
+}
+
+type toggleModel struct {
+	label string
+	theme *Theme
+	keys  *Keymap
+	opts  ToggleOptions
+	value bool
+	done  bool
+}
+
+// state names the state on or off.
+func (m *toggleModel) state(on bool) string {
+	if on {
+		return m.opts.On
+	}
+	return m.opts.Off
+}
+
+func (m *toggleModel) defaultAnswer() string {
+	return m.state(m.value)
+}
+
+func (m *toggleModel) result() interface{} {
+	return m.value
+}
+
+func (m *toggleModel) prompt() string {
+	return m.theme.label(m.label) + fmt.Sprintf(" [%s/%s] (%s) ", m.opts.On, m.opts.Off, m.state(m.value))
+}
+
+func (m *toggleModel) answer(s string) (bool, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "":
+	case strings.EqualFold(s, m.opts.On) || strings.EqualFold(s, "on"):
+		m.value = true
+	case strings.EqualFold(s, m.opts.Off) || strings.EqualFold(s, "off"):
+		m.value = false
+	default:
+		on, ok := parseYesNo(s)
+		if !ok {
+			return false, nil
+		}
+		m.value = on
+	}
+	m.done = true
+	return true, nil
+}
+
+func (m *toggleModel) update(k key) (bool, error) {
+	switch {
+	case bound(m.keys.Submit, k):
+		m.done = true
+	case bound(m.keys.Toggle, k) || bound(m.keys.Left, k) || bound(m.keys.Right, k):
+		m.value = !m.value
+	}
+	return m.done, nil
+}
+
+func (m *toggleModel) keyHelp() ([]keyAction, bool) {
+	km := m.keys
+	return []keyAction{
+		act("switch the answer", km.Toggle, km.Left, km.Right),
+		act("accept the answer", km.Submit),
+	}, false
+}
+
+func (m *toggleModel) view() frame {
+	t := m.theme
+	if m.done {
+		return frame{lines: []string{t.answered(m.label, m.state(m.value))}}
+	}
+	on, off := "◉", "○"
+	if t.ascii {
+		on, off = "(*)", "( )"
+	}
+	switchState := func(state bool) string {
+		if state == m.value {
+			return t.render(t.Highlight, on+" "+m.state(state))
+		}
+		return t.render(t.Hint, off+" "+m.state(state))
+	}
+	return frame{lines: []string{t.label(m.label) + " " + switchState(true) + t.render(t.Hint, " / ") + switchState(false)}}
+}
@@ -0,0 +1,305 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// TransferOptions configures Transfer.
+type TransferOptions struct {
+	// PageSize is the number of options shown at once in each list.
+	// Zero means the default page size.
+	PageSize int
+	// Default lists the options chosen to begin with, in order.
+	Default []string
+	// AvailableTitle and ChosenTitle are shown above the lists.
+	// Empty means "Available" and "Chosen".
+	AvailableTitle, ChosenTitle string
+}
+
+func (o TransferOptions) apply(c *config) { c.transfer = o }
+
+// Transfer asks to choose some of the options, in order, using the label
+// and returns the chosen ones. The options are listed side by side as
+// available and chosen ones. Right moves the available option under the
+// cursor to the end of the chosen ones and Left moves the chosen option
+// under the cursor back. Tab moves the cursor to the other list, the up and
+// down arrows move it within the list and Ctrl+Up and Ctrl+Down move the
+// chosen option under it. Enter accepts the chosen options, provided they
+// pass the validators given with WithValidator, which are given the
+// options joined by commas.
+//
+// If the standard input is not a terminal, the next line read from it
+// must list the chosen options separated by commas. An empty line keeps
+// the options chosen by default.
+//
+// It returns ErrNoOptions if options is empty and ErrInterrupted if the
+// user presses Ctrl+C.
+func Transfer(label string, options []string, opts ...Option) ([]string, error) {
+	return TransferContext(context.Background(), label, options, opts...)
+}
+
+// TransferContext is like Transfer but gives up when ctx is done,
+// returning ctx.Err().
+func TransferContext(ctx context.Context, label string, options []string, opts ...Option) ([]string, error) {
+	c := newConfig(label, opts)
+	if len(options) == 0 {
+		return nil, ErrNoOptions
+	}
+	m := &transferModel{
+		label:      label,
+		theme:      &c.theme,
+		keys:       &c.keymap,
+		opts:       c.transfer,
+		validators: c.validators,
+		options:    options,
+	}
+	if m.opts.PageSize <= 0 {
+		m.opts.PageSize = defaultPageSize
+	}
+	if m.opts.AvailableTitle == "" {
+		m.opts.AvailableTitle = c.theme.text("Available")
+	}
+	if m.opts.ChosenTitle == "" {
+		m.opts.ChosenTitle = c.theme.text("Chosen")
+	}
+	chosen := m.opts.Default
+	if initial, ok := c.initial.([]string); ok {
+		chosen = initial
+	}
+	if err := m.choose(chosen); err != nil {
+		return nil, fmt.Errorf("prompts: %w", err)
+	}
+	if err := run(ctx, c, m); err != nil {
+		return nil, err
+	}
+	return m.picked(), nil
+}
+
+type transferModel struct {
+	label      string
+	theme      *Theme
+	keys       *Keymap
+	opts       TransferOptions
+	validators []Validator
+	options    []string
+	// panes are the indexes of the available options, in the order of
+	// options, and of the chosen ones, in the order they were chosen.
+	panes [2][]int
+	// focus is the pane with the cursor, cursor and top are indexes
+	// into each pane.
+	focus  int
+	cursor [2]int
+	top    [2]int
+	// err is why the last answer was rejected.
+	err  error
+	done bool
+}
+
+const (
+	availablePane = iota
+	chosenPane
+)
+
+// choose makes list the chosen options.
+func (m *transferModel) choose(list []string) error {
+	chosen := make([]bool, len(m.options))
+	var panes [2][]int
+	for _, o := range list {
+		i := findOption(m.options, o)
+		if i < 0 {
+			return notAnOption(o)
+		}
+		if !chosen[i] {
+			chosen[i] = true
+			panes[chosenPane] = append(panes[chosenPane], i)
+		}
+	}
+	for i := range m.options {
+		if !chosen[i] {
+			panes[availablePane] = append(panes[availablePane], i)
+		}
+	}
+	m.panes = panes
+	m.cursor, m.top = [2]int{}, [2]int{}
+	return nil
+}
+
+func (m *transferModel) picked() []string {
+	picked := []string{}
+	for _, i := range m.panes[chosenPane] {
+		picked = append(picked, m.options[i])
+	}
+	return picked
+}
+
+// transfer moves the option under the cursor of the focused pane
+// to the other one.
+func (m *transferModel) transfer() {
+	from := m.panes[m.focus]
+	if len(from) == 0 {
+		return
+	}
+	c := m.cursor[m.focus]
+	i := from[c]
+	m.panes[m.focus] = append(from[:c:c], from[c+1:]...)
+	if m.focus == availablePane {
+		m.panes[chosenPane] = append(m.panes[chosenPane], i)
+	} else {
+		// Put the option back where it was among the available ones.
+		to := m.panes[availablePane]
+		j := 0
+		for j < len(to) && to[j] < i {
+			j++
+		}
+		m.panes[availablePane] = append(to[:j:j], append([]int{i}, to[j:]...)...)
+	}
+	if c >= len(m.panes[m.focus]) && c > 0 {
+		m.cursor[m.focus] = c - 1
+	}
+}
+
+func (m *transferModel) result() interface{} {
+	return m.picked()
+}
+
+func (m *transferModel) prompt() string {
+	if picked := m.picked(); len(picked) > 0 {
+		return m.theme.label(m.label) + " (" + strings.Join(picked, ", ") + ") "
+	}
+	return m.theme.label(m.label) + " "
+}
+
+func (m *transferModel) answer(s string) (bool, error) {
+	if strings.TrimSpace(s) == "" {
+		return true, m.answerList(m.picked())
+	}
+	var list []string
+	for _, o := range strings.Split(s, ",") {
+		if o = strings.TrimSpace(o); o != "" {
+			list = append(list, o)
+		}
+	}
+	return true, m.answerList(list)
+}
+
+func (m *transferModel) answerList(list []string) error {
+	panes := m.panes
+	if err := m.choose(list); err != nil {
+		return err
+	}
+	if err := validate(m.validators, strings.Join(m.picked(), ",")); err != nil {
+		m.panes = panes
+		return err
+	}
+	m.done = true
+	return nil
+}
+
+func (m *transferModel) rejected() error {
+	return m.err
+}
+
+func (m *transferModel) update(k key) (bool, error) {
+	pane := m.panes[m.focus]
+	n := len(pane)
+	c := &m.cursor[m.focus]
+	switch {
+	case bound(m.keys.Submit, k):
+		m.err = validate(m.validators, strings.Join(m.picked(), ","))
+		m.done = m.err == nil
+		return m.done, nil
+	case bound(m.keys.SwitchPane, k):
+		m.focus = 1 - m.focus
+	case bound(m.keys.Right, k) && m.focus == availablePane,
+		bound(m.keys.Left, k) && m.focus == chosenPane:
+		m.transfer()
+	case n == 0:
+		return false, nil
+	case m.focus == chosenPane && bound(m.keys.MoveUp, k):
+		if *c > 0 {
+			pane[*c-1], pane[*c] = pane[*c], pane[*c-1]
+			*c--
+		}
+	case m.focus == chosenPane && bound(m.keys.MoveDown, k):
+		if *c < n-1 {
+			pane[*c+1], pane[*c] = pane[*c], pane[*c+1]
+			*c++
+		}
+	case bound(m.keys.Up, k):
+		*c = (*c - 1 + n) % n
+	case bound(m.keys.Down, k):
+		*c = (*c + 1) % n
+	case bound(m.keys.PageUp, k) || bound(m.keys.PageDown, k):
+		*c = pageMove(m.keys, k, *c, m.opts.PageSize, n)
+	default:
+		return false, nil
+	}
+	m.err = nil
+	for p := range m.panes {
+		m.top[p] = scrollTop(m.top[p], m.cursor[p], m.opts.PageSize)
+	}
+	return false, nil
+}
+
+func (m *transferModel) keyHelp() ([]keyAction, bool) {
+	km := m.keys
+	return []keyAction{
+		act("move the cursor", km.Up, km.Down),
+		act("move by a page", km.PageUp, km.PageDown),
+		act("switch between the lists", km.SwitchPane),
+		act("move the option to the other list", km.Left, km.Right),
+		act("reorder the chosen options", km.MoveUp, km.MoveDown),
+		act("accept the chosen options", km.Submit),
+	}, false
+}
+
+// paneLines renders the title and a page of the options of pane p.
+func (m *transferModel) paneLines(p int, title string) []string {
+	t := m.theme
+	pane := m.panes[p]
+	lines := []string{t.render(Style{Bold: true}, title)}
+	if len(pane) == 0 {
+		return append(lines, t.option(t.render(t.Hint, t.text("(none)")), "", nil, false))
+	}
+	return append(lines, t.page(m.top[p], m.opts.PageSize, len(pane), func(i int) string {
+		return t.option(m.options[pane[i]], "", nil, p == m.focus && i == m.cursor[p])
+	})...)
+}
+
+func (m *transferModel) view() frame {
+	t := m.theme
+	if m.done {
+		return frame{lines: []string{t.answered(m.label, strings.Join(m.picked(), ", "))}}
+	}
+	left := m.paneLines(availablePane, m.opts.AvailableTitle)
+	right := m.paneLines(chosenPane, m.opts.ChosenTitle)
+	// The available list is as wide as its widest option could be.
+	width := textWidth(m.opts.AvailableTitle)
+	for _, o := range m.options {
+		if w := textWidth(t.option(o, "", nil, false)); w > width {
+			width = w
+		}
+	}
+	for _, s := range left {
+		if w := textWidth(s); w > width {
+			width = w
+		}
+	}
+	lines := []string{t.label(m.label)}
+	for i := 0; i < len(left) || i < len(right); i++ {
+		var l, r string
+		if i < len(left) {
+			l = left[i]
+		}
+		if i < len(right) {
+			r = right[i]
+		}
+		lines = append(lines, strings.TrimRight(l+strings.Repeat(" ", width-textWidth(l))+"    "+r, " "))
+	}
+	if m.err != nil {
+		lines = append(lines, t.errorLine(m.err))
+	}
+	return frame{lines: lines}
+}
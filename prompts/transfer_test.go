@@ -0,0 +1,56 @@
+package prompts_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/prompttest"
+)
+
+func TestTransfer(t *testing.T) {
+	tests := []struct {
+		name string
+		opts prompts.TransferOptions
+		keys string
+		want string
+	}{
+		{"none", prompts.TransferOptions{}, prompttest.Enter, ""},
+		{"moved right", prompts.TransferOptions{}, prompttest.Down + prompttest.Right + prompttest.Right + prompttest.Enter, "b,c"},
+		{"default", prompts.TransferOptions{Default: []string{"c", "a"}}, prompttest.Enter, "c,a"},
+		{"moved back", prompts.TransferOptions{Default: []string{"c", "a"}}, prompttest.Tab + prompttest.Left + prompttest.Enter, "a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term := prompttest.New(t)
+			res := prompttest.Start(term, func(o prompts.Option) ([]string, error) {
+				return prompts.Transfer("Pick?", []string{"a", "b", "c"}, tt.opts, o)
+			})
+			term.WaitFor("Chosen")
+			term.Send(tt.keys)
+			got, err := res.Wait()
+			if err != nil || strings.Join(got, ",") != tt.want {
+				t.Fatalf("Transfer() = %q, %v, want [%s], nil", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransferLines(t *testing.T) {
+	var out strings.Builder
+	got, err := prompts.Transfer("Pick?", []string{"a", "b", "c"},
+		prompts.WithInput(strings.NewReader("d\nc, A\n")), prompts.WithOutput(&out))
+	if err != nil || strings.Join(got, ",") != "c,a" {
+		t.Fatalf("Transfer() = %q, %v, want [c a], nil", got, err)
+	}
+	if want := "Pick? \n\"d\" is not one of the options\nPick? \n"; out.String() != want {
+		t.Errorf("output is %q, want %q", out.String(), want)
+	}
+}
+
+func TestTransferDefaultNotAnOption(t *testing.T) {
+	_, err := prompts.Transfer("Pick?", []string{"a"}, prompts.TransferOptions{Default: []string{"z"}})
+	if err == nil || err.Error() != `prompts: "z" is not one of the options` {
+		t.Fatalf("Transfer() returned %v, want the default rejected", err)
+	}
+}
@@ -0,0 +1,42 @@
+package prompts
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Transform cleans up an answer before it is validated and returned.
+type Transform func(string) string
+
+// WithTransform makes a text or password prompt apply t to the answer
+// after it is entered and before it is validated. When given more than
+// once, the transforms are applied in order.
+func WithTransform(t Transform) Option {
+	return optionFunc(func(c *config) { c.transforms = append(c.transforms, t) })
+}
+
+// TrimSpace removes leading and trailing white space.
+func TrimSpace(s string) string {
+	return strings.TrimSpace(s)
+}
+
+// ToLower maps all letters to lower case.
+func ToLower(s string) string {
+	return strings.ToLower(s)
+}
+
+// NFC normalizes s to Unicode Normalization Form C, so that characters
+// typed as a base letter and combining marks compare equal to their
+// precomposed forms.
+func NFC(s string) string {
+	return norm.NFC.String(s)
+}
+
+// transform applies the transforms in ts to s in order.
+func transform(ts []Transform, s string) string {
+	for _, t := range ts {
+		s = t(s)
+	}
+	return s
+}
@@ -0,0 +1,359 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// TreeNode is a node of the tree of TreeSelect.
+type TreeNode struct {
+	Label string
+	// Value identifies the node in the answer. Empty means the labels
+	// of the nodes on the path to it joined by slashes, e.g. "web/api".
+	Value    string
+	Children []TreeNode
+}
+
+// TreeSelectOptions configures TreeSelect.
+type TreeSelectOptions struct {
+	// PageSize is the number of nodes shown at once.
+	// Zero means the default page size.
+	PageSize int
+	// Default lists the values of the nodes checked to begin with.
+	Default []string
+	// Expanded shows the tree with all its nodes expanded to begin with.
+	Expanded bool
+}
+
+func (o TreeSelectOptions) apply(c *config) { c.treeSelect = o }
+
+// TreeSelect asks to check any number of the leaves of a tree using the
+// label and returns the values of the checked ones, in the order of the
+// tree. A node is checked when all its children are, and shown as mixed
+// when only some are. Up and Down move through the nodes, Right expands
+// a node or moves to its first child once expanded, and Left collapses it
+// or moves to its parent. Space checks or unchecks the node under the
+// cursor with all the nodes under it. Enter accepts the checked leaves,
+// provided they pass the validators given with WithValidator, which are
+// given the values joined by commas.
+//
+// If the standard input is not a terminal, the next line read from it
+// must list the values of the checked nodes separated by commas, where
+// a node stands for all the leaves under it. An empty line keeps the
+// nodes checked by default.
+//
+// It returns ErrNoOptions if nodes is empty and ErrInterrupted if the
+// user presses Ctrl+C.
+func TreeSelect(label string, nodes []TreeNode, opts ...Option) ([]string, error) {
+	return TreeSelectContext(context.Background(), label, nodes, opts...)
+}
+
+// TreeSelectContext is like TreeSelect but gives up when ctx is done,
+// returning ctx.Err().
+func TreeSelectContext(ctx context.Context, label string, nodes []TreeNode, opts ...Option) ([]string, error) {
+	c := newConfig(label, opts)
+	if len(nodes) == 0 {
+		return nil, ErrNoOptions
+	}
+	m := &treeSelectModel{
+		label:      label,
+		theme:      &c.theme,
+		keys:       &c.keymap,
+		validators: c.validators,
+		pageSize:   c.treeSelect.PageSize,
+	}
+	if m.pageSize <= 0 {
+		m.pageSize = defaultPageSize
+	}
+	m.maxPage = m.pageSize
+	m.roots = buildTree(nodes, nil, c.treeSelect.Expanded)
+	checked := c.treeSelect.Default
+	if initial, ok := c.initial.([]string); ok {
+		checked = initial
+	}
+	if err := m.check(checked); err != nil {
+		return nil, fmt.Errorf("prompts: %w", err)
+	}
+	m.visible = flattenTree(m.roots, nil)
+	if err := run(ctx, c, m); err != nil {
+		return nil, err
+	}
+	return m.picked(), nil
+}
+
+// treeNode is a node of the tree of TreeSelect as shown.
+type treeNode struct {
+	label, value string
+	depth        int
+	parent       *treeNode
+	children     []*treeNode
+	expanded     bool
+	// checked is whether a leaf is checked.
+	checked bool
+}
+
+func buildTree(nodes []TreeNode, parent *treeNode, expanded bool) []*treeNode {
+	var tree []*treeNode
+	for _, n := range nodes {
+		t := &treeNode{label: n.Label, value: n.Value, parent: parent, expanded: expanded}
+		if parent != nil {
+			t.depth = parent.depth + 1
+		}
+		if t.value == "" {
+			t.value = t.path()
+		}
+		t.children = buildTree(n.Children, t, expanded)
+		tree = append(tree, t)
+	}
+	return tree
+}
+
+// path returns the labels of the nodes on the path to n joined by slashes.
+func (n *treeNode) path() string {
+	if n.parent == nil {
+		return n.label
+	}
+	return n.parent.path() + "/" + n.label
+}
+
+// flattenTree appends the nodes shown of tree to list.
+func flattenTree(tree []*treeNode, list []*treeNode) []*treeNode {
+	for _, n := range tree {
+		list = append(list, n)
+		if n.expanded {
+			list = flattenTree(n.children, list)
+		}
+	}
+	return list
+}
+
+// state returns how many of the leaves under n, or n itself if it is
+// a leaf, are checked out of how many.
+func (n *treeNode) state() (checked, leaves int) {
+	if len(n.children) == 0 {
+		if n.checked {
+			return 1, 1
+		}
+		return 0, 1
+	}
+	for _, c := range n.children {
+		cc, cl := c.state()
+		checked, leaves = checked+cc, leaves+cl
+	}
+	return checked, leaves
+}
+
+// set checks or unchecks the leaves under n.
+func (n *treeNode) set(checked bool) {
+	n.checked = checked
+	for _, c := range n.children {
+		c.set(checked)
+	}
+}
+
+// checkedLeaves appends the values of the checked leaves of tree to list.
+func checkedLeaves(tree []*treeNode, list []string) []string {
+	for _, n := range tree {
+		if len(n.children) == 0 && n.checked {
+			list = append(list, n.value)
+		}
+		list = checkedLeaves(n.children, list)
+	}
+	return list
+}
+
+// findNode returns the node of tree with the value s,
+// ignoring case if there is no exact match, or nil.
+func findNode(tree []*treeNode, s string, fold bool) *treeNode {
+	for _, n := range tree {
+		if n.value == s || fold && strings.EqualFold(n.value, s) {
+			return n
+		}
+		if found := findNode(n.children, s, fold); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+type treeSelectModel struct {
+	label      string
+	theme      *Theme
+	keys       *Keymap
+	validators []Validator
+	roots      []*treeNode
+	// visible holds the nodes of expanded nodes, in order.
+	visible  []*treeNode
+	cursor   int
+	top      int
+	pageSize int
+	// maxPage is the page size configured, as for optionList.
+	maxPage int
+	// err is why the last answer was rejected.
+	err  error
+	done bool
+}
+
+// check makes the leaves under the nodes with the values of list
+// the checked ones.
+func (m *treeSelectModel) check(list []string) error {
+	var nodes []*treeNode
+	for _, s := range list {
+		s = strings.TrimSpace(s)
+		n := findNode(m.roots, s, false)
+		if n == nil {
+			n = findNode(m.roots, s, true)
+		}
+		if n == nil {
+			return notAnOption(s)
+		}
+		nodes = append(nodes, n)
+	}
+	for _, n := range m.roots {
+		n.set(false)
+	}
+	for _, n := range nodes {
+		n.set(true)
+	}
+	return nil
+}
+
+func (m *treeSelectModel) picked() []string {
+	return checkedLeaves(m.roots, []string{})
+}
+
+// moveTo highlights the node n.
+func (m *treeSelectModel) moveTo(n *treeNode) {
+	m.visible = flattenTree(m.roots, nil)
+	for i, v := range m.visible {
+		if v == n {
+			m.cursor = i
+		}
+	}
+}
+
+func (m *treeSelectModel) result() interface{} {
+	return m.picked()
+}
+
+func (m *treeSelectModel) prompt() string {
+	if picked := m.picked(); len(picked) > 0 {
+		return m.theme.label(m.label) + " (" + strings.Join(picked, ", ") + ") "
+	}
+	return m.theme.label(m.label) + " "
+}
+
+func (m *treeSelectModel) answer(s string) (bool, error) {
+	if strings.TrimSpace(s) == "" {
+		return true, m.answerList(m.picked())
+	}
+	var list []string
+	for _, o := range strings.Split(s, ",") {
+		if o = strings.TrimSpace(o); o != "" {
+			list = append(list, o)
+		}
+	}
+	return true, m.answerList(list)
+}
+
+func (m *treeSelectModel) answerList(list []string) error {
+	picked := m.picked()
+	if err := m.check(list); err != nil {
+		return err
+	}
+	if err := validate(m.validators, strings.Join(m.picked(), ",")); err != nil {
+		m.check(picked)
+		return err
+	}
+	m.done = true
+	return nil
+}
+
+func (m *treeSelectModel) rejected() error {
+	return m.err
+}
+
+func (m *treeSelectModel) update(k key) (bool, error) {
+	n := m.visible[m.cursor]
+	switch {
+	case bound(m.keys.Up, k):
+		m.cursor = (m.cursor + len(m.visible) - 1) % len(m.visible)
+	case bound(m.keys.Down, k):
+		m.cursor = (m.cursor + 1) % len(m.visible)
+	case bound(m.keys.PageUp, k) || bound(m.keys.PageDown, k):
+		m.cursor = pageMove(m.keys, k, m.cursor, m.pageSize, len(m.visible))
+	case bound(m.keys.Right, k):
+		if len(n.children) > 0 && !n.expanded {
+			n.expanded = true
+			m.moveTo(n)
+		} else if len(n.children) > 0 {
+			m.moveTo(n.children[0])
+		}
+	case bound(m.keys.Left, k):
+		if len(n.children) > 0 && n.expanded {
+			n.expanded = false
+			m.moveTo(n)
+		} else if n.parent != nil {
+			m.moveTo(n.parent)
+		}
+	case bound(m.keys.Toggle, k):
+		checked, leaves := n.state()
+		n.set(checked < leaves)
+	case bound(m.keys.Submit, k):
+		m.err = validate(m.validators, strings.Join(m.picked(), ","))
+		m.done = m.err == nil
+		return m.done, nil
+	default:
+		return false, nil
+	}
+	m.err = nil
+	m.top = scrollTop(m.top, m.cursor, m.pageSize)
+	return false, nil
+}
+
+func (m *treeSelectModel) keyHelp() ([]keyAction, bool) {
+	km := m.keys
+	return []keyAction{
+		act("move the cursor", km.Up, km.Down),
+		act("move by a page", km.PageUp, km.PageDown),
+		act("collapse or expand a node", km.Left, km.Right),
+		act("check or uncheck the node", km.Toggle),
+		act("accept the checked nodes", km.Submit),
+	}, false
+}
+
+func (m *treeSelectModel) resize(width, height int) {
+	m.pageSize = fitPage(m.maxPage, height, 1)
+	m.top = scrollTop(m.top, m.cursor, m.pageSize)
+}
+
+func (m *treeSelectModel) view() frame {
+	t := m.theme
+	if m.done {
+		return frame{lines: []string{t.answered(m.label, strings.Join(m.picked(), ", "))}}
+	}
+	lines := []string{t.label(m.label)}
+	lines = append(lines, t.page(m.top, m.pageSize, len(m.visible), func(i int) string {
+		n := m.visible[i]
+		expand := strings.Repeat(" ", textWidth(t.Collapsed))
+		if len(n.children) > 0 {
+			expand = t.Collapsed
+			if n.expanded {
+				expand = t.Expanded
+			}
+		}
+		check := t.Unchecked
+		switch checked, leaves := n.state(); {
+		case checked == leaves:
+			check = t.Checked
+		case checked > 0:
+			check = t.Mixed
+		}
+		return t.option(strings.Repeat("  ", n.depth)+expand+" "+check+" "+n.label, "", nil, i == m.cursor)
+	})...)
+	if m.err != nil {
+		lines = append(lines, t.errorLine(m.err))
+	}
+	return frame{lines: lines}
+}
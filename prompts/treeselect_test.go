@@ -0,0 +1,54 @@
+package prompts_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/prompttest"
+)
+
+var services = []prompts.TreeNode{
+	{Label: "web", Children: []prompts.TreeNode{{Label: "api"}, {Label: "ui"}}},
+	{Label: "db"},
+}
+
+func TestTreeSelect(t *testing.T) {
+	tests := []struct {
+		name string
+		opts prompts.TreeSelectOptions
+		keys string
+		want string
+	}{
+		{"none", prompts.TreeSelectOptions{}, prompttest.Enter, ""},
+		{"node", prompts.TreeSelectOptions{}, prompttest.Space + prompttest.Enter, "web/api,web/ui"},
+		{"leaf", prompts.TreeSelectOptions{}, prompttest.Right + prompttest.Down + prompttest.Down + prompttest.Space + prompttest.Enter, "web/ui"},
+		{"default", prompts.TreeSelectOptions{Default: []string{"db"}}, prompttest.Enter, "db"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term := prompttest.New(t)
+			res := prompttest.Start(term, func(o prompts.Option) ([]string, error) {
+				return prompts.TreeSelect("Services?", services, tt.opts, o)
+			})
+			term.WaitFor("db")
+			term.Send(tt.keys)
+			got, err := res.Wait()
+			if err != nil || strings.Join(got, ",") != tt.want {
+				t.Fatalf("TreeSelect() = %q, %v, want [%s], nil", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTreeSelectLines(t *testing.T) {
+	var out strings.Builder
+	got, err := prompts.TreeSelect("Services?", services,
+		prompts.WithInput(strings.NewReader("cache\nweb/ui, db\n")), prompts.WithOutput(&out))
+	if err != nil || strings.Join(got, ",") != "web/ui,db" {
+		t.Fatalf("TreeSelect() = %q, %v, want [web/ui db], nil", got, err)
+	}
+	if want := "Services? \n\"cache\" is not one of the options\nServices? \n"; out.String() != want {
+		t.Errorf("output is %q, want %q", out.String(), want)
+	}
+}
@@ -0,0 +1,64 @@
+package prompts
+
+import (
+	"errors"
+	"strings"
+)
+
+// Validator checks an answer and returns an error describing
+// what is wrong with it, if anything.
+type Validator func(string) error
+
+// All returns a Validator that passes when all of vs pass.
+// It fails with the error of the first one that does not.
+func All(vs ...Validator) Validator {
+	return func(s string) error {
+		for _, v := range vs {
+			if err := v(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Any returns a Validator that passes when at least one of vs passes.
+// Otherwise it fails with the errors of all of them.
+func Any(vs ...Validator) Validator {
+	return func(s string) error {
+		msgs := make([]string, 0, len(vs))
+		for _, v := range vs {
+			err := v(s)
+			if err == nil {
+				return nil
+			}
+			msgs = append(msgs, err.Error())
+		}
+		return errors.New(strings.Join(msgs, " or "))
+	}
+}
+
+// WithValidator makes a prompt check its answer with v. Until the answer
+// passes, the prompt shows the error and asks again. When given more than
+// once, all the validators must pass.
+func WithValidator(v Validator) Option {
+	return optionFunc(func(c *config) { c.validators = append(c.validators, v) })
+}
+
+// invalidAnswer is the error of a Validator that rejected an answer.
+type invalidAnswer struct {
+	err error
+}
+
+func (e *invalidAnswer) Error() string { return e.err.Error() }
+
+func (e *invalidAnswer) Unwrap() error { return e.err }
+
+// validate checks s with the validators in vs,
+// returning an *invalidAnswer if it does not pass.
+func validate(vs []Validator, s string) error {
+	if err := All(vs...)(s); err != nil {
+		return &invalidAnswer{err: err}
+	}
+	return nil
+}
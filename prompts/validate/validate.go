@@ -0,0 +1,220 @@
+// Package validate provides common validators for prompts.
+// They are given to prompts.WithValidator, or named in the validate
+// tags of struct forms, as parsed by Parse.
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Error is the error of the validators of this package. Its message is
+// kept apart from the values filling it in, so that prompts can show it
+// translated by their message catalog.
+type Error struct {
+	// Format is the message in English, as a format string such as
+	// "must be at least %d characters long".
+	Format string
+	// Args are the values filling in the format.
+	Args []interface{}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf(e.Format, e.Args...)
+}
+
+// newError returns the *Error of format filled in with args.
+func newError(format string, args ...interface{}) error {
+	return &Error{Format: format, Args: args}
+}
+
+// Required rejects answers that are empty or only hold white space.
+func Required() func(string) error {
+	return func(s string) error {
+		if strings.TrimSpace(s) == "" {
+			return newError("a value is required")
+		}
+		return nil
+	}
+}
+
+// Regexp rejects answers that do not match re with the message,
+// or with a generic one if message is empty.
+func Regexp(re *regexp.Regexp, message string) func(string) error {
+	if message == "" {
+		return func(s string) error {
+			if !re.MatchString(s) {
+				return newError("must match %s", re.String())
+			}
+			return nil
+		}
+	}
+	return func(s string) error {
+		if !re.MatchString(s) {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// Email rejects answers that are not a bare email address,
+// such as gopher@example.com.
+func Email() func(string) error {
+	return func(s string) error {
+		a, err := mail.ParseAddress(s)
+		if err != nil || a.Address != s {
+			return newError("must be a valid email address")
+		}
+		return nil
+	}
+}
+
+// URL rejects answers that are not absolute URLs with a host,
+// such as https://example.com/.
+func URL() func(string) error {
+	return func(s string) error {
+		u, err := url.Parse(s)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return newError("must be a valid URL")
+		}
+		return nil
+	}
+}
+
+// IntRange rejects answers that are not whole numbers
+// between min and max inclusive.
+func IntRange(min, max int) func(string) error {
+	return func(s string) error {
+		n, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return newError("must be a whole number")
+		}
+		if n < min || n > max {
+			return newError("must be between %d and %d", min, max)
+		}
+		return nil
+	}
+}
+
+// MinLength rejects answers shorter than n characters.
+func MinLength(n int) func(string) error {
+	return func(s string) error {
+		if utf8.RuneCountInString(s) < n {
+			return newError("must be at least %d characters long", n)
+		}
+		return nil
+	}
+}
+
+// MaxLength rejects answers longer than n characters.
+func MaxLength(n int) func(string) error {
+	return func(s string) error {
+		if utf8.RuneCountInString(s) > n {
+			return newError("must be at most %d characters long", n)
+		}
+		return nil
+	}
+}
+
+// Parse returns the validator described by spec, a comma-separated list of
+// rules that must all pass: required, email, url, min=N and max=N for the
+// length of the answer, and range=MIN:MAX for whole numbers. For example,
+// "required,max=40".
+func Parse(spec string) (func(string) error, error) {
+	var vs []func(string) error
+	for _, rule := range strings.Split(spec, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		v, err := parseRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("validate: %q: %w", rule, err)
+		}
+		vs = append(vs, v)
+	}
+	return func(s string) error {
+		for _, v := range vs {
+			if err := v(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil
+}
+
+// ParseCount returns the bounds on the number of choices described by
+// spec, for prompts picking several, such as Checkboxes: required means at
+// least one, and min=N and max=N at least and at most N. A max of zero
+// means no limit. Other rules are errors.
+func ParseCount(spec string) (min, max int, err error) {
+	for _, rule := range strings.Split(spec, ",") {
+		rule = strings.TrimSpace(rule)
+		name, arg := rule, ""
+		if i := strings.IndexByte(rule, '='); i >= 0 {
+			name, arg = rule[:i], rule[i+1:]
+		}
+		switch name {
+		case "":
+		case "required":
+			if min < 1 {
+				min = 1
+			}
+		case "min", "max":
+			n, err := strconv.Atoi(arg)
+			if err != nil {
+				return 0, 0, fmt.Errorf("validate: %q: needs a count", rule)
+			}
+			if name == "min" {
+				min = n
+			} else {
+				max = n
+			}
+		default:
+			return 0, 0, fmt.Errorf("validate: %q: does not apply to choices", rule)
+		}
+	}
+	return min, max, nil
+}
+
+func parseRule(rule string) (func(string) error, error) {
+	name, arg := rule, ""
+	if i := strings.IndexByte(rule, '='); i >= 0 {
+		name, arg = rule[:i], rule[i+1:]
+	}
+	switch name {
+	case "required":
+		return Required(), nil
+	case "email":
+		return Email(), nil
+	case "url":
+		return URL(), nil
+	case "min", "max":
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, errors.New("needs a length")
+		}
+		if name == "min" {
+			return MinLength(n), nil
+		}
+		return MaxLength(n), nil
+	case "range":
+		bounds := strings.SplitN(arg, ":", 2)
+		if len(bounds) != 2 {
+			return nil, errors.New("needs MIN:MAX")
+		}
+		min, err1 := strconv.Atoi(bounds[0])
+		max, err2 := strconv.Atoi(bounds[1])
+		if err1 != nil || err2 != nil {
+			return nil, errors.New("needs MIN:MAX")
+		}
+		return IntRange(min, max), nil
+	}
+	return nil, errors.New("unknown rule")
+}
@@ -0,0 +1,102 @@
+package validate_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/validate"
+)
+
+func TestValidators(t *testing.T) {
+	tests := []struct {
+		name  string
+		v     func(string) error
+		input string
+		want  string
+	}{
+		{"required", validate.Required(), "Gopher", ""},
+		{"required empty", validate.Required(), "  ", "a value is required"},
+		{"regexp", validate.Regexp(regexp.MustCompile(`^[a-z]+$`), ""), "Go", "must match ^[a-z]+$"},
+		{"regexp message", validate.Regexp(regexp.MustCompile(`^[a-z]+$`), "lower case only"), "Go", "lower case only"},
+		{"email", validate.Email(), "gopher@example.com", ""},
+		{"email with name", validate.Email(), "Gopher <gopher@example.com>", "must be a valid email address"},
+		{"url", validate.URL(), "https://example.com/", ""},
+		{"url without host", validate.URL(), "example.com", "must be a valid URL"},
+		{"int range", validate.IntRange(1, 10), " 10 ", ""},
+		{"int range out", validate.IntRange(1, 10), "11", "must be between 1 and 10"},
+		{"int range not a number", validate.IntRange(1, 10), "ten", "must be a whole number"},
+		{"min length", validate.MinLength(3), "héé", ""},
+		{"min length short", validate.MinLength(3), "hé", "must be at least 3 characters long"},
+		{"max length long", validate.MaxLength(2), "héé", "must be at most 2 characters long"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.v(tt.input)
+			if got := errText(err); got != tt.want {
+				t.Errorf("validator(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorFormat(t *testing.T) {
+	err, ok := validate.MinLength(3)("a").(*validate.Error)
+	if !ok || err.Format != "must be at least %d characters long" || len(err.Args) != 1 || err.Args[0] != 3 {
+		t.Fatalf("MinLength(3)(\"a\") = %#v, want its format and arguments apart", err)
+	}
+}
+
+func TestParse(t *testing.T) {
+	v, err := validate.Parse("required, max=5, range=1:99")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for input, want := range map[string]string{
+		"42":     "",
+		"":       "a value is required",
+		"123456": "must be at most 5 characters long",
+		"100":    "must be between 1 and 99",
+	} {
+		if got := errText(v(input)); got != want {
+			t.Errorf("validator(%q) = %q, want %q", input, got, want)
+		}
+	}
+	for spec, want := range map[string]string{
+		"min=x":     `validate: "min=x": needs a length`,
+		"range=1":   `validate: "range=1": needs MIN:MAX`,
+		"lowercase": `validate: "lowercase": unknown rule`,
+	} {
+		if _, err := validate.Parse(spec); errText(err) != want {
+			t.Errorf("Parse(%q) returned %v, want %s", spec, err, want)
+		}
+	}
+}
+
+func TestParseCount(t *testing.T) {
+	tests := []struct {
+		spec     string
+		min, max int
+		err      string
+	}{
+		{"", 0, 0, ""},
+		{"required", 1, 0, ""},
+		{"min=2,max=3", 2, 3, ""},
+		{"required,min=2", 2, 0, ""},
+		{"max=two", 0, 0, `validate: "max=two": needs a count`},
+		{"email", 0, 0, `validate: "email": does not apply to choices`},
+	}
+	for _, tt := range tests {
+		min, max, err := validate.ParseCount(tt.spec)
+		if min != tt.min || max != tt.max || errText(err) != tt.err {
+			t.Errorf("ParseCount(%q) = %d, %d, %v, want %d, %d, %s", tt.spec, min, max, err, tt.min, tt.max, tt.err)
+		}
+	}
+}
+
+// errText returns the message of err, or "" if it is nil.
+func errText(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,299 @@
+package prompts
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// EditMode selects the keys that edit the text of Input.
+type EditMode int
+
+const (
+	// EditAuto uses vi keys if the readline init file says
+	// "set editing-mode vi", and emacs keys otherwise. The init file is
+	// the one named by INPUTRC, or ~/.inputrc.
+	EditAuto EditMode = iota
+	// EditEmacs uses the readline keys of the Keymap.
+	EditEmacs
+	// EditVi starts in insert mode, where the keys are those of EditEmacs,
+	// and switches to normal mode on Esc. Normal mode has the motions
+	// h, l, 0, ^, $, w, b and e, the commands x, X, D, C, s, S, r, i, a,
+	// I, A and u, and the operators d and c with a motion, a d or c of
+	// their own for the whole line, or the text objects iw and aw.
+	EditVi
+)
+
+// viEnabled reports whether the readline init file asks for vi keys.
+var viEnabled = func() func() bool {
+	var once sync.Once
+	var vi bool
+	return func() bool {
+		once.Do(func() { vi = inputrcVi() })
+		return vi
+	}
+}()
+
+func inputrcVi() bool {
+	name := os.Getenv("INPUTRC")
+	if name == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return false
+		}
+		name = filepath.Join(home, ".inputrc")
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	vi := false
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 3 && fields[0] == "set" && strings.EqualFold(fields[1], "editing-mode") {
+			vi = strings.EqualFold(fields[2], "vi")
+		}
+	}
+	return vi
+}
+
+// viLine edits a line with vi keys.
+type viLine struct {
+	// normal is set in normal mode and unset in insert mode.
+	normal bool
+	// pending is the start of an unfinished command, such as "d" or "ci".
+	pending string
+	// saved is the line before the last change, for undoing it.
+	saved    []rune
+	savedPos int
+}
+
+// captures reports whether the key is taken by the editor
+// before the prompt gets to handle it.
+func (v *viLine) captures(k key) bool {
+	return !v.normal && k.name == "esc"
+}
+
+// edit applies a key to the line and reports whether it was one
+// the editor handles. Normal mode handles all keys but Enter and Ctrl+D.
+func (v *viLine) edit(l *line, k key, km *Keymap) bool {
+	if !v.normal {
+		if k.name == "esc" {
+			v.normal = true
+			l.left()
+			return true
+		}
+		return l.edit(k, km)
+	}
+	if k.name == "enter" || k.name == "ctrl+d" || bound(km.Submit, k) {
+		return false
+	}
+	if v.pending != "" {
+		v.operate(l, k)
+	} else {
+		v.command(l, k, km)
+	}
+	if v.normal && l.pos >= len(l.buf) && l.pos > 0 {
+		l.pos = l.before(len(l.buf))
+	}
+	return true
+}
+
+func (v *viLine) command(l *line, k key, km *Keymap) {
+	switch {
+	case k.name == "h" || bound(km.Left, k) || k.name == "backspace":
+		l.left()
+	case k.name == "l" || bound(km.Right, k) || k.name == "space":
+		l.right()
+	case k.name == "0" || bound(km.Home, k):
+		l.pos = 0
+	case k.name == "^":
+		l.pos = 0
+		for l.pos < len(l.buf) && unicode.IsSpace(l.buf[l.pos]) {
+			l.pos++
+		}
+	case k.name == "$" || bound(km.End, k):
+		l.pos = len(l.buf)
+	case k.name == "w" || k.name == "b" || k.name == "e":
+		l.pos = viMotion(l, k.name)
+	case k.name == "x" || k.name == "delete":
+		v.save(l)
+		l.delete()
+	case k.name == "X":
+		v.save(l)
+		l.backspace()
+	case k.name == "D" || k.name == "C":
+		v.save(l)
+		l.deleteRange(l.pos, len(l.buf))
+		v.normal = k.name == "D"
+	case k.name == "s":
+		v.save(l)
+		l.delete()
+		v.normal = false
+	case k.name == "S":
+		v.save(l)
+		l.deleteRange(0, len(l.buf))
+		v.normal = false
+	case k.name == "i" || k.name == "a" || k.name == "I" || k.name == "A":
+		v.save(l)
+		switch k.name {
+		case "a":
+			l.right()
+		case "I":
+			l.pos = 0
+		case "A":
+			l.pos = len(l.buf)
+		}
+		v.normal = false
+	case k.name == "u":
+		buf, pos := l.buf, l.pos
+		l.buf, l.pos = append([]rune(nil), v.saved...), v.savedPos
+		v.saved, v.savedPos = buf, pos
+	case k.name == "r" || k.name == "d" || k.name == "c":
+		v.pending = k.name
+	}
+}
+
+// operate finishes the pending command with k.
+func (v *viLine) operate(l *line, k key) {
+	op := v.pending
+	v.pending = ""
+	if op == "r" {
+		if k.r != 0 && l.pos < len(l.buf) {
+			v.save(l)
+			l.buf[l.pos] = k.r
+		}
+		return
+	}
+	from, to := l.pos, l.pos
+	switch name := k.name; {
+	case len(op) == 2:
+		if name != "w" {
+			return
+		}
+		from, to = viWord(l, op[1] == 'a')
+		op = op[:1]
+	case name == "i" || name == "a":
+		v.pending = op + name
+		return
+	case name == string(op[0]):
+		from, to = 0, len(l.buf)
+	case name == "0":
+		from = 0
+	case name == "$":
+		to = len(l.buf)
+	case name == "b":
+		from = viMotion(l, "b")
+	case name == "e" || name == "w" && op == "c":
+		// As in vi, cw changes to the end of the word.
+		to = viMotion(l, "e") + 1
+		if to > len(l.buf) {
+			to = len(l.buf)
+		}
+	case name == "w":
+		to = viMotion(l, "w")
+		if to == l.pos {
+			to = len(l.buf)
+		}
+	default:
+		return
+	}
+	v.save(l)
+	l.deleteRange(from, to)
+	v.normal = op == "d"
+}
+
+func (v *viLine) save(l *line) {
+	v.saved, v.savedPos = append(v.saved[:0], l.buf...), l.pos
+}
+
+// viClass returns the class of r for vi word motions:
+// white space, word characters or other characters.
+func viClass(r rune) int {
+	switch {
+	case unicode.IsSpace(r):
+		return 0
+	case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+		return 1
+	}
+	return 2
+}
+
+// viMotion returns where the motion w, b or e moves the cursor of l.
+func viMotion(l *line, motion string) int {
+	buf, i := l.buf, l.pos
+	switch motion {
+	case "w":
+		if i < len(buf) {
+			c := viClass(buf[i])
+			for i < len(buf) && c != 0 && viClass(buf[i]) == c {
+				i++
+			}
+		}
+		for i < len(buf) && viClass(buf[i]) == 0 {
+			i++
+		}
+		if i >= len(buf) {
+			return l.pos
+		}
+	case "e":
+		i++
+		for i < len(buf) && viClass(buf[i]) == 0 {
+			i++
+		}
+		if i >= len(buf) {
+			return len(buf) - 1
+		}
+		c := viClass(buf[i])
+		for i+1 < len(buf) && viClass(buf[i+1]) == c {
+			i++
+		}
+	case "b":
+		for i > 0 && viClass(buf[i-1]) == 0 {
+			i--
+		}
+		if i > 0 {
+			c := viClass(buf[i-1])
+			for i > 0 && viClass(buf[i-1]) == c {
+				i--
+			}
+		}
+	}
+	return i
+}
+
+// viWord returns the bounds of the word under the cursor of l, with the
+// white space after it, or before it if there is none after, when around
+// is set.
+func viWord(l *line, around bool) (int, int) {
+	buf := l.buf
+	if len(buf) == 0 {
+		return 0, 0
+	}
+	c := viClass(buf[l.pos])
+	from, to := l.pos, l.pos
+	for from > 0 && viClass(buf[from-1]) == c {
+		from--
+	}
+	for to < len(buf) && viClass(buf[to]) == c {
+		to++
+	}
+	if around && c != 0 {
+		end := to
+		for end < len(buf) && viClass(buf[end]) == 0 {
+			end++
+		}
+		if end > to {
+			return from, end
+		}
+		for from > 0 && viClass(buf[from-1]) == 0 {
+			from--
+		}
+	}
+	return from, to
+}
@@ -0,0 +1,169 @@
+package prompts
+
+import (
+	"context"
+	"errors"
+)
+
+// Wizard asks a series of questions as numbered steps, like a Form, but
+// lets the user press Esc to go back to the previous step and change its
+// answer, which the question then starts from. The Back keys of the
+// Keymap go back instead of Esc if they are set.
+//
+// Steps have no way back when the standard input is not a terminal.
+type Wizard struct {
+	opts   []Option
+	fields []formField
+}
+
+// NewWizard returns a Wizard without steps. The options apply to all its
+// questions, which can override them with their own.
+func NewWizard(opts ...Option) *Wizard {
+	return &Wizard{opts: opts}
+}
+
+// Add appends a step asking q for the field name and returns w.
+// The name is also the key of the question in answers files.
+func (w *Wizard) Add(name string, q *Question) *Wizard {
+	w.fields = append(w.fields, formField{name: name, q: q})
+	return w
+}
+
+// Run asks the steps in order and stores the answers in v as Form.Run
+// does, once all of them are given. Steps with a When condition that does
+// not hold are skipped and not counted. With WithReview, the answers are
+// reviewed before they are stored. Run stops at the first step that
+// fails and returns its error.
+func (w *Wizard) Run(v interface{}) error {
+	return w.RunContext(context.Background(), v)
+}
+
+// RunContext is like Run but gives up when ctx is done,
+// returning ctx.Err().
+func (w *Wizard) RunContext(ctx context.Context, v interface{}) error {
+	rv, err := target(v)
+	if err != nil {
+		return err
+	}
+	answers := Answers{}
+	steps := make([]step, len(w.fields))
+	// asked holds the steps that were asked, to go back through them.
+	var asked []int
+	for i := 0; i < len(w.fields); {
+		field, st := w.fields[i], &steps[i]
+		opts := fieldOptions(w.opts, field.name)
+		if !field.q.asks(answers, opts) {
+			delete(answers, field.name)
+			i++
+			continue
+		}
+		st.number, st.count = len(asked)+1, len(asked)+w.remaining(i, answers)
+		st.back = len(asked) > 0
+		st.rows = 0
+		opts = append(opts, withStep(st))
+		if answer, ok := answers[field.name]; ok {
+			opts = append(opts, withInitial(answer))
+		}
+		answer, err := field.q.Ask(ctx, opts...)
+		if err == errBack {
+			i, asked = asked[len(asked)-1], asked[:len(asked)-1]
+			steps[i].erase = steps[i].rows
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		answers[field.name] = answer
+		asked = append(asked, i)
+		i++
+	}
+	if err := review(ctx, w.opts, w.fields, answers); err != nil {
+		return err
+	}
+	if err := printAnswers(w.opts, w.fields, answers); err != nil {
+		return err
+	}
+	return bindAll(rv, w.fields, answers)
+}
+
+// remaining returns how many steps from the i-th one on are to be asked
+// after the answers, as far as it can tell before they are given.
+func (w *Wizard) remaining(i int, answers Answers) int {
+	n := 0
+	for _, field := range w.fields[i:] {
+		if field.q.asks(answers, fieldOptions(w.opts, field.name)) {
+			n++
+		}
+	}
+	return n
+}
+
+// errBack is returned by a step when the user goes back.
+var errBack = errors.New("prompts: back")
+
+// step is the part a prompt plays in a Wizard.
+type step struct {
+	// number and count are the number of the step and of all the steps
+	// in the header shown above the prompt.
+	number, count int
+	// back lets the user go back to the previous step.
+	back bool
+	// erase is the number of rows above the prompt it replaces,
+	// which the prompt resets once it has done so.
+	erase int
+	// rows is the number of rows the prompt leaves on the screen.
+	rows int
+}
+
+// header renders the header of the step.
+func (st *step) header(t *Theme) string {
+	return t.textf("Step %d/%d", st.number, st.count)
+}
+
+func withStep(st *step) Option {
+	return optionFunc(func(c *config) { c.step = st })
+}
+
+func withInitial(v interface{}) Option {
+	return optionFunc(func(c *config) { c.initial = v })
+}
+
+// keyCapturer is a model that needs some keys
+// that would otherwise be taken by a Wizard.
+type keyCapturer interface {
+	captures(k key) bool
+}
+
+// stepModel shows the header of a step above a prompt
+// and goes back on Esc.
+type stepModel struct {
+	model
+	theme *Theme
+	keys  *Keymap
+	step  *step
+	done  bool
+}
+
+func (m *stepModel) update(k key) (bool, error) {
+	c, ok := m.model.(keyCapturer)
+	if bound(m.keys.Back, k) && m.step.back && !(ok && c.captures(k)) {
+		return false, errBack
+	}
+	done, err := m.model.update(k)
+	m.done = done
+	return done, err
+}
+
+func (m *stepModel) view() frame {
+	f := m.model.view()
+	if m.done {
+		return f
+	}
+	header := m.step.header(m.theme)
+	if m.step.back && len(m.keys.Back) > 0 {
+		header += " (" + m.theme.textf("%s to go back", keyLabel(m.keys.Back)) + ")"
+	}
+	f.lines = append([]string{m.theme.render(m.theme.Hint, header)}, f.lines...)
+	f.cursorRow++
+	return f
+}
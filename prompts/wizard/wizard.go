@@ -0,0 +1,368 @@
+// Package wizard runs prompts.Wizard flows described in YAML, so that
+// they can be written and changed without recompiling the program:
+//
+//	review: true
+//	steps:
+//	  - name: name
+//	    prompt: input
+//	    label: Project name
+//	    validate: required,max=40
+//	  - name: kind
+//	    prompt: select
+//	    label: What kind of project?
+//	    options: [web, cli, library]
+//	  - name: port
+//	    prompt: number
+//	    label: Which port does it listen on?
+//	    default: 8080
+//	    min: 1
+//	    max: 65535
+//	    when:
+//	      kind: web
+//
+// The flow is run with
+//
+//	answers, err := wizard.Load("init.yaml").Run()
+package wizard
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+	rules "github.com/tidalmigrations/interactive-cli-prompts/prompts/validate"
+)
+
+// Flow is a wizard loaded from its YAML definition.
+type Flow struct {
+	w   *prompts.Wizard
+	err error
+}
+
+// Load reads the definition of a flow from the YAML file at path.
+// The options apply to all its steps. If the file cannot be read or does
+// not define a flow, the error is returned by Err and Run.
+func Load(path string, opts ...prompts.Option) *Flow {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return &Flow{err: fmt.Errorf("wizard: %w", err)}
+	}
+	f := Parse(b, opts...)
+	if f.err != nil {
+		f.err = fmt.Errorf("wizard: %s: %w", path, errors.Unwrap(f.err))
+	}
+	return f
+}
+
+// Parse reads the definition of a flow from YAML, as Load does.
+func Parse(b []byte, opts ...prompts.Option) *Flow {
+	var def definition
+	d := yaml.NewDecoder(bytes.NewReader(b))
+	d.KnownFields(true)
+	if err := d.Decode(&def); err != nil {
+		return &Flow{err: fmt.Errorf("wizard: %w", err)}
+	}
+	w, err := def.wizard(opts)
+	if err != nil {
+		return &Flow{err: fmt.Errorf("wizard: %w", err)}
+	}
+	return &Flow{w: w}
+}
+
+// Err returns the error that kept the flow from loading, if any.
+func (f *Flow) Err() error {
+	return f.err
+}
+
+// Run asks the steps of the flow and returns the answers by step name.
+// Inputs, passwords and selects answer strings, confirms and toggles
+// bools, numbers and sliders float64s, dates time.Times, ratings ints,
+// and checkboxes and tags []strings.
+func (f *Flow) Run() (map[string]interface{}, error) {
+	return f.RunContext(context.Background())
+}
+
+// RunContext is like Run but gives up when ctx is done,
+// returning ctx.Err().
+func (f *Flow) RunContext(ctx context.Context) (map[string]interface{}, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	answers := map[string]interface{}{}
+	if err := f.w.RunContext(ctx, &answers); err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+// definition is the YAML document describing a flow.
+type definition struct {
+	// Review reviews the answers at the end, as prompts.WithReview does.
+	Review bool       `yaml:"review"`
+	Steps  []stepSpec `yaml:"steps"`
+}
+
+// stepSpec describes a step of a flow.
+type stepSpec struct {
+	// Name is the key of the answer, and of the step in answers files.
+	Name string `yaml:"name"`
+	// Prompt is the kind of prompt asking the step, input by default.
+	Prompt string `yaml:"prompt"`
+	// Label is the question, the name by default.
+	Label       string      `yaml:"label"`
+	Default     interface{} `yaml:"default"`
+	Placeholder string      `yaml:"placeholder"`
+	// Options are the choices of selects and checkboxes,
+	// and the suggestions of tags.
+	Options []string `yaml:"options"`
+	// Validate is a validator spec, as parsed by validate.Parse, or for
+	// checkboxes bounds on the number checked, as parsed by
+	// validate.ParseCount. Confirms and toggles take none.
+	Validate string `yaml:"validate"`
+	// Min, Max and Step bound numbers and sliders,
+	// and Max is the number of stars of ratings.
+	Min  *float64 `yaml:"min"`
+	Max  *float64 `yaml:"max"`
+	Step float64  `yaml:"step"`
+	// When and Unless map the names of earlier steps to the answers
+	// for which the step is asked, or skipped.
+	When   map[string]interface{} `yaml:"when"`
+	Unless map[string]interface{} `yaml:"unless"`
+}
+
+// wizard returns the wizard asking the steps of def.
+func (def *definition) wizard(opts []prompts.Option) (*prompts.Wizard, error) {
+	if len(def.Steps) == 0 {
+		return nil, errors.New("no steps")
+	}
+	if def.Review {
+		opts = append(opts, prompts.WithReview())
+	}
+	w := prompts.NewWizard(opts...)
+	seen := map[string]bool{}
+	for i := range def.Steps {
+		st := &def.Steps[i]
+		if st.Name == "" {
+			return nil, fmt.Errorf("step %d has no name", i+1)
+		}
+		if seen[st.Name] {
+			return nil, fmt.Errorf("step %s is defined twice", st.Name)
+		}
+		for _, cond := range []map[string]interface{}{st.When, st.Unless} {
+			for name := range cond {
+				if !seen[name] {
+					return nil, fmt.Errorf("step %s depends on %s, which is not an earlier step", st.Name, name)
+				}
+			}
+		}
+		seen[st.Name] = true
+		q, err := st.question()
+		if err != nil {
+			return nil, fmt.Errorf("step %s: %w", st.Name, err)
+		}
+		w.Add(st.Name, q)
+	}
+	return w, nil
+}
+
+// question returns the question asking the step.
+func (st *stepSpec) question() (*prompts.Question, error) {
+	label := st.Label
+	if label == "" {
+		label = st.Name
+	}
+	var opts []prompts.Option
+	switch {
+	case st.Validate == "" || st.Prompt == "checkboxes":
+	case st.Prompt == "confirm" || st.Prompt == "toggle":
+		return nil, fmt.Errorf("validate is not supported for %s", st.Prompt)
+	default:
+		v, err := rules.Parse(st.Validate)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, prompts.WithValidator(v))
+	}
+	if st.When != nil || st.Unless != nil {
+		opts = append(opts, prompts.When(func(a prompts.Answers) bool {
+			return holds(st.When, a, true) && !holds(st.Unless, a, false)
+		}))
+	}
+	min, max := math.Inf(-1), math.Inf(1)
+	if st.Min != nil {
+		min = *st.Min
+	}
+	if st.Max != nil {
+		max = *st.Max
+	}
+	def := ""
+	if st.Default != nil {
+		def = fmt.Sprint(st.Default)
+	}
+	input := prompts.InputOptions{Default: def, Placeholder: st.Placeholder}
+	switch st.Prompt {
+	case "", "input":
+		return prompts.InputQuestion(label, append(opts, input)...), nil
+	case "password":
+		return prompts.PasswordQuestion(label, opts...), nil
+	case "multiline":
+		return prompts.MultilineQuestion(label, append(opts, input)...), nil
+	case "editor":
+		return prompts.EditorQuestion(label, def, opts...), nil
+	case "path":
+		return prompts.FilePathQuestion(label, append(opts, input)...), nil
+	case "confirm", "toggle":
+		b, ok := st.Default.(bool)
+		if st.Default != nil && !ok {
+			return nil, fmt.Errorf("default %v is not true or false", st.Default)
+		}
+		if st.Prompt == "toggle" {
+			return prompts.ToggleQuestion(label, b, opts...), nil
+		}
+		return prompts.ConfirmQuestion(label, b, opts...), nil
+	case "select", "checkboxes":
+		if len(st.Options) == 0 {
+			return nil, fmt.Errorf("%s needs options", st.Prompt)
+		}
+		if st.Prompt == "select" {
+			opts = append(opts, prompts.SelectOptions{Default: def})
+			return prompts.SelectQuestion(label, st.Options, opts...), nil
+		}
+		list, err := stringList(st.Default)
+		if err != nil {
+			return nil, err
+		}
+		o := prompts.CheckboxesOptions{Default: list}
+		if st.Validate != "" {
+			if o.MinSelected, o.MaxSelected, err = rules.ParseCount(st.Validate); err != nil {
+				return nil, err
+			}
+		}
+		opts = append(opts, o)
+		return prompts.CheckboxesQuestion(label, st.Options, opts...), nil
+	case "tags":
+		list, err := stringList(st.Default)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, prompts.TagsOptions{Default: list, Suggestions: st.Options})
+		return prompts.TagsQuestion(label, opts...), nil
+	case "number":
+		return prompts.NumberQuestion(label, min, max, st.Step, append(opts, input)...), nil
+	case "slider":
+		if st.Min == nil || st.Max == nil {
+			return nil, errors.New("slider needs min and max")
+		}
+		var f float64
+		switch d := st.Default.(type) {
+		case nil:
+		case float64:
+			f = d
+		case int:
+			f = float64(d)
+		default:
+			return nil, fmt.Errorf("default %v is not a number", st.Default)
+		}
+		opts = append(opts, prompts.SliderOptions{Default: f, Step: st.Step})
+		return prompts.SliderQuestion(label, min, max, opts...), nil
+	case "rating":
+		var n int
+		switch d := st.Default.(type) {
+		case nil:
+		case int:
+			n = d
+		case float64:
+			// 3.0 is a whole number too.
+			if n = int(d); float64(n) != d {
+				return nil, fmt.Errorf("default %v is not a whole number", st.Default)
+			}
+		default:
+			return nil, fmt.Errorf("default %v is not a whole number", st.Default)
+		}
+		stars := 5
+		if st.Max != nil {
+			stars = int(*st.Max)
+		}
+		return prompts.RatingQuestion(label, stars, append(opts, prompts.RatingOptions{Default: n})...), nil
+	case "date":
+		switch d := st.Default.(type) {
+		case nil:
+		case time.Time:
+			// YAML reads unquoted dates as such, at midnight UTC.
+			y, m, day := d.Date()
+			opts = append(opts, prompts.DateOptions{Default: time.Date(y, m, day, 0, 0, 0, 0, time.Local)})
+		case string:
+			t, err := time.ParseInLocation("2006-01-02", d, time.Local)
+			if err != nil {
+				return nil, fmt.Errorf("default %q is not a date", def)
+			}
+			opts = append(opts, prompts.DateOptions{Default: t})
+		default:
+			return nil, fmt.Errorf("default %v is not a date", st.Default)
+		}
+		return prompts.DateQuestion(label, opts...), nil
+	}
+	return nil, fmt.Errorf("unknown prompt %q", st.Prompt)
+}
+
+// stringList returns the default of a step answered with a list.
+func stringList(v interface{}) ([]string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	items, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("default %v is not a list", v)
+	}
+	list := make([]string, len(items))
+	for i, item := range items {
+		list[i] = fmt.Sprint(item)
+	}
+	return list, nil
+}
+
+// holds reports whether each step named in cond has one of the answers
+// cond maps it to in a, or ifEmpty if cond is empty. An answer that is a
+// list matches when it holds the value, and a list of values when it
+// holds any of them.
+func holds(cond map[string]interface{}, a prompts.Answers, ifEmpty bool) bool {
+	if len(cond) == 0 {
+		return ifEmpty
+	}
+	for name, want := range cond {
+		answer, ok := a[name]
+		if !ok {
+			return false
+		}
+		wants, ok := want.([]interface{})
+		if !ok {
+			wants = []interface{}{want}
+		}
+		if !matches(answer, wants) {
+			return false
+		}
+	}
+	return true
+}
+
+func matches(answer interface{}, wants []interface{}) bool {
+	got := []string{fmt.Sprint(answer)}
+	if list, ok := answer.([]string); ok {
+		got = list
+	}
+	for _, want := range wants {
+		for _, g := range got {
+			if g == fmt.Sprint(want) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package wizard_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/prompttest"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/wizard"
+)
+
+// run runs the flow defined by def on a terminal, answering each step with
+// keys once its label shows.
+func run(t *testing.T, def string, steps ...[2]string) map[string]interface{} {
+	t.Helper()
+	term := prompttest.New(t)
+	res := prompttest.Start(term, func(o prompts.Option) (map[string]interface{}, error) {
+		return wizard.Parse([]byte(def), o).Run()
+	})
+	for _, st := range steps {
+		term.WaitFor(st[0])
+		term.Send(st[1])
+	}
+	answers, err := res.Wait()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return answers
+}
+
+func TestRun(t *testing.T) {
+	def := `
+steps:
+  - name: name
+    label: Project name
+    validate: required
+  - name: kind
+    prompt: select
+    label: What kind?
+    options: [web, cli]
+  - name: port
+    prompt: number
+    label: Port?
+    default: 8080
+    when:
+      kind: web
+  - name: docs
+    prompt: confirm
+    label: Docs?
+    unless:
+      kind: web
+`
+	answers := run(t, def,
+		[2]string{"Project name", "demo" + prompttest.Enter},
+		[2]string{"What kind?", prompttest.Enter},
+		[2]string{"Port?", prompttest.Enter},
+	)
+	want := map[string]interface{}{"name": "demo", "kind": "web", "port": 8080.0}
+	if !reflect.DeepEqual(answers, want) {
+		t.Errorf("Run() = %v, want %v", answers, want)
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	march4 := time.Date(2026, time.March, 4, 0, 0, 0, 0, time.Local)
+	tests := []struct {
+		name, step, label string
+		want              interface{}
+	}{
+		{"unquoted date", "prompt: date\n    default: 2026-03-04", "When?", march4},
+		{"quoted date", "prompt: date\n    default: \"2026-03-04\"", "When?", march4},
+		{"rating", "prompt: rating\n    default: 3", "When?", 3},
+		{"rating written as a float", "prompt: rating\n    default: 3.0", "When?", 3},
+		{"slider", "prompt: slider\n    min: 0\n    max: 10\n    default: 4", "When?", 4.0},
+		{"select", "prompt: select\n    options: [a, b, c]\n    default: b", "When?", "b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def := "steps:\n  - name: s\n    label: When?\n    " + tt.step + "\n"
+			answers := run(t, def, [2]string{tt.label, prompttest.Enter})
+			got := answers["s"]
+			if d, ok := got.(time.Time); ok {
+				got = d.In(time.Local)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Run() answered %v (%T), want %v (%T)", got, got, tt.want, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name, step, err string
+	}{
+		{"unknown prompt", "prompt: dial", `unknown prompt "dial"`},
+		{"date", "prompt: date\n    default: tomorrow", `default "tomorrow" is not a date`},
+		{"date number", "prompt: date\n    default: 20260304", "default 20260304 is not a date"},
+		{"rating fraction", "prompt: rating\n    default: 3.5", "default 3.5 is not a whole number"},
+		{"rating text", "prompt: rating\n    default: three", "default three is not a whole number"},
+		{"slider text", "prompt: slider\n    min: 0\n    max: 10\n    default: half", "default half is not a number"},
+		{"slider without range", "prompt: slider", "slider needs min and max"},
+		{"confirm", "prompt: confirm\n    default: maybe", "default maybe is not true or false"},
+		{"validate on confirm", "prompt: confirm\n    validate: required", "validate is not supported for confirm"},
+		{"select without options", "prompt: select", "select needs options"},
+		{"checkboxes validate", "prompt: checkboxes\n    options: [a]\n    validate: email", `validate: "email": does not apply to choices`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def := "steps:\n  - name: s\n    " + tt.step + "\n"
+			err := wizard.Parse([]byte(def)).Err()
+			if err == nil || !strings.Contains(err.Error(), "step s: "+tt.err) {
+				t.Errorf("Parse() failed with %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
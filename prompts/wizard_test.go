@@ -0,0 +1,53 @@
+package prompts_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts/prompttest"
+)
+
+func TestWizardBack(t *testing.T) {
+	answers := map[string]interface{}{}
+	term := prompttest.New(t)
+	res := prompttest.Start(term, func(o prompts.Option) (bool, error) {
+		w := prompts.NewWizard(o)
+		w.Add("name", prompts.InputQuestion("Name?"))
+		w.Add("tier", prompts.SelectQuestion("Tier?", []string{"free", "pro"}))
+		return true, w.Run(&answers)
+	})
+	term.WaitFor("Step 1/2")
+	term.Send("billing" + prompttest.Enter)
+	term.WaitFor("Step 2/2")
+	term.Send(prompttest.Esc)
+	// The step starts from its earlier answer.
+	term.WaitFor("Step 1/2")
+	if got, want := term.Screen(), "Step 1/2\nName? billing"; got != want {
+		t.Errorf("screen shows %q after going back, want %q", got, want)
+	}
+	term.Send("-eu" + prompttest.Enter)
+	term.WaitFor("billing-eu\nStep 2/2")
+	term.Send(prompttest.Down + prompttest.Enter)
+	if _, err := res.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if answers["name"] != "billing-eu" || answers["tier"] != "pro" {
+		t.Errorf("Run() stored %v, want map[name:billing-eu tier:pro]", answers)
+	}
+}
+
+func TestWizardSkipped(t *testing.T) {
+	answers := map[string]interface{}{}
+	w := prompts.NewWizard(prompts.WithInput(strings.NewReader("n\nbilling\n")), prompts.WithOutput(&strings.Builder{}))
+	w.Add("external", prompts.ConfirmQuestion("Use an external database?", false))
+	w.Add("host", prompts.InputQuestion("Which database host?",
+		prompts.When(func(a prompts.Answers) bool { return a["external"] == true })))
+	w.Add("name", prompts.InputQuestion("Name?"))
+	if err := w.Run(&answers); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := answers["host"]; ok || answers["name"] != "billing" {
+		t.Errorf("Run() stored %v, want map[external:false name:billing]", answers)
+	}
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+)
+
+func main() {
+	lang, _, err := prompts.Select(
+		"What is your favourite programming language?",
+		[]string{"C", "Go", "Python", "Rust", "JavaScript"},
+	)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(prompts.ExitCode(err))
+	}
+	fmt.Println("Oh, I see! You like", lang)
+}
@@ -1,27 +1,19 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"os"
-	"strings"
-)
 
-// StringPrompt asks for a string value using the label
-func StringPrompt(label string) string {
-	var s string
-	r := bufio.NewReader(os.Stdin)
-	for {
-		fmt.Fprint(os.Stderr, label+" ")
-		s, _ = r.ReadString('\n')
-		if s != "" {
-			break
-		}
-	}
-	return strings.TrimSpace(s)
-}
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+)
 
 func main() {
-	name := StringPrompt("What is your name?")
+	name, err := prompts.Input("What is your name?", prompts.InputOptions{
+		Default: "Gopher",
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(prompts.ExitCode(err))
+	}
 	fmt.Printf("Hello, %s!\n", name)
 }
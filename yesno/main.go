@@ -1,41 +1,18 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"os"
-	"strings"
-)
-
-// YesNoPrompt asks yes/no questions using the label.
-func YesNoPrompt(label string, def bool) bool {
-	choices := "Y/n"
-	if !def {
-		choices = "y/N"
-	}
-
-	r := bufio.NewReader(os.Stdin)
-	var s string
 
-	for {
-		fmt.Fprintf(os.Stderr, "%s (%s) ", label, choices)
-		s, _ = r.ReadString('\n')
-		s = strings.TrimSpace(s)
-		if s == "" {
-			return def
-		}
-		s = strings.ToLower(s)
-		if s == "y" || s == "yes" {
-			return true
-		}
-		if s == "n" || s == "no" {
-			return false
-		}
-	}
-}
+	"github.com/tidalmigrations/interactive-cli-prompts/prompts"
+)
 
 func main() {
-	ok := YesNoPrompt("Dev.to is awesome!", true)
+	ok, err := prompts.Confirm("Dev.to is awesome!", true)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(prompts.ExitCode(err))
+	}
 	if ok {
 		fmt.Println("Agree!")
 	} else {